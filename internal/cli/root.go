@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/alexkarsten/reducto/internal/config"
 	"github.com/alexkarsten/reducto/internal/git"
 	"github.com/alexkarsten/reducto/internal/mcp"
 	"github.com/alexkarsten/reducto/internal/reporter"
+	"github.com/alexkarsten/reducto/internal/runner"
 	"github.com/alexkarsten/reducto/internal/sidecar"
 	"github.com/alexkarsten/reducto/pkg/models"
 	"github.com/spf13/cobra"
@@ -138,6 +142,15 @@ func runAnalyzeWithReport(path string) error {
 		}
 	}
 
+	rep := reporter.New(cfg)
+	if err := rep.GenerateBaseline(newBaselineResult(result)); err != nil {
+		return fmt.Errorf("failed to generate baseline report: %w", err)
+	}
+
+	return nil
+}
+
+func newBaselineResult(result *sidecar.AnalyzeResult) *reporter.BaselineResult {
 	baseline := &reporter.BaselineResult{
 		TotalFiles:   result.TotalFiles,
 		TotalSymbols: result.TotalSymbols,
@@ -152,13 +165,16 @@ func runAnalyzeWithReport(path string) error {
 			CognitiveComplexity:  hs.CognitiveComplexity,
 		}
 	}
-
-	rep := reporter.New(cfg)
-	if err := rep.GenerateBaseline(baseline); err != nil {
-		return fmt.Errorf("failed to generate baseline report: %w", err)
+	for _, sym := range result.Symbols {
+		baseline.Symbols = append(baseline.Symbols, reporter.ComplexityHotspot{
+			File:                 sym.File,
+			Line:                 sym.StartLine,
+			Symbol:               sym.Name,
+			CyclomaticComplexity: sym.CyclomaticComplexity,
+		})
+		baseline.SymbolComplexities = append(baseline.SymbolComplexities, sym.CyclomaticComplexity)
 	}
-
-	return nil
+	return baseline
 }
 
 func runDeduplicate(path string, commitChanges bool, generateReport bool) error {
@@ -264,6 +280,84 @@ func runReport(sessionID string) error {
 	return rep.Load(sessionID)
 }
 
+func runPrune(opts reporter.PruneOptions) error {
+	rep := reporter.New(cfg)
+	removed, err := rep.Prune(opts)
+	if err != nil {
+		return err
+	}
+
+	verb := "Removed"
+	if opts.DryRun {
+		verb = "Would remove"
+	}
+	for _, path := range removed {
+		fmt.Printf("%s %s\n", verb, path)
+	}
+	fmt.Printf("%s %d item(s)\n", verb, len(removed))
+	return nil
+}
+
+func runCheckpoints(path string) error {
+	checkpoints, err := git.NewManager(path).ListCheckpoints()
+	if err != nil {
+		return fmt.Errorf("failed to list checkpoints: %w", err)
+	}
+
+	if len(checkpoints) == 0 {
+		fmt.Println("No checkpoints found")
+		return nil
+	}
+
+	for _, cp := range checkpoints {
+		subject, _, _ := strings.Cut(cp.Message, "\n")
+		line := fmt.Sprintf("%s  %s  %s", cp.Hash, cp.When.Format(time.RFC3339), subject)
+		if cp.TestStatus != "" {
+			line += fmt.Sprintf(" [%s]", cp.TestStatus)
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func runExplain(path string) error {
+	r, err := runner.NewFromRepo(path)
+	if err != nil {
+		return err
+	}
+	for _, warning := range r.ConfigWarnings() {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	plan := r.DescribeExecution()
+	fmt.Printf("Project type: %s\n", plan.ProjectType)
+	fmt.Printf("Working dir:  %s\n", plan.WorkDir)
+	fmt.Printf("Timeout:      %v\n", plan.Timeout)
+	fmt.Printf("Test:         %s\n", describeCommand(plan.TestCommand))
+	fmt.Printf("Lint:         %s\n", describeCommand(plan.LintCommand))
+	fmt.Printf("Build:        %s\n", describeCommand(plan.BuildCommand))
+
+	if len(plan.Overrides) > 0 {
+		fmt.Println("Overrides:")
+		keys := make([]string, 0, len(plan.Overrides))
+		for key := range plan.Overrides {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Printf("  %s: %s\n", key, plan.Overrides[key])
+		}
+	}
+	return nil
+}
+
+func describeCommand(cmd []string) string {
+	if len(cmd) == 0 {
+		return "(none)"
+	}
+	return strings.Join(cmd, " ")
+}
+
 func runMCP(path string) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -380,6 +474,52 @@ var reportCmd = &cobra.Command{
 	},
 }
 
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove old reports and snapshots",
+	Long: `Deletes reports, baselines and snapshots in .reducto that are older
+than --max-age or beyond the most recent --keep entries.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		maxAge, _ := cmd.Flags().GetDuration("max-age")
+		keep, _ := cmd.Flags().GetInt("keep")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		return runPrune(reporter.PruneOptions{MaxAge: maxAge, KeepN: keep, DryRun: dryRun})
+	},
+}
+
+var checkpointsCmd = &cobra.Command{
+	Use:   "checkpoints [path]",
+	Short: "List checkpoints created by reducto",
+	Long: `Lists the checkpoint commits reducto created on the current branch,
+newest first. Each hash can be passed to a rollback to restore that state.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		return runCheckpoints(path)
+	},
+}
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [path]",
+	Short: "Show the test, lint and build commands that would run",
+	Long: `Detects the project type and resolves the test, lint and build commands,
+including any overrides from the repository config, without running anything.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		return runExplain(path)
+	},
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the version number",
@@ -417,12 +557,18 @@ func initCommands() {
 	patternCmd.Flags().BoolP("yes", "y", false, "skip approval and apply changes automatically")
 	patternCmd.Flags().Bool("report", false, "generate report after pattern injection")
 	reportCmd.Flags().StringP("session", "s", "", "session ID to report (default: last session)")
+	pruneCmd.Flags().Duration("max-age", 0, "remove entries older than this duration")
+	pruneCmd.Flags().Int("keep", 0, "keep only the most recent N entries of each kind")
+	pruneCmd.Flags().Bool("dry-run", false, "list what would be removed without deleting")
 
 	rootCmd.AddCommand(analyzeCmd)
 	rootCmd.AddCommand(deduplicateCmd)
 	rootCmd.AddCommand(idiomatizeCmd)
 	rootCmd.AddCommand(patternCmd)
 	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(pruneCmd)
+	rootCmd.AddCommand(checkpointsCmd)
+	rootCmd.AddCommand(explainCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(mcpCmd)
 }
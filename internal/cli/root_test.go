@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/internal/reporter"
+	"github.com/alexkarsten/reducto/internal/sidecar"
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestBaselineIncludesComplexityHistogram(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	result := &sidecar.AnalyzeResult{
+		TotalFiles:   2,
+		TotalSymbols: 3,
+		Hotspots: []sidecar.ComplexityHotspot{
+			{File: "b.go", Line: 1, Symbol: "Big", CyclomaticComplexity: 25},
+		},
+		Symbols: []models.Symbol{
+			{Name: "small", File: "a.go", StartLine: 1, EndLine: 3, CyclomaticComplexity: 2},
+			{Name: "medium", File: "a.go", StartLine: 5, EndLine: 20, CyclomaticComplexity: 8},
+			{Name: "Big", File: "b.go", StartLine: 1, EndLine: 90, CyclomaticComplexity: 25},
+		},
+	}
+
+	if err := reporter.New(&models.Config{}).GenerateBaseline(newBaselineResult(result)); err != nil {
+		t.Fatalf("GenerateBaseline returned error: %v", err)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(".reducto", "reducto-baseline-*.md"))
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("expected one baseline report, got %v (err %v)", matches, err)
+	}
+	content, err := os.ReadFile(matches[0])
+	if err != nil {
+		t.Fatalf("failed to read baseline: %v", err)
+	}
+
+	for _, want := range []string{"## Complexity Distribution", "1-5   | ", "6-10  | ", "21+   | "} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("baseline missing %q:\n%s", want, content)
+		}
+	}
+
+	baselines, err := reporter.New(&models.Config{}).LoadBaselines()
+	if err != nil {
+		t.Fatalf("LoadBaselines returned error: %v", err)
+	}
+	if len(baselines) != 1 || len(baselines[0].Symbols) != 3 {
+		t.Fatalf("expected saved baseline with 3 symbols, got %+v", baselines)
+	}
+	if points := reporter.SymbolTrend(baselines)["a.go::medium"]; len(points) != 1 || points[0] != (reporter.TrendPoint{Value: 8, Present: true}) {
+		t.Errorf("expected trend point for a.go::medium, got %v", points)
+	}
+}
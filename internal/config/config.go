@@ -101,6 +101,7 @@ func setDefaults(v *viper.Viper, cfg *models.Config) {
 	v.SetDefault("sidecar.startup_timeout", cfg.Sidecar.StartupTimeout)
 	v.SetDefault("sidecar.shutdown_timeout", cfg.Sidecar.ShutdownTimeout)
 	v.SetDefault("sidecar.auto_install", cfg.Sidecar.AutoInstall)
+	v.SetDefault("sidecar.python_path", cfg.Sidecar.PythonPath)
 
 	v.SetDefault("complexity_thresholds.cyclomatic_complexity", cfg.ComplexityThresholds.CyclomaticComplexity)
 	v.SetDefault("complexity_thresholds.cognitive_complexity", cfg.ComplexityThresholds.CognitiveComplexity)
@@ -0,0 +1,114 @@
+package dedup
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+type Similarity interface {
+	Score(a, b models.CodeBlock) float64
+}
+
+type TokenJaccard struct{}
+
+var tokenRegex = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*|\d+(?:\.\d+)?|[^\sA-Za-z0-9_]`)
+
+func (TokenJaccard) Score(a, b models.CodeBlock) float64 {
+	tokensA := tokenSet(a.Content)
+	tokensB := tokenSet(b.Content)
+
+	if len(tokensA) == 0 && len(tokensB) == 0 {
+		return 0
+	}
+
+	intersection := 0
+	for token := range tokensA {
+		if tokensB[token] {
+			intersection++
+		}
+	}
+
+	union := len(tokensA) + len(tokensB) - intersection
+	return float64(intersection) / float64(union)
+}
+
+func tokenSet(content string) map[string]bool {
+	tokens := make(map[string]bool)
+	for _, token := range tokenRegex.FindAllString(content, -1) {
+		tokens[token] = true
+	}
+	return tokens
+}
+
+func Preview(blocks []models.CodeBlock, sim Similarity, threshold float64) []models.DuplicateGroup {
+	if sim == nil {
+		sim = TokenJaccard{}
+	}
+
+	parent := make([]int, len(blocks))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	minScore := make(map[int]float64)
+	for i := 0; i < len(blocks); i++ {
+		for j := i + 1; j < len(blocks); j++ {
+			score := sim.Score(blocks[i], blocks[j])
+			if score < threshold {
+				continue
+			}
+
+			ri, rj := find(i), find(j)
+			root := ri
+			if rj < ri {
+				root = rj
+			}
+
+			lowest := score
+			for _, r := range []int{ri, rj} {
+				if s, ok := minScore[r]; ok && s < lowest {
+					lowest = s
+				}
+				delete(minScore, r)
+			}
+
+			parent[ri] = root
+			parent[rj] = root
+			minScore[root] = lowest
+		}
+	}
+
+	members := make(map[int][]models.CodeBlock)
+	var roots []int
+	for i, block := range blocks {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], block)
+	}
+
+	var groups []models.DuplicateGroup
+	for _, root := range roots {
+		if len(members[root]) < 2 {
+			continue
+		}
+		groups = append(groups, models.DuplicateGroup{
+			ID:         fmt.Sprintf("preview-%d", len(groups)+1),
+			Blocks:     members[root],
+			Similarity: minScore[root],
+		})
+	}
+
+	return groups
+}
@@ -0,0 +1,111 @@
+package dedup
+
+import (
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+type stubSimilarity map[[2]string]float64
+
+func (s stubSimilarity) Score(a, b models.CodeBlock) float64 {
+	if score, ok := s[[2]string{a.ID, b.ID}]; ok {
+		return score
+	}
+	return s[[2]string{b.ID, a.ID}]
+}
+
+func blockIDs(group models.DuplicateGroup) []string {
+	var ids []string
+	for _, b := range group.Blocks {
+		ids = append(ids, b.ID)
+	}
+	return ids
+}
+
+func TestPreviewCustomSimilarity(t *testing.T) {
+	blocks := []models.CodeBlock{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}, {ID: "e"}}
+	sim := stubSimilarity{
+		{"a", "b"}: 0.95,
+		{"b", "c"}: 0.85,
+		{"d", "e"}: 0.5,
+	}
+
+	tests := []struct {
+		name      string
+		threshold float64
+		expected  [][]string
+		scores    []float64
+	}{
+		{
+			name:      "transitive grouping",
+			threshold: 0.8,
+			expected:  [][]string{{"a", "b", "c"}},
+			scores:    []float64{0.85},
+		},
+		{
+			name:      "high threshold",
+			threshold: 0.9,
+			expected:  [][]string{{"a", "b"}},
+			scores:    []float64{0.95},
+		},
+		{
+			name:      "low threshold",
+			threshold: 0.4,
+			expected:  [][]string{{"a", "b", "c"}, {"d", "e"}},
+			scores:    []float64{0.85, 0.5},
+		},
+		{
+			name:      "nothing above threshold",
+			threshold: 0.99,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := Preview(blocks, sim, tt.threshold)
+			if len(groups) != len(tt.expected) {
+				t.Fatalf("expected %d groups, got %d", len(tt.expected), len(groups))
+			}
+
+			for i, group := range groups {
+				ids := blockIDs(group)
+				if len(ids) != len(tt.expected[i]) {
+					t.Fatalf("group %d: expected %v, got %v", i, tt.expected[i], ids)
+				}
+				for j := range ids {
+					if ids[j] != tt.expected[i][j] {
+						t.Errorf("group %d: expected %v, got %v", i, tt.expected[i], ids)
+						break
+					}
+				}
+				if group.Similarity != tt.scores[i] {
+					t.Errorf("group %d: expected similarity %v, got %v", i, tt.scores[i], group.Similarity)
+				}
+			}
+		})
+	}
+}
+
+func TestPreviewTokenJaccard(t *testing.T) {
+	blocks := []models.CodeBlock{
+		{ID: "1", File: "a.py", Content: "def total(items):\n    return sum(i.price for i in items)"},
+		{ID: "2", File: "b.py", Content: "def total(items):\n    return sum(i.price for i in items)"},
+		{ID: "3", File: "c.py", Content: "class Config:\n    debug = False"},
+	}
+
+	groups := Preview(blocks, TokenJaccard{}, 0.8)
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 group, got %d", len(groups))
+	}
+	if ids := blockIDs(groups[0]); len(ids) != 2 || ids[0] != "1" || ids[1] != "2" {
+		t.Errorf("expected blocks 1 and 2 grouped, got %v", ids)
+	}
+	if groups[0].Similarity != 1 {
+		t.Errorf("expected identical blocks to score 1, got %v", groups[0].Similarity)
+	}
+
+	if score := (TokenJaccard{}).Score(blocks[0], blocks[2]); score >= 0.5 {
+		t.Errorf("expected unrelated blocks to score low, got %v", score)
+	}
+}
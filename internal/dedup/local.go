@@ -0,0 +1,254 @@
+package dedup
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+const (
+	defaultWindowLines = 4
+	defaultMinTokens   = 12
+)
+
+type ScanOptions struct {
+	WindowLines int
+	MinTokens   int
+}
+
+var scanKeywords = map[string]bool{
+	"def": true, "class": true, "return": true, "if": true, "elif": true, "else": true,
+	"for": true, "while": true, "in": true, "not": true, "and": true, "or": true,
+	"try": true, "except": true, "finally": true, "with": true, "as": true, "yield": true,
+	"import": true, "from": true, "lambda": true, "None": true, "True": true, "False": true,
+	"func": true, "function": true, "var": true, "let": true, "const": true, "switch": true,
+	"case": true, "default": true, "break": true, "continue": true, "range": true, "go": true,
+	"defer": true, "new": true, "this": true, "self": true, "nil": true, "null": true,
+	"true": true, "false": true, "async": true, "await": true, "throw": true, "catch": true,
+}
+
+type scanLine struct {
+	number int
+	norm   string
+	tokens int
+}
+
+type scanWindow struct {
+	file  int
+	start int
+	end   int
+}
+
+type scanRegion struct {
+	file  int
+	start int
+	end   int
+}
+
+func ScanLocal(files []models.FileInfo, opts ScanOptions) []models.DuplicateGroup {
+	if opts.WindowLines <= 0 {
+		opts.WindowLines = defaultWindowLines
+	}
+	if opts.MinTokens <= 0 {
+		opts.MinTokens = defaultMinTokens
+	}
+
+	sorted := make([]models.FileInfo, len(files))
+	copy(sorted, files)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Path < sorted[j].Path
+	})
+
+	var windows []scanWindow
+	buckets := make(map[uint64][]int)
+	var order []uint64
+	for fi, file := range sorted {
+		lines := normalizeLines(file.Content)
+		for i := 0; i+opts.WindowLines <= len(lines); i++ {
+			chunk := lines[i : i+opts.WindowLines]
+
+			tokens := 0
+			h := fnv.New64a()
+			for _, line := range chunk {
+				tokens += line.tokens
+				h.Write([]byte(line.norm))
+				h.Write([]byte{'\n'})
+			}
+			if tokens < opts.MinTokens {
+				continue
+			}
+
+			key := h.Sum64()
+			if _, ok := buckets[key]; !ok {
+				order = append(order, key)
+			}
+			buckets[key] = append(buckets[key], len(windows))
+			windows = append(windows, scanWindow{file: fi, start: chunk[0].number, end: chunk[len(chunk)-1].number})
+		}
+	}
+
+	var matched []int
+	var matchedBuckets [][]int
+	for _, key := range order {
+		ids := buckets[key]
+		if !hasDistinctWindows(windows, ids) {
+			continue
+		}
+		matched = append(matched, ids...)
+		matchedBuckets = append(matchedBuckets, ids)
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	regions, regionOf := mergeWindows(windows, matched)
+
+	parent := make([]int, len(regions))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for _, ids := range matchedBuckets {
+		first := find(regionOf[ids[0]])
+		for _, id := range ids[1:] {
+			if r := find(regionOf[id]); r != first {
+				if r < first {
+					parent[first] = r
+					first = r
+				} else {
+					parent[r] = first
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]models.CodeBlock)
+	var roots []int
+	for i, region := range regions {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], regionBlock(sorted[region.file], region))
+	}
+
+	var groups []models.DuplicateGroup
+	for _, root := range roots {
+		blocks := members[root]
+		if len(blocks) < 2 {
+			continue
+		}
+		groups = append(groups, models.DuplicateGroup{
+			ID:         fmt.Sprintf("local-%d", len(groups)+1),
+			Blocks:     blocks,
+			Similarity: minPairwiseScore(blocks),
+		})
+	}
+
+	return groups
+}
+
+func normalizeLines(content string) []scanLine {
+	var lines []scanLine
+	for i, line := range strings.Split(content, "\n") {
+		raw := tokenRegex.FindAllString(line, -1)
+		if len(raw) == 0 || strings.HasPrefix(raw[0], "#") || (len(raw) > 1 && raw[0] == "/" && raw[1] == "/") {
+			continue
+		}
+
+		norm := make([]string, len(raw))
+		for j, token := range raw {
+			switch {
+			case scanKeywords[token]:
+				norm[j] = token
+			case token[0] >= '0' && token[0] <= '9':
+				norm[j] = "0"
+			case token[0] == '_' || (token[0] >= 'A' && token[0] <= 'Z') || (token[0] >= 'a' && token[0] <= 'z'):
+				norm[j] = "$"
+			default:
+				norm[j] = token
+			}
+		}
+		lines = append(lines, scanLine{number: i + 1, norm: strings.Join(norm, " "), tokens: len(raw)})
+	}
+	return lines
+}
+
+func hasDistinctWindows(windows []scanWindow, ids []int) bool {
+	for i := 0; i < len(ids); i++ {
+		for j := i + 1; j < len(ids); j++ {
+			a, b := windows[ids[i]], windows[ids[j]]
+			if a.file != b.file || a.end < b.start || b.end < a.start {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func mergeWindows(windows []scanWindow, ids []int) ([]scanRegion, map[int]int) {
+	sorted := make([]int, 0, len(ids))
+	seen := make(map[int]bool)
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			sorted = append(sorted, id)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := windows[sorted[i]], windows[sorted[j]]
+		if a.file != b.file {
+			return a.file < b.file
+		}
+		return a.start < b.start
+	})
+
+	var regions []scanRegion
+	regionOf := make(map[int]int, len(sorted))
+	for _, id := range sorted {
+		w := windows[id]
+		if n := len(regions); n > 0 && regions[n-1].file == w.file && w.start <= regions[n-1].end {
+			if w.end > regions[n-1].end {
+				regions[n-1].end = w.end
+			}
+			regionOf[id] = n - 1
+			continue
+		}
+		regions = append(regions, scanRegion{file: w.file, start: w.start, end: w.end})
+		regionOf[id] = len(regions) - 1
+	}
+	return regions, regionOf
+}
+
+func regionBlock(file models.FileInfo, region scanRegion) models.CodeBlock {
+	lines := strings.Split(file.Content, "\n")
+	return models.CodeBlock{
+		ID:        models.BlockID(file.Path, "", region.start, region.end),
+		File:      file.Path,
+		StartLine: region.start,
+		EndLine:   region.end,
+		Content:   strings.Join(lines[region.start-1:region.end], "\n"),
+		Language:  models.LanguageFromPath(file.Path),
+	}
+}
+
+func minPairwiseScore(blocks []models.CodeBlock) float64 {
+	lowest := 1.0
+	for i := 0; i < len(blocks); i++ {
+		for j := i + 1; j < len(blocks); j++ {
+			if score := (TokenJaccard{}).Score(blocks[i], blocks[j]); score < lowest {
+				lowest = score
+			}
+		}
+	}
+	return lowest
+}
@@ -0,0 +1,73 @@
+package dedup
+
+import (
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestScanLocal(t *testing.T) {
+	files := []models.FileInfo{
+		{
+			Path: "orders.py",
+			Content: `import math
+
+
+def order_total(items, tax_rate):
+    subtotal = 0
+    for item in items:
+        subtotal += item.price * item.quantity
+    tax = subtotal * tax_rate
+    return round(subtotal + tax, 2)
+`,
+		},
+		{
+			Path: "invoices.py",
+			Content: `def invoice_total(lines, rate):
+    # sum every line before tax
+    amount = 0
+    for line in lines:
+        amount += line.price * line.quantity
+    tax = amount * rate
+    return round(amount + tax, 2)
+
+
+def describe(invoice):
+    return f"Invoice {invoice.id}"
+`,
+		},
+		{
+			Path:    "config.py",
+			Content: "class Config:\n    debug = False\n    verbose = True\n    retries = 3\n    timeout = 30\n",
+		},
+	}
+
+	groups := ScanLocal(files, ScanOptions{})
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 group, got %d: %+v", len(groups), groups)
+	}
+
+	group := groups[0]
+	if len(group.Blocks) != 2 {
+		t.Fatalf("expected 2 blocks, got %d", len(group.Blocks))
+	}
+
+	byFile := make(map[string]models.CodeBlock)
+	for _, block := range group.Blocks {
+		byFile[block.File] = block
+	}
+	if b, ok := byFile["orders.py"]; !ok || b.StartLine != 4 || b.EndLine != 9 {
+		t.Errorf("expected orders.py block at 4-9, got %+v", b)
+	}
+	if b, ok := byFile["invoices.py"]; !ok || b.StartLine != 1 || b.EndLine != 7 {
+		t.Errorf("expected invoices.py block at 1-7, got %+v", b)
+	}
+
+	if group.Similarity <= 0 || group.Similarity >= 1 {
+		t.Errorf("expected approximate similarity between 0 and 1, got %v", group.Similarity)
+	}
+
+	if groups := ScanLocal(files[2:], ScanOptions{}); len(groups) != 0 {
+		t.Errorf("expected no groups for a single file, got %d", len(groups))
+	}
+}
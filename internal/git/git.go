@@ -1,27 +1,48 @@
 package git
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/alexkarsten/reducto/pkg/models"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
 	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
 type Manager struct {
-	path string
-	repo *git.Repository
+	path        string
+	repo        *git.Repository
+	authorName  string
+	authorEmail string
+}
+
+type ErrDetachedHead struct {
+	Hash string
+}
+
+func (e *ErrDetachedHead) Error() string {
+	return fmt.Sprintf("HEAD is detached at %s", e.Hash[:8])
 }
 
 func NewManager(path string) *Manager {
 	return &Manager{path: path}
 }
 
+func (m *Manager) SetAuthor(name, email string) {
+	m.authorName = name
+	m.authorEmail = email
+}
+
 func (m *Manager) open() error {
 	if m.repo != nil {
 		return nil
@@ -69,9 +90,58 @@ func (m *Manager) CurrentBranch() (string, error) {
 		return "", fmt.Errorf("failed to get HEAD: %w", err)
 	}
 
+	if ref.Name() == plumbing.HEAD {
+		return "", &ErrDetachedHead{Hash: ref.Hash().String()}
+	}
+
 	return ref.Name().Short(), nil
 }
 
+func (m *Manager) IsDetached() (bool, error) {
+	if err := m.open(); err != nil {
+		return false, err
+	}
+
+	ref, err := m.repo.Head()
+	if err != nil {
+		return false, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	return ref.Name() == plumbing.HEAD, nil
+}
+
+// AttachHead puts a detached HEAD on a new reducto-checkpoint branch so
+// checkpoints have a branch to land on. It returns the created branch name, or
+// "" when HEAD was already on a branch.
+func (m *Manager) AttachHead() (string, error) {
+	if err := m.open(); err != nil {
+		return "", err
+	}
+
+	ref, err := m.repo.Head()
+	if err == plumbing.ErrReferenceNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	if ref.Name() != plumbing.HEAD {
+		return "", nil
+	}
+
+	branch := plumbing.NewBranchReferenceName(fmt.Sprintf("reducto-checkpoint-%s", ref.Hash().String()[:8]))
+	if err := m.repo.Storer.SetReference(plumbing.NewHashReference(branch, ref.Hash())); err != nil {
+		return "", fmt.Errorf("failed to create branch %s: %w", branch.Short(), err)
+	}
+
+	if err := m.repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, branch)); err != nil {
+		return "", fmt.Errorf("failed to switch to branch %s: %w", branch.Short(), err)
+	}
+
+	return branch.Short(), nil
+}
+
 func (m *Manager) CurrentCommit() (string, error) {
 	if err := m.open(); err != nil {
 		return "", err
@@ -85,11 +155,107 @@ func (m *Manager) CurrentCommit() (string, error) {
 	return ref.Hash().String()[:8], nil
 }
 
+func (m *Manager) Path() string {
+	return m.path
+}
+
+func (m *Manager) RepoUser() (string, string, error) {
+	if err := m.open(); err != nil {
+		return "", "", err
+	}
+
+	local, err := m.repo.Config()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read repository config: %w", err)
+	}
+	name, email := local.User.Name, local.User.Email
+	if name != "" && email != "" {
+		return name, email, nil
+	}
+
+	global, err := config.LoadConfig(config.GlobalScope)
+	if err != nil {
+		return name, email, nil
+	}
+	if name == "" {
+		name = global.User.Name
+	}
+	if email == "" {
+		email = global.User.Email
+	}
+
+	return name, email, nil
+}
+
+const (
+	checkpointName  = "reducto"
+	checkpointEmail = "reducto@local"
+)
+
+// authorSignature attributes commits to the configured author, then the
+// repository's user, then reducto itself. Checkpoints are always committed by
+// reducto so they can be told apart from user commits whoever authored them.
+func (m *Manager) authorSignature() *object.Signature {
+	name, email := m.authorName, m.authorEmail
+	if name == "" || email == "" {
+		if repoName, repoEmail, err := m.RepoUser(); err == nil {
+			if name == "" {
+				name = repoName
+			}
+			if email == "" {
+				email = repoEmail
+			}
+		}
+	}
+	if name == "" {
+		name = checkpointName
+	}
+	if email == "" {
+		email = checkpointEmail
+	}
+	return &object.Signature{Name: name, Email: email, When: time.Now()}
+}
+
+func committerSignature() *object.Signature {
+	return &object.Signature{Name: checkpointName, Email: checkpointEmail, When: time.Now()}
+}
+
+func isCheckpointCommit(c *object.Commit) bool {
+	return c.Committer.Email == checkpointEmail
+}
+
 func (m *Manager) CreateCheckpoint(message string) error {
+	return m.CreateCheckpointExcluding(message)
+}
+
+func (m *Manager) CheckpointIfDirty(message string) (bool, error) {
+	clean, err := m.IsClean()
+	if err != nil {
+		return false, err
+	}
+	if clean {
+		return false, nil
+	}
+
+	if err := m.CreateCheckpoint(message); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (m *Manager) CreateCheckpointExcluding(message string, exclude ...string) error {
 	if err := m.open(); err != nil {
 		return err
 	}
 
+	head, err := m.repo.Head()
+	if err != nil && err != plumbing.ErrReferenceNotFound {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	if err == nil && head.Name() == plumbing.HEAD {
+		return &ErrDetachedHead{Hash: head.Hash().String()}
+	}
+
 	wt, err := m.repo.Worktree()
 	if err != nil {
 		return fmt.Errorf("failed to get worktree: %w", err)
@@ -101,6 +267,9 @@ func (m *Manager) CreateCheckpoint(message string) error {
 	}
 
 	for file := range status {
+		if isExcludedPath(file, exclude) {
+			continue
+		}
 		_, err := wt.Add(file)
 		if err != nil {
 			return fmt.Errorf("failed to stage %s: %w", file, err)
@@ -108,10 +277,8 @@ func (m *Manager) CreateCheckpoint(message string) error {
 	}
 
 	_, err = wt.Commit(message, &git.CommitOptions{
-		Author: &object.Signature{
-			Name:  "reducto",
-			Email: "reducto@local",
-		},
+		Author:    m.authorSignature(),
+		Committer: committerSignature(),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to commit: %w", err)
@@ -120,6 +287,17 @@ func (m *Manager) CreateCheckpoint(message string) error {
 	return nil
 }
 
+func isExcludedPath(file string, exclude []string) bool {
+	file = filepath.ToSlash(file)
+	for _, prefix := range exclude {
+		prefix = strings.TrimSuffix(filepath.ToSlash(prefix), "/")
+		if file == prefix || strings.HasPrefix(file, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Manager) Commit(message string, changes []models.FileChange) error {
 	if err := m.open(); err != nil {
 		return err
@@ -138,10 +316,8 @@ func (m *Manager) Commit(message string, changes []models.FileChange) error {
 	}
 
 	_, err = wt.Commit(message, &git.CommitOptions{
-		Author: &object.Signature{
-			Name:  "reducto",
-			Email: "reducto@local",
-		},
+		Author:    m.authorSignature(),
+		Committer: committerSignature(),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to commit: %w", err)
@@ -151,6 +327,14 @@ func (m *Manager) Commit(message string, changes []models.FileChange) error {
 }
 
 func (m *Manager) Rollback() error {
+	return m.RollbackN(1)
+}
+
+func (m *Manager) RollbackN(n int) error {
+	if n < 1 {
+		return fmt.Errorf("rollback count must be at least 1, got %d", n)
+	}
+
 	if err := m.open(); err != nil {
 		return err
 	}
@@ -165,27 +349,175 @@ func (m *Manager) Rollback() error {
 		return fmt.Errorf("failed to get HEAD: %w", err)
 	}
 
-	commit, err := m.repo.CommitObject(ref.Hash())
+	boundary, err := m.shallowBoundary()
 	if err != nil {
-		return fmt.Errorf("failed to get commit: %w", err)
+		return err
+	}
+
+	target := ref.Hash()
+	for i := 0; i < n; i++ {
+		if boundary[target] {
+			return &ErrShallowRepo{Op: "rollback"}
+		}
+		commit, err := m.repo.CommitObject(target)
+		if err != nil {
+			return fmt.Errorf("failed to get commit: %w", err)
+		}
+		if len(commit.ParentHashes) == 0 {
+			if n == 1 {
+				return fmt.Errorf("no parent commit to rollback to")
+			}
+			return fmt.Errorf("cannot roll back %d commits: history only has %d", n, i+1)
+		}
+		target = commit.ParentHashes[0]
+	}
+
+	err = wt.Reset(&git.ResetOptions{
+		Commit: target,
+		Mode:   git.HardReset,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Manager) RollbackTo(hash string) error {
+	if err := m.open(); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(hash) == "" {
+		return fmt.Errorf("commit hash required")
+	}
+
+	target, err := m.resolveCommit(hash)
+	if err != nil {
+		return m.shallowErr("rollback", fmt.Errorf("checkpoint %s not found: %w", hash, err))
+	}
+
+	head, err := m.resolveCommit("HEAD")
+	if err != nil {
+		return err
+	}
+
+	ok, err := target.IsAncestor(head)
+	if err != nil {
+		return m.shallowErr("rollback", fmt.Errorf("failed to check ancestry: %w", err))
+	}
+	if !ok {
+		return m.shallowErr("rollback", fmt.Errorf("checkpoint %s is not an ancestor of HEAD", hash))
 	}
 
-	if len(commit.ParentHashes) == 0 {
-		return fmt.Errorf("no parent commit to rollback to")
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
 	}
 
-	parentHash := commit.ParentHashes[0]
 	err = wt.Reset(&git.ResetOptions{
-		Commit: parentHash,
+		Commit: target.Hash,
 		Mode:   git.HardReset,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to reset: %w", err)
 	}
 
+	status, err := wt.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get status: %w", err)
+	}
+	for path, st := range status {
+		if st.Staging != git.Untracked || st.Worktree != git.Untracked {
+			return fmt.Errorf("worktree not clean after rollback to %s: %s", hash, path)
+		}
+	}
+
+	return nil
+}
+
+// RollbackToRoot resets the worktree and index to an empty tree. It removes
+// all tracked files from disk. The branch ref is kept, so existing checkpoints
+// stay reachable and the next commit records the deletion on top of them.
+// Untracked files are left in place; uncommitted changes to tracked files make
+// it refuse to run.
+func (m *Manager) RollbackToRoot() error {
+	if err := m.open(); err != nil {
+		return err
+	}
+
+	head, err := m.repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	if head.Type() != plumbing.SymbolicReference {
+		return &ErrDetachedHead{Hash: head.Hash().String()}
+	}
+	branch := head.Target()
+
+	ref, err := m.repo.Reference(branch, true)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", branch.Short(), err)
+	}
+
+	commit, err := m.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return fmt.Errorf("failed to get tree: %w", err)
+	}
+
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return fmt.Errorf("failed to get status: %w", err)
+	}
+
+	for path, st := range status {
+		if st.Staging != git.Untracked || st.Worktree != git.Untracked {
+			return fmt.Errorf("working tree has uncommitted changes to %s; commit or stash them before rolling back to root", path)
+		}
+	}
+
+	tracked := make(map[string]bool)
+	if err := collectTreePaths(tree, tracked); err != nil {
+		return err
+	}
+
+	for file := range tracked {
+		if err := os.Remove(filepath.Join(m.path, filepath.FromSlash(file))); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", file, err)
+		}
+		removeEmptyParents(m.path, filepath.Dir(filepath.Join(m.path, filepath.FromSlash(file))))
+	}
+
+	if err := m.repo.Storer.SetIndex(&index.Index{Version: 2}); err != nil {
+		return fmt.Errorf("failed to reset index: %w", err)
+	}
+
 	return nil
 }
 
+func removeEmptyParents(root, dir string) {
+	for {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			return
+		}
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
 func (m *Manager) Stash() error {
 	if err := m.open(); err != nil {
 		return err
@@ -269,7 +601,40 @@ func (m *Manager) Diff(file string) (string, error) {
 	return patch.String(), nil
 }
 
-func (m *Manager) ChangedFiles() ([]string, error) {
+func (m *Manager) DiffCheckpoints(from, to string) (string, error) {
+	if err := m.open(); err != nil {
+		return "", err
+	}
+
+	fromCommit, err := m.resolveCommit(from)
+	if err != nil {
+		return "", m.shallowErr("diff", fmt.Errorf("checkpoint %s not found: %w", from, err))
+	}
+
+	toCommit, err := m.resolveCommit(to)
+	if err != nil {
+		return "", m.shallowErr("diff", fmt.Errorf("checkpoint %s not found: %w", to, err))
+	}
+
+	if fromCommit.TreeHash == toCommit.TreeHash {
+		return "", nil
+	}
+
+	patch, err := fromCommit.Patch(toCommit)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate patch: %w", err)
+	}
+
+	return patch.String(), nil
+}
+
+type FileStatus struct {
+	Path       string
+	StatusCode string
+	OldPath    string
+}
+
+func (m *Manager) Status() ([]FileStatus, error) {
 	if err := m.open(); err != nil {
 		return nil, err
 	}
@@ -284,44 +649,373 @@ func (m *Manager) ChangedFiles() ([]string, error) {
 		return nil, fmt.Errorf("failed to get status: %w", err)
 	}
 
-	var files []string
-	for file := range status {
-		files = append(files, file)
+	_, headErr := m.repo.Head()
+	noHead := errors.Is(headErr, plumbing.ErrReferenceNotFound)
+
+	var files []FileStatus
+	for file, st := range status {
+		code := st.Staging
+		if code == git.Unmodified {
+			code = st.Worktree
+		}
+		if noHead && code != git.Deleted {
+			code = git.Added
+		}
+		files = append(files, FileStatus{Path: file, StatusCode: statusName(code)})
 	}
 
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
 	return files, nil
 }
 
-func (m *Manager) GetFileAtCommit(file string, hash plumbing.Hash) (string, error) {
-	if err := m.open(); err != nil {
-		return "", err
+func statusName(code git.StatusCode) string {
+	switch code {
+	case git.Added:
+		return "added"
+	case git.Deleted:
+		return "deleted"
+	case git.Renamed:
+		return "renamed"
+	case git.Copied:
+		return "copied"
+	case git.Untracked:
+		return "untracked"
+	case git.Unmodified:
+		return "unmodified"
+	default:
+		return "modified"
 	}
+}
 
-	commit, err := m.repo.CommitObject(hash)
+type ChangedFilesOptions struct {
+	IncludeIgnored bool
+}
+
+func (m *Manager) ChangedFiles() ([]string, error) {
+	return m.ChangedFilesWithOptions(ChangedFilesOptions{})
+}
+
+// ChangedFilesWithOptions lists changed paths. Untracked files matching the
+// repository's gitignore rules are skipped unless IncludeIgnored is set, in
+// which case ignored files on disk are reported as well.
+func (m *Manager) ChangedFilesWithOptions(opts ChangedFilesOptions) ([]string, error) {
+	statuses, err := m.changedFiles(opts)
 	if err != nil {
-		return "", fmt.Errorf("failed to get commit: %w", err)
+		return nil, err
 	}
 
-	tree, err := commit.Tree()
-	if err != nil {
-		return "", fmt.Errorf("failed to get tree: %w", err)
+	files := make([]string, 0, len(statuses))
+	for _, st := range statuses {
+		files = append(files, st.Path)
+		if st.OldPath != "" {
+			files = append(files, st.OldPath)
+		}
 	}
+	sort.Strings(files)
 
-	entry, err := tree.FindEntry(file)
+	return files, nil
+}
+
+// ChangedFilesDetailed reports each changed path with its status. A deleted
+// file whose exact content reappears under a new path is folded into a single
+// "renamed" entry carrying OldPath.
+func (m *Manager) ChangedFilesDetailed() ([]FileStatus, error) {
+	return m.changedFiles(ChangedFilesOptions{})
+}
+
+func (m *Manager) changedFiles(opts ChangedFilesOptions) ([]FileStatus, error) {
+	status, err := m.Status()
 	if err != nil {
-		return "", fmt.Errorf("file not found in commit: %w", err)
+		return nil, err
 	}
 
-	blob, err := m.repo.BlobObject(entry.Hash)
+	matcher, err := m.ignoreMatcher()
 	if err != nil {
-		return "", fmt.Errorf("failed to get blob: %w", err)
+		return nil, err
 	}
 
-	reader, err := blob.Reader()
-	if err != nil {
-		return "", fmt.Errorf("failed to get reader: %w", err)
+	seen := make(map[string]bool, len(status))
+	files := make([]FileStatus, 0, len(status))
+	for _, st := range status {
+		seen[st.Path] = true
+		if st.StatusCode == "untracked" && !opts.IncludeIgnored && matchesIgnore(matcher, st.Path) {
+			continue
+		}
+		files = append(files, st)
 	}
-	defer reader.Close()
+
+	if opts.IncludeIgnored {
+		ignored, err := m.ignoredFiles(matcher)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range ignored {
+			if !seen[file] {
+				files = append(files, FileStatus{Path: file, StatusCode: "untracked"})
+			}
+		}
+	}
+
+	files, err = m.pairRenames(files)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	return files, nil
+}
+
+func (m *Manager) pairRenames(files []FileStatus) ([]FileStatus, error) {
+	head, err := m.repo.Head()
+	if err != nil {
+		return files, nil
+	}
+	commit, err := m.repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree: %w", err)
+	}
+
+	type deletedFile struct {
+		path    string
+		hash    plumbing.Hash
+		content string
+	}
+	var deleted []*deletedFile
+	for _, st := range files {
+		if st.StatusCode != "deleted" {
+			continue
+		}
+		entry, err := tree.FindEntry(st.Path)
+		if err != nil {
+			continue
+		}
+		content, _, err := readTreeFile(tree, st.Path)
+		if err != nil {
+			continue
+		}
+		deleted = append(deleted, &deletedFile{path: st.Path, hash: entry.Hash, content: content})
+	}
+	if len(deleted) == 0 {
+		return files, nil
+	}
+
+	added := make(map[int]string)
+	for i, st := range files {
+		if st.StatusCode != "added" && st.StatusCode != "untracked" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(m.path, filepath.FromSlash(st.Path)))
+		if err != nil {
+			continue
+		}
+		added[i] = string(data)
+	}
+
+	renamedFrom := make(map[string]bool)
+	rename := func(i int, from *deletedFile) {
+		files[i] = FileStatus{Path: files[i].Path, StatusCode: "renamed", OldPath: from.path}
+		renamedFrom[from.path] = true
+		delete(added, i)
+	}
+
+	// Exact content matches win first, so an unchanged move is never paired
+	// with a merely similar file.
+	for i := range files {
+		content, ok := added[i]
+		if !ok {
+			continue
+		}
+		hash := plumbing.ComputeHash(plumbing.BlobObject, []byte(content))
+		for _, d := range deleted {
+			if !renamedFrom[d.path] && d.hash == hash {
+				rename(i, d)
+				break
+			}
+		}
+	}
+
+	for i := range files {
+		content, ok := added[i]
+		if !ok {
+			continue
+		}
+		var best *deletedFile
+		bestScore := renameThreshold
+		for _, d := range deleted {
+			if renamedFrom[d.path] {
+				continue
+			}
+			if score := lineSimilarity(d.content, content); score >= bestScore {
+				best, bestScore = d, score
+			}
+		}
+		if best != nil {
+			rename(i, best)
+		}
+	}
+
+	kept := files[:0]
+	for _, st := range files {
+		if st.StatusCode == "deleted" && renamedFrom[st.Path] {
+			continue
+		}
+		kept = append(kept, st)
+	}
+	return kept, nil
+}
+
+// renameThreshold matches git's default: a deleted and an added file are a
+// rename when at least half their lines are shared.
+const renameThreshold = 0.5
+
+func lineSimilarity(a, b string) float64 {
+	aLines, bLines := splitLines(a), splitLines(b)
+	if len(aLines) == 0 || len(bLines) == 0 {
+		return 0
+	}
+
+	counts := make(map[string]int, len(aLines))
+	for _, line := range aLines {
+		counts[line]++
+	}
+	shared := 0
+	for _, line := range bLines {
+		if counts[line] > 0 {
+			counts[line]--
+			shared++
+		}
+	}
+
+	longest := len(aLines)
+	if len(bLines) > longest {
+		longest = len(bLines)
+	}
+	return float64(shared) / float64(longest)
+}
+
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func (m *Manager) ignoreMatcher() (gitignore.Matcher, error) {
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	patterns, err := gitignore.ReadPatterns(wt.Filesystem, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gitignore patterns: %w", err)
+	}
+	patterns = append(patterns, wt.Excludes...)
+
+	return gitignore.NewMatcher(patterns), nil
+}
+
+func matchesIgnore(matcher gitignore.Matcher, file string) bool {
+	return matcher.Match(strings.Split(filepath.ToSlash(file), "/"), false)
+}
+
+// ignoredFiles lists files matched by the ignore rules. Files in the index are
+// tracked whatever the rules say, so they are left out.
+func (m *Manager) ignoredFiles(matcher gitignore.Matcher) ([]string, error) {
+	idx, err := m.repo.Storer.Index()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+	tracked := make(map[string]bool, len(idx.Entries))
+	for _, entry := range idx.Entries {
+		tracked[entry.Name] = true
+	}
+
+	var files []string
+	err = filepath.WalkDir(m.path, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(m.path, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if rel == git.GitDirName {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel = filepath.ToSlash(rel)
+		if !tracked[rel] && matchesIgnore(matcher, rel) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan for ignored files: %w", err)
+	}
+
+	return files, nil
+}
+
+func (m *Manager) HasChangesExcluding(exclude ...string) (bool, error) {
+	files, err := m.ChangedFiles()
+	if err != nil {
+		return false, err
+	}
+
+	for _, file := range files {
+		if !isExcludedPath(file, exclude) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *Manager) GetFileAtCommit(file string, hash plumbing.Hash) (string, error) {
+	if err := m.open(); err != nil {
+		return "", err
+	}
+
+	commit, err := m.repo.CommitObject(hash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get tree: %w", err)
+	}
+
+	entry, err := tree.FindEntry(file)
+	if err != nil {
+		return "", fmt.Errorf("file not found in commit: %w", err)
+	}
+
+	blob, err := m.repo.BlobObject(entry.Hash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get blob: %w", err)
+	}
+
+	reader, err := blob.Reader()
+	if err != nil {
+		return "", fmt.Errorf("failed to get reader: %w", err)
+	}
+	defer reader.Close()
 
 	content, err := io.ReadAll(reader)
 	if err != nil {
@@ -330,3 +1024,354 @@ func (m *Manager) GetFileAtCommit(file string, hash plumbing.Hash) (string, erro
 
 	return string(content), nil
 }
+
+type FileDiff struct {
+	Path     string
+	Status   string
+	Original string
+	Modified string
+}
+
+func (m *Manager) DiffWorktree(ref string) ([]FileDiff, error) {
+	if err := m.open(); err != nil {
+		return nil, err
+	}
+
+	commit, err := m.resolveCommit(ref)
+	if err != nil {
+		return nil, m.shallowErr("diff", err)
+	}
+
+	refTree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree: %w", err)
+	}
+
+	paths := make(map[string]bool)
+	if err := collectTreePaths(refTree, paths); err != nil {
+		return nil, err
+	}
+
+	if head, err := m.repo.Head(); err == nil {
+		headCommit, err := m.repo.CommitObject(head.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit: %w", err)
+		}
+		headTree, err := headCommit.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tree: %w", err)
+		}
+		if err := collectTreePaths(headTree, paths); err != nil {
+			return nil, err
+		}
+	}
+
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status: %w", err)
+	}
+	for file := range status {
+		paths[file] = true
+	}
+
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	var diffs []FileDiff
+	for _, path := range sorted {
+		original, inRef, err := readTreeFile(refTree, path)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := os.ReadFile(filepath.Join(m.path, path))
+		onDisk := err == nil
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		modified := string(data)
+
+		switch {
+		case inRef && !onDisk:
+			diffs = append(diffs, FileDiff{Path: path, Status: "deleted", Original: original})
+		case !inRef && onDisk:
+			diffs = append(diffs, FileDiff{Path: path, Status: "added", Modified: modified})
+		case inRef && onDisk && original != modified:
+			diffs = append(diffs, FileDiff{Path: path, Status: "modified", Original: original, Modified: modified})
+		}
+	}
+
+	return diffs, nil
+}
+
+func (m *Manager) resolveCommit(ref string) (*object.Commit, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	hash, err := m.repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+
+	commit, err := m.repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	return commit, nil
+}
+
+func collectTreePaths(tree *object.Tree, paths map[string]bool) error {
+	err := tree.Files().ForEach(func(f *object.File) error {
+		paths[f.Name] = true
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list tree files: %w", err)
+	}
+	return nil
+}
+
+func readTreeFile(tree *object.Tree, path string) (string, bool, error) {
+	file, err := tree.File(path)
+	if err == object.ErrFileNotFound {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get %s from tree: %w", path, err)
+	}
+
+	content, err := file.Contents()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s from tree: %w", path, err)
+	}
+
+	return content, true, nil
+}
+
+func (m *Manager) IsAncestor(a, b string) (bool, error) {
+	if err := m.open(); err != nil {
+		return false, err
+	}
+
+	ancestor, err := m.resolveCommit(a)
+	if err != nil {
+		return false, err
+	}
+
+	descendant, err := m.resolveCommit(b)
+	if err != nil {
+		return false, err
+	}
+
+	ok, err := ancestor.IsAncestor(descendant)
+	if err != nil {
+		return false, m.shallowErr("ancestry check", fmt.Errorf("failed to check ancestry: %w", err))
+	}
+
+	return ok, nil
+}
+
+func (m *Manager) MergeBase(a, b string) (string, error) {
+	if err := m.open(); err != nil {
+		return "", err
+	}
+
+	first, err := m.resolveCommit(a)
+	if err != nil {
+		return "", err
+	}
+
+	second, err := m.resolveCommit(b)
+	if err != nil {
+		return "", err
+	}
+
+	bases, err := first.MergeBase(second)
+	if err != nil {
+		return "", m.shallowErr("merge base", fmt.Errorf("failed to compute merge base: %w", err))
+	}
+
+	if len(bases) == 0 {
+		return "", m.shallowErr("merge base", fmt.Errorf("no common ancestor between %s and %s", a, b))
+	}
+
+	return bases[0].Hash.String(), nil
+}
+
+func (m *Manager) VerifyCheckpoint(hash string) error {
+	if err := m.open(); err != nil {
+		return err
+	}
+
+	commit, err := m.resolveCommit(hash)
+	if err != nil {
+		return fmt.Errorf("checkpoint %s is not a valid commit: %w", hash, err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return fmt.Errorf("checkpoint %s has an unreadable tree: %w", hash, err)
+	}
+
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("checkpoint %s has a missing tree object: %w", hash, err)
+		}
+
+		if !entry.Mode.IsFile() {
+			continue
+		}
+
+		if _, err := m.repo.BlobObject(entry.Hash); err != nil {
+			return fmt.Errorf("checkpoint %s is missing blob %s for %s: %w", hash, entry.Hash, name, err)
+		}
+	}
+
+	reachable, err := m.isReachableFromRef(commit)
+	if err != nil {
+		return err
+	}
+	if !reachable {
+		return fmt.Errorf("checkpoint %s is not reachable from any ref", hash)
+	}
+
+	return nil
+}
+
+func (m *Manager) isReachableFromRef(commit *object.Commit) (bool, error) {
+	refs, err := m.repo.References()
+	if err != nil {
+		return false, fmt.Errorf("failed to list refs: %w", err)
+	}
+	defer refs.Close()
+
+	reachable := false
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		if reachable || ref.Type() != plumbing.HashReference {
+			return nil
+		}
+
+		tip, err := m.repo.CommitObject(ref.Hash())
+		if err != nil {
+			return nil
+		}
+
+		if tip.Hash == commit.Hash {
+			reachable = true
+			return nil
+		}
+
+		ok, err := commit.IsAncestor(tip)
+		if err != nil {
+			return fmt.Errorf("failed to check reachability from %s: %w", ref.Name(), err)
+		}
+		reachable = ok
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return reachable, nil
+}
+
+const TestsTrailer = "Dehydrator-Tests"
+
+type Checkpoint struct {
+	Hash       string
+	Message    string
+	Author     string
+	When       time.Time
+	TestStatus string
+}
+
+func (m *Manager) AmendMessage(message string) error {
+	if err := m.open(); err != nil {
+		return err
+	}
+
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	_, err = wt.Commit(message, &git.CommitOptions{
+		Amend:     true,
+		Author:    m.authorSignature(),
+		Committer: committerSignature(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to amend commit: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Manager) ListCheckpoints() ([]Checkpoint, error) {
+	if err := m.open(); err != nil {
+		return nil, err
+	}
+
+	head, err := m.repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	iter, err := m.repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+	defer iter.Close()
+
+	var checkpoints []Checkpoint
+	err = iter.ForEach(func(c *object.Commit) error {
+		if !isCheckpointCommit(c) {
+			return nil
+		}
+		checkpoints = append(checkpoints, Checkpoint{
+			Hash:       c.Hash.String()[:8],
+			Message:    strings.TrimSpace(c.Message),
+			Author:     c.Author.Name,
+			When:       c.Author.When,
+			TestStatus: parseTrailer(c.Message, TestsTrailer),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, m.shallowErr("listing checkpoints", fmt.Errorf("failed to walk log: %w", err))
+	}
+
+	return checkpoints, nil
+}
+
+func parseTrailer(message, key string) string {
+	prefix := key + ":"
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, prefix))
+		}
+	}
+	return ""
+}
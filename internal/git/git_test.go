@@ -1,11 +1,15 @@
 package git
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
@@ -263,6 +267,407 @@ func TestCreateCheckpoint(t *testing.T) {
 	})
 }
 
+func TestCheckpointIfDirty(t *testing.T) {
+	tests := []struct {
+		name    string
+		dirty   bool
+		created bool
+	}{
+		{name: "clean repo", dirty: false, created: false},
+		{name: "dirty repo", dirty: true, created: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, tmpDir := initTestRepo(t)
+			initial := commitFile(t, repo, tmpDir, "a.txt", "initial", "initial")
+
+			if tt.dirty {
+				if err := os.WriteFile(filepath.Join(tmpDir, "a.txt"), []byte("changed"), 0644); err != nil {
+					t.Fatalf("failed to write file: %v", err)
+				}
+			}
+
+			mgr := NewManager(tmpDir)
+			created, err := mgr.CheckpointIfDirty("checkpoint")
+			if err != nil {
+				t.Fatalf("CheckpointIfDirty returned error: %v", err)
+			}
+			if created != tt.created {
+				t.Errorf("expected created=%v, got %v", tt.created, created)
+			}
+
+			head, err := repo.Head()
+			if err != nil {
+				t.Fatalf("failed to get HEAD: %v", err)
+			}
+			if moved := head.Hash() != initial; moved != tt.created {
+				t.Errorf("expected HEAD moved=%v, got %v", tt.created, moved)
+			}
+		})
+	}
+}
+
+func TestRollbackN(t *testing.T) {
+	t.Run("two of three commits", func(t *testing.T) {
+		repo, tmpDir := initTestRepo(t)
+		commitFile(t, repo, tmpDir, "a.txt", "a", "add a")
+		commitFile(t, repo, tmpDir, "b.txt", "b", "add b")
+		commitFile(t, repo, tmpDir, "a.txt", "a2", "update a")
+
+		mgr := NewManager(tmpDir)
+		if err := mgr.RollbackN(2); err != nil {
+			t.Fatalf("RollbackN returned error: %v", err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, "a.txt"))
+		if err != nil {
+			t.Fatalf("failed to read a.txt: %v", err)
+		}
+		if string(content) != "a" {
+			t.Errorf("expected a.txt to be restored, got %q", content)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "b.txt")); !os.IsNotExist(err) {
+			t.Error("expected b.txt to be removed")
+		}
+
+		clean, err := mgr.IsClean()
+		if err != nil {
+			t.Fatalf("IsClean returned error: %v", err)
+		}
+		if !clean {
+			t.Error("expected clean worktree after rollback")
+		}
+
+		ref, err := repo.Head()
+		if err != nil {
+			t.Fatalf("failed to get HEAD: %v", err)
+		}
+		commit, err := repo.CommitObject(ref.Hash())
+		if err != nil {
+			t.Fatalf("failed to get commit: %v", err)
+		}
+		if commit.Message != "add a" {
+			t.Errorf("expected HEAD at first commit, got %q", commit.Message)
+		}
+	})
+
+	t.Run("exceeds history", func(t *testing.T) {
+		repo, tmpDir := initTestRepo(t)
+		commitFile(t, repo, tmpDir, "a.txt", "a", "add a")
+		commitFile(t, repo, tmpDir, "b.txt", "b", "add b")
+
+		mgr := NewManager(tmpDir)
+		err := mgr.RollbackN(2)
+		if err == nil || !strings.Contains(err.Error(), "history only has 2") {
+			t.Fatalf("expected history depth error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "b.txt")); err != nil {
+			t.Error("expected worktree to be untouched after failed rollback")
+		}
+	})
+
+	t.Run("invalid count", func(t *testing.T) {
+		_, tmpDir := initTestRepo(t)
+		if err := NewManager(tmpDir).RollbackN(0); err == nil {
+			t.Error("expected error for zero count")
+		}
+	})
+}
+
+func TestListCheckpoints(t *testing.T) {
+	repo, tmpDir := initTestRepo(t)
+	commitFile(t, repo, tmpDir, "user.txt", "user", "user commit")
+
+	mgr := NewManager(tmpDir)
+	mgr.SetAuthor("Dana Lee", "dana@example.com")
+	for i, name := range []string{"first.txt", "second.txt"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(name), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		if err := mgr.CreateCheckpoint(fmt.Sprintf("reducto: checkpoint %d", i+1)); err != nil {
+			t.Fatalf("CreateCheckpoint returned error: %v", err)
+		}
+	}
+	commitFile(t, repo, tmpDir, "later.txt", "later", "another user commit")
+
+	checkpoints, err := mgr.ListCheckpoints()
+	if err != nil {
+		t.Fatalf("ListCheckpoints returned error: %v", err)
+	}
+
+	if len(checkpoints) != 2 {
+		t.Fatalf("expected 2 checkpoints, got %d: %+v", len(checkpoints), checkpoints)
+	}
+	if checkpoints[0].Message != "reducto: checkpoint 2" || checkpoints[1].Message != "reducto: checkpoint 1" {
+		t.Errorf("expected newest checkpoint first, got %q then %q", checkpoints[0].Message, checkpoints[1].Message)
+	}
+	for _, cp := range checkpoints {
+		if len(cp.Hash) != 8 {
+			t.Errorf("expected short hash, got %q", cp.Hash)
+		}
+		if cp.Author != "Dana Lee" {
+			t.Errorf("expected configured author, got %q", cp.Author)
+		}
+		if cp.When.IsZero() {
+			t.Error("expected checkpoint timestamp")
+		}
+	}
+	if checkpoints[1].When.After(checkpoints[0].When) {
+		t.Error("expected checkpoints ordered newest first")
+	}
+}
+
+func TestCheckpointAuthor(t *testing.T) {
+	tests := []struct {
+		name      string
+		author    [2]string
+		repoUser  [2]string
+		wantName  string
+		wantEmail string
+	}{
+		{
+			name:      "configured author",
+			author:    [2]string{"Dana Lee", "dana@example.com"},
+			repoUser:  [2]string{"Repo User", "repo@example.com"},
+			wantName:  "Dana Lee",
+			wantEmail: "dana@example.com",
+		},
+		{
+			name:      "falls back to repository user",
+			repoUser:  [2]string{"Repo User", "repo@example.com"},
+			wantName:  "Repo User",
+			wantEmail: "repo@example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, tmpDir := initTestRepo(t)
+			commitFile(t, repo, tmpDir, "a.txt", "a", "add a")
+
+			cfg, err := repo.Config()
+			if err != nil {
+				t.Fatalf("failed to read config: %v", err)
+			}
+			cfg.User.Name, cfg.User.Email = tt.repoUser[0], tt.repoUser[1]
+			if err := repo.SetConfig(cfg); err != nil {
+				t.Fatalf("failed to write config: %v", err)
+			}
+
+			if err := os.WriteFile(filepath.Join(tmpDir, "b.txt"), []byte("b"), 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+
+			mgr := NewManager(tmpDir)
+			if tt.author[0] != "" {
+				mgr.SetAuthor(tt.author[0], tt.author[1])
+			}
+			if err := mgr.CreateCheckpoint("checkpoint"); err != nil {
+				t.Fatalf("CreateCheckpoint returned error: %v", err)
+			}
+
+			ref, err := repo.Head()
+			if err != nil {
+				t.Fatalf("failed to get HEAD: %v", err)
+			}
+			commit, err := repo.CommitObject(ref.Hash())
+			if err != nil {
+				t.Fatalf("failed to get commit: %v", err)
+			}
+
+			if commit.Author.Name != tt.wantName || commit.Author.Email != tt.wantEmail {
+				t.Errorf("expected author %s <%s>, got %s <%s>", tt.wantName, tt.wantEmail, commit.Author.Name, commit.Author.Email)
+			}
+			if commit.Author.When.IsZero() {
+				t.Error("expected author timestamp")
+			}
+			if commit.Committer.Email != "reducto@local" {
+				t.Errorf("expected reducto committer, got %s", commit.Committer.Email)
+			}
+
+			checkpoints, err := mgr.ListCheckpoints()
+			if err != nil {
+				t.Fatalf("ListCheckpoints returned error: %v", err)
+			}
+			if len(checkpoints) != 1 {
+				t.Errorf("expected checkpoint to be listed regardless of author, got %d", len(checkpoints))
+			}
+		})
+	}
+}
+
+func TestRollbackTo(t *testing.T) {
+	t.Run("short hash", func(t *testing.T) {
+		repo, tmpDir := initTestRepo(t)
+		commitFile(t, repo, tmpDir, "a.txt", "a", "add a")
+
+		mgr := NewManager(tmpDir)
+		checkpoint, err := mgr.CurrentCommit()
+		if err != nil {
+			t.Fatalf("CurrentCommit returned error: %v", err)
+		}
+
+		commitFile(t, repo, tmpDir, "b.txt", "b", "add b")
+		commitFile(t, repo, tmpDir, "a.txt", "a2", "update a")
+
+		if err := mgr.RollbackTo(checkpoint); err != nil {
+			t.Fatalf("RollbackTo returned error: %v", err)
+		}
+
+		current, err := mgr.CurrentCommit()
+		if err != nil {
+			t.Fatalf("CurrentCommit returned error: %v", err)
+		}
+		if current != checkpoint {
+			t.Errorf("expected HEAD at %s, got %s", checkpoint, current)
+		}
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, "a.txt"))
+		if err != nil {
+			t.Fatalf("failed to read a.txt: %v", err)
+		}
+		if string(content) != "a" {
+			t.Errorf("expected a.txt to be restored, got %q", content)
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "b.txt")); !os.IsNotExist(err) {
+			t.Error("expected b.txt to be removed")
+		}
+
+		clean, err := mgr.IsClean()
+		if err != nil {
+			t.Fatalf("IsClean returned error: %v", err)
+		}
+		if !clean {
+			t.Error("expected clean worktree after rollback")
+		}
+	})
+
+	t.Run("rejects non-ancestor", func(t *testing.T) {
+		repo, tmpDir := initTestRepo(t)
+		commitFile(t, repo, tmpDir, "a.txt", "a", "add a")
+
+		wt, err := repo.Worktree()
+		if err != nil {
+			t.Fatalf("failed to get worktree: %v", err)
+		}
+		if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("feature"), Create: true}); err != nil {
+			t.Fatalf("failed to create branch: %v", err)
+		}
+		other := commitFile(t, repo, tmpDir, "feature.txt", "f", "feature work")
+		if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("master")}); err != nil {
+			t.Fatalf("failed to checkout master: %v", err)
+		}
+		head := commitFile(t, repo, tmpDir, "b.txt", "b", "add b")
+
+		mgr := NewManager(tmpDir)
+		err = mgr.RollbackTo(other.String())
+		if err == nil || !strings.Contains(err.Error(), "not an ancestor") {
+			t.Fatalf("expected non-ancestor error, got %v", err)
+		}
+
+		ref, err := repo.Head()
+		if err != nil {
+			t.Fatalf("failed to get HEAD: %v", err)
+		}
+		if ref.Hash() != head {
+			t.Errorf("expected HEAD to stay at %s, got %s", head, ref.Hash())
+		}
+		if _, err := os.Stat(filepath.Join(tmpDir, "b.txt")); err != nil {
+			t.Error("expected worktree to be untouched")
+		}
+	})
+
+	t.Run("unknown hash", func(t *testing.T) {
+		repo, tmpDir := initTestRepo(t)
+		commitFile(t, repo, tmpDir, "a.txt", "a", "add a")
+
+		if err := NewManager(tmpDir).RollbackTo("deadbeef"); err == nil {
+			t.Error("expected error for unknown hash")
+		}
+	})
+}
+
+func TestRollbackToRoot(t *testing.T) {
+	repo, tmpDir := initTestRepo(t)
+	if err := os.MkdirAll(filepath.Join(tmpDir, "pkg"), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	commitFile(t, repo, tmpDir, "a.txt", "a", "add a")
+	if err := os.WriteFile(filepath.Join(tmpDir, "pkg", "b.txt"), []byte("b"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	mgr := NewManager(tmpDir)
+	if err := mgr.CreateCheckpoint("add b"); err != nil {
+		t.Fatalf("CreateCheckpoint returned error: %v", err)
+	}
+
+	before, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+
+	t.Run("refuses dirty worktree", func(t *testing.T) {
+		if err := os.WriteFile(filepath.Join(tmpDir, "a.txt"), []byte("edited"), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		if err := mgr.RollbackToRoot(); err == nil {
+			t.Fatal("expected error for uncommitted changes")
+		}
+		content, err := os.ReadFile(filepath.Join(tmpDir, "a.txt"))
+		if err != nil || string(content) != "edited" {
+			t.Fatalf("expected uncommitted edit to survive, got %q (err %v)", content, err)
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, "a.txt"), []byte("a"), 0644); err != nil {
+			t.Fatalf("failed to restore file: %v", err)
+		}
+	})
+
+	if err := mgr.RollbackToRoot(); err != nil {
+		t.Fatalf("RollbackToRoot returned error: %v", err)
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to read worktree: %v", err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != ".git" {
+			t.Errorf("expected empty worktree, found %s", entry.Name())
+		}
+	}
+
+	reopened := NewManager(tmpDir)
+	if !reopened.IsRepo() {
+		t.Fatal("expected repository to remain valid")
+	}
+
+	head, err := repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		t.Fatalf("failed to read HEAD: %v", err)
+	}
+	if head.Target() != plumbing.NewBranchReferenceName("master") {
+		t.Errorf("expected HEAD to keep pointing at master, got %s", head.Target())
+	}
+
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName("master"), true)
+	if err != nil {
+		t.Fatalf("expected branch ref to survive, got %v", err)
+	}
+	if ref.Hash() != before.Hash() {
+		t.Errorf("expected branch to stay at %s, got %s", before.Hash(), ref.Hash())
+	}
+
+	checkpoints, err := reopened.ListCheckpoints()
+	if err != nil {
+		t.Fatalf("ListCheckpoints returned error: %v", err)
+	}
+	if len(checkpoints) != 1 {
+		t.Errorf("expected checkpoint to stay reachable, got %d", len(checkpoints))
+	}
+}
+
 func TestRollback(t *testing.T) {
 	t.Run("single commit", func(t *testing.T) {
 		tmpDir := t.TempDir()
@@ -432,3 +837,556 @@ func TestChangedFiles(t *testing.T) {
 		}
 	})
 }
+
+func TestChangedFilesIgnored(t *testing.T) {
+	repo, tmpDir := initTestRepo(t)
+	commitFile(t, repo, tmpDir, ".gitignore", "*.log\nvenv/\n", "add gitignore")
+	commitFile(t, repo, tmpDir, "main.go", "package main\n", "add main")
+	commitFile(t, repo, tmpDir, "keep.log", "tracked despite the rules\n", "add keep.log")
+
+	files := map[string]string{
+		"main.go":           "package main\n\nfunc main() {}\n",
+		"debug.log":         "noise",
+		"venv/bin/activate": "noise",
+		"notes.txt":         "todo",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		opts     ChangedFilesOptions
+		expected []string
+	}{
+		{"ignored excluded by default", ChangedFilesOptions{}, []string{"main.go", "notes.txt"}},
+		{"ignored included on request", ChangedFilesOptions{IncludeIgnored: true}, []string{"debug.log", "main.go", "notes.txt", "venv/bin/activate"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewManager(tmpDir).ChangedFilesWithOptions(tt.opts)
+			if err != nil {
+				t.Fatalf("ChangedFilesWithOptions returned error: %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+const serviceSource = `package service
+
+type Service struct {
+	name  string
+	ready bool
+}
+
+func New(name string) *Service {
+	return &Service{name: name}
+}
+
+func (s *Service) Start() error {
+	s.ready = true
+	return nil
+}
+`
+
+func TestChangedFilesDetailed(t *testing.T) {
+	repo, tmpDir := initTestRepo(t)
+	commitFile(t, repo, tmpDir, "edit.go", "package a\n", "add edit")
+	commitFile(t, repo, tmpDir, "gone.go", "package gone\n", "add gone")
+	commitFile(t, repo, tmpDir, "old.go", "package moved\n", "add old")
+	commitFile(t, repo, tmpDir, "service.go", serviceSource, "add service")
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "edit.go"), []byte("package a\n\nvar x = 1\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if _, err := wt.Add("edit.go"); err != nil {
+		t.Fatalf("failed to stage edit.go: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "new.go"), []byte("package fresh\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.Remove(filepath.Join(tmpDir, "gone.go")); err != nil {
+		t.Fatalf("failed to remove file: %v", err)
+	}
+	if err := os.Rename(filepath.Join(tmpDir, "old.go"), filepath.Join(tmpDir, "moved.go")); err != nil {
+		t.Fatalf("failed to rename file: %v", err)
+	}
+	if err := os.Remove(filepath.Join(tmpDir, "service.go")); err != nil {
+		t.Fatalf("failed to remove file: %v", err)
+	}
+	edited := strings.Replace(serviceSource, "return nil", "return errNotReady", 1)
+	if err := os.WriteFile(filepath.Join(tmpDir, "renamed.go"), []byte(edited), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "staged.go"), []byte("package staged\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if _, err := wt.Add("staged.go"); err != nil {
+		t.Fatalf("failed to stage staged.go: %v", err)
+	}
+
+	mgr := NewManager(tmpDir)
+	got, err := mgr.ChangedFilesDetailed()
+	if err != nil {
+		t.Fatalf("ChangedFilesDetailed returned error: %v", err)
+	}
+
+	expected := []FileStatus{
+		{Path: "edit.go", StatusCode: "modified"},
+		{Path: "gone.go", StatusCode: "deleted"},
+		{Path: "moved.go", StatusCode: "renamed", OldPath: "old.go"},
+		{Path: "new.go", StatusCode: "untracked"},
+		{Path: "renamed.go", StatusCode: "renamed", OldPath: "service.go"},
+		{Path: "staged.go", StatusCode: "added"},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("entry %d: expected %+v, got %+v", i, expected[i], got[i])
+		}
+	}
+
+	paths, err := mgr.ChangedFiles()
+	if err != nil {
+		t.Fatalf("ChangedFiles returned error: %v", err)
+	}
+	if want := "edit.go,gone.go,moved.go,new.go,old.go,renamed.go,service.go,staged.go"; strings.Join(paths, ",") != want {
+		t.Errorf("expected paths %s, got %v", want, paths)
+	}
+}
+
+func TestDiffCheckpoints(t *testing.T) {
+	repo, tmpDir := initTestRepo(t)
+	commitFile(t, repo, tmpDir, "calc.go", "package calc\n\nfunc Add(a, b int) int { return a + b }\n", "add calc")
+	commitFile(t, repo, tmpDir, "other.go", "package calc\n", "add other")
+
+	mgr := NewManager(tmpDir)
+	first, err := mgr.CurrentCommit()
+	if err != nil {
+		t.Fatalf("CurrentCommit returned error: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "calc.go"), []byte("package calc\n\nfunc Sum(a, b int) int { return a + b }\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := mgr.CreateCheckpoint("rename Add"); err != nil {
+		t.Fatalf("CreateCheckpoint returned error: %v", err)
+	}
+	second, err := mgr.CurrentCommit()
+	if err != nil {
+		t.Fatalf("CurrentCommit returned error: %v", err)
+	}
+
+	t.Run("edited file", func(t *testing.T) {
+		diff, err := mgr.DiffCheckpoints(first, second)
+		if err != nil {
+			t.Fatalf("DiffCheckpoints returned error: %v", err)
+		}
+		for _, want := range []string{
+			"diff --git a/calc.go b/calc.go",
+			"-func Add(a, b int) int { return a + b }",
+			"+func Sum(a, b int) int { return a + b }",
+		} {
+			if !strings.Contains(diff, want) {
+				t.Errorf("expected diff to contain %q, got:\n%s", want, diff)
+			}
+		}
+		if strings.Contains(diff, "other.go") {
+			t.Error("expected unchanged files to be left out of the diff")
+		}
+	})
+
+	t.Run("identical commits", func(t *testing.T) {
+		diff, err := mgr.DiffCheckpoints(second, second)
+		if err != nil {
+			t.Fatalf("DiffCheckpoints returned error: %v", err)
+		}
+		if diff != "" {
+			t.Errorf("expected empty diff, got %q", diff)
+		}
+	})
+
+	t.Run("unresolvable hash", func(t *testing.T) {
+		_, err := mgr.DiffCheckpoints(first, "deadbeef")
+		if err == nil || !strings.Contains(err.Error(), "deadbeef") {
+			t.Errorf("expected error naming the bad hash, got %v", err)
+		}
+	})
+}
+
+func initTestRepo(t *testing.T) (*git.Repository, string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	repo, err := git.PlainInit(tmpDir, false)
+	if err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+	return repo, tmpDir
+}
+
+func commitFile(t *testing.T, repo *git.Repository, dir, name, content, message string) plumbing.Hash {
+	t.Helper()
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	_, err = wt.Add(name)
+	if err != nil {
+		t.Fatalf("failed to add file: %v", err)
+	}
+
+	hash, err := wt.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "test",
+			Email: "test@test.com",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	return hash
+}
+
+func TestDiffWorktree(t *testing.T) {
+	repo, tmpDir := initTestRepo(t)
+	commitFile(t, repo, tmpDir, "keep.txt", "keep", "initial")
+	commitFile(t, repo, tmpDir, "edit.txt", "before", "second")
+	commitFile(t, repo, tmpDir, "remove.txt", "gone soon", "third")
+
+	mgr := NewManager(tmpDir)
+	checkpoint, err := mgr.CurrentCommit()
+	if err != nil {
+		t.Fatalf("CurrentCommit returned error: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "edit.txt"), []byte("after"), 0644); err != nil {
+		t.Fatalf("failed to modify file: %v", err)
+	}
+	if err := os.Remove(filepath.Join(tmpDir, "remove.txt")); err != nil {
+		t.Fatalf("failed to remove file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "added.txt"), []byte("added"), 0644); err != nil {
+		t.Fatalf("failed to add file: %v", err)
+	}
+
+	diffs, err := mgr.DiffWorktree(checkpoint)
+	if err != nil {
+		t.Fatalf("DiffWorktree returned error: %v", err)
+	}
+
+	expected := map[string]string{
+		"added.txt":  "added",
+		"edit.txt":   "modified",
+		"remove.txt": "deleted",
+	}
+	if len(diffs) != len(expected) {
+		t.Fatalf("expected %d diffs, got %d: %+v", len(expected), len(diffs), diffs)
+	}
+	for _, d := range diffs {
+		if expected[d.Path] != d.Status {
+			t.Errorf("expected %s to be %s, got %s", d.Path, expected[d.Path], d.Status)
+		}
+		if d.Path == "edit.txt" && (d.Original != "before" || d.Modified != "after") {
+			t.Errorf("unexpected contents for edit.txt: %q -> %q", d.Original, d.Modified)
+		}
+	}
+
+	t.Run("includes files committed after ref", func(t *testing.T) {
+		first, err := repo.ResolveRevision("HEAD~2")
+		if err != nil {
+			t.Fatalf("failed to resolve HEAD~2: %v", err)
+		}
+
+		diffs, err := mgr.DiffWorktree(first.String())
+		if err != nil {
+			t.Fatalf("DiffWorktree returned error: %v", err)
+		}
+
+		found := false
+		for _, d := range diffs {
+			if d.Path == "edit.txt" && d.Status == "added" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected edit.txt to be reported as added, got %+v", diffs)
+		}
+	})
+
+	t.Run("unknown ref", func(t *testing.T) {
+		if _, err := mgr.DiffWorktree("does-not-exist"); err == nil {
+			t.Error("expected error for unknown ref")
+		}
+	})
+}
+
+func TestIsAncestorAndMergeBase(t *testing.T) {
+	repo, tmpDir := initTestRepo(t)
+	root := commitFile(t, repo, tmpDir, "root.txt", "root", "root")
+	base := commitFile(t, repo, tmpDir, "base.txt", "base", "base")
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+
+	mainBranch, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+
+	err = wt.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName("feature"),
+		Create: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create branch: %v", err)
+	}
+	feature := commitFile(t, repo, tmpDir, "feature.txt", "feature", "feature")
+
+	err = wt.Checkout(&git.CheckoutOptions{Branch: mainBranch.Name()})
+	if err != nil {
+		t.Fatalf("failed to checkout main: %v", err)
+	}
+	mainTip := commitFile(t, repo, tmpDir, "main.txt", "main", "main")
+
+	mgr := NewManager(tmpDir)
+
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{"root before feature", root.String(), "feature", true},
+		{"base before main tip", base.String()[:8], mainTip.String(), true},
+		{"feature not before main", "feature", mainBranch.Name().Short(), false},
+		{"main not before feature", mainTip.String(), feature.String(), false},
+		{"commit is its own ancestor", base.String(), base.String(), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := mgr.IsAncestor(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("IsAncestor returned error: %v", err)
+			}
+			if ok != tt.expected {
+				t.Errorf("IsAncestor(%s, %s) = %v, expected %v", tt.a, tt.b, ok, tt.expected)
+			}
+		})
+	}
+
+	t.Run("merge base", func(t *testing.T) {
+		mb, err := mgr.MergeBase("feature", mainBranch.Name().Short())
+		if err != nil {
+			t.Fatalf("MergeBase returned error: %v", err)
+		}
+		if mb != base.String() {
+			t.Errorf("expected merge base %s, got %s", base, mb)
+		}
+	})
+
+	t.Run("unknown ref", func(t *testing.T) {
+		if _, err := mgr.IsAncestor("nope", "feature"); err == nil {
+			t.Error("expected error for unknown ref")
+		}
+		if _, err := mgr.MergeBase("feature", "nope"); err == nil {
+			t.Error("expected error for unknown ref")
+		}
+	})
+}
+
+func TestVerifyCheckpoint(t *testing.T) {
+	t.Run("valid checkpoint", func(t *testing.T) {
+		repo, tmpDir := initTestRepo(t)
+		commitFile(t, repo, tmpDir, "main.py", "print('hi')", "initial")
+
+		if err := os.MkdirAll(filepath.Join(tmpDir, "pkg"), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, "pkg", "util.py"), []byte("x = 1"), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+
+		mgr := NewManager(tmpDir)
+		if err := mgr.CreateCheckpoint("checkpoint"); err != nil {
+			t.Fatalf("CreateCheckpoint returned error: %v", err)
+		}
+
+		hash, err := mgr.CurrentCommit()
+		if err != nil {
+			t.Fatalf("CurrentCommit returned error: %v", err)
+		}
+
+		if err := mgr.VerifyCheckpoint(hash); err != nil {
+			t.Errorf("expected checkpoint to verify, got %v", err)
+		}
+	})
+
+	t.Run("bogus hash", func(t *testing.T) {
+		repo, tmpDir := initTestRepo(t)
+		commitFile(t, repo, tmpDir, "main.py", "print('hi')", "initial")
+
+		mgr := NewManager(tmpDir)
+		if err := mgr.VerifyCheckpoint("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef"); err == nil {
+			t.Error("expected error for bogus hash")
+		}
+	})
+}
+
+func TestDetachedHead(t *testing.T) {
+	repo, tmpDir := initTestRepo(t)
+	first := commitFile(t, repo, tmpDir, "a.txt", "one", "first")
+	commitFile(t, repo, tmpDir, "b.txt", "two", "second")
+
+	mgr := NewManager(tmpDir)
+	detached, err := mgr.IsDetached()
+	if err != nil {
+		t.Fatalf("IsDetached returned error: %v", err)
+	}
+	if detached {
+		t.Fatal("expected attached HEAD on a branch")
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	if err := wt.Checkout(&git.CheckoutOptions{Hash: first}); err != nil {
+		t.Fatalf("failed to detach HEAD: %v", err)
+	}
+
+	mgr = NewManager(tmpDir)
+	detached, err = mgr.IsDetached()
+	if err != nil {
+		t.Fatalf("IsDetached returned error: %v", err)
+	}
+	if !detached {
+		t.Error("expected detached HEAD to be reported")
+	}
+
+	_, err = mgr.CurrentBranch()
+	var detachedErr *ErrDetachedHead
+	if !errors.As(err, &detachedErr) {
+		t.Fatalf("expected ErrDetachedHead, got %v", err)
+	}
+	if detachedErr.Hash != first.String() {
+		t.Errorf("expected hash %s, got %s", first, detachedErr.Hash)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "c.txt"), []byte("three"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := mgr.CreateCheckpoint("checkpoint"); !errors.As(err, &detachedErr) {
+		t.Fatalf("expected CreateCheckpoint to refuse a detached HEAD, got %v", err)
+	}
+
+	created, err := mgr.AttachHead()
+	if err != nil {
+		t.Fatalf("AttachHead returned error: %v", err)
+	}
+	if created != "reducto-checkpoint-"+first.String()[:8] {
+		t.Errorf("unexpected created branch %q", created)
+	}
+	if err := mgr.CreateCheckpoint("checkpoint"); err != nil {
+		t.Fatalf("CreateCheckpoint returned error: %v", err)
+	}
+
+	branch, err := mgr.CurrentBranch()
+	if err != nil {
+		t.Fatalf("expected checkpoint to land on a branch, got %v", err)
+	}
+	if branch != created {
+		t.Errorf("expected checkpoint on %s, got %s", created, branch)
+	}
+
+	created, err = mgr.AttachHead()
+	if err != nil || created != "" {
+		t.Errorf("expected no branch when HEAD is attached, got %q (%v)", created, err)
+	}
+}
+
+func TestStatusEmptyRepo(t *testing.T) {
+	tmpDir := t.TempDir()
+	if _, err := git.PlainInit(tmpDir, false); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	for _, name := range []string{"b.py", "a.py"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte("x = 1\n"), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	mgr := NewManager(tmpDir)
+
+	status, err := mgr.Status()
+	if err != nil {
+		t.Fatalf("Status returned error: %v", err)
+	}
+
+	expected := []FileStatus{{Path: "a.py", StatusCode: "added"}, {Path: "b.py", StatusCode: "added"}}
+	if len(status) != len(expected) {
+		t.Fatalf("expected %d entries, got %v", len(expected), status)
+	}
+	for i := range expected {
+		if status[i] != expected[i] {
+			t.Errorf("entry %d: expected %+v, got %+v", i, expected[i], status[i])
+		}
+	}
+
+	files, err := mgr.ChangedFiles()
+	if err != nil {
+		t.Fatalf("ChangedFiles returned error: %v", err)
+	}
+	if len(files) != 2 || files[0] != "a.py" || files[1] != "b.py" {
+		t.Errorf("expected [a.py b.py], got %v", files)
+	}
+}
+
+func TestRepoUser(t *testing.T) {
+	repo, tmpDir := initTestRepo(t)
+
+	cfg, err := repo.Config()
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	cfg.User.Name = "Jane Developer"
+	cfg.User.Email = "jane@example.com"
+	if err := repo.SetConfig(cfg); err != nil {
+		t.Fatalf("failed to set config: %v", err)
+	}
+
+	name, email, err := NewManager(tmpDir).RepoUser()
+	if err != nil {
+		t.Fatalf("RepoUser returned error: %v", err)
+	}
+	if name != "Jane Developer" || email != "jane@example.com" {
+		t.Errorf("expected repo-local user, got %q <%q>", name, email)
+	}
+}
@@ -0,0 +1,351 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+type ErrMergeConflict struct {
+	Commit string
+	Files  []string
+}
+
+func (e *ErrMergeConflict) Error() string {
+	return fmt.Sprintf("checkpoint %s conflicts with target branch in %s", e.Commit, strings.Join(e.Files, ", "))
+}
+
+type checkpointChange struct {
+	path    string
+	content *string
+	mode    os.FileMode
+}
+
+type checkpointCommit struct {
+	commit  *object.Commit
+	changes []checkpointChange
+}
+
+func (m *Manager) MergeCheckpoints(into string) error {
+	if err := m.open(); err != nil {
+		return err
+	}
+
+	head, err := m.repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	if !head.Name().IsBranch() {
+		return &ErrDetachedHead{Hash: head.Hash().String()}
+	}
+
+	intoName := plumbing.NewBranchReferenceName(into)
+	if intoName == head.Name() {
+		return fmt.Errorf("cannot merge checkpoints of %s into itself", into)
+	}
+
+	intoRef, err := m.repo.Reference(intoName, true)
+	if err != nil {
+		return fmt.Errorf("failed to resolve branch %s: %w", into, err)
+	}
+
+	headCommit, err := m.repo.CommitObject(head.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to get commit: %w", err)
+	}
+	intoCommit, err := m.repo.CommitObject(intoRef.Hash())
+	if err != nil {
+		return m.shallowErr("merge", fmt.Errorf("failed to get commit: %w", err))
+	}
+
+	if intoCommit.Hash == headCommit.Hash {
+		return nil
+	}
+
+	fastForward, err := intoCommit.IsAncestor(headCommit)
+	if err != nil {
+		return m.shallowErr("merge", fmt.Errorf("failed to check ancestry: %w", err))
+	}
+	if fastForward {
+		fastForward, err = onlyCheckpointsSince(headCommit, intoCommit.Hash)
+		if err != nil {
+			return m.shallowErr("merge", err)
+		}
+	}
+	if fastForward {
+		if err := m.repo.Storer.SetReference(plumbing.NewHashReference(intoName, headCommit.Hash)); err != nil {
+			return fmt.Errorf("failed to fast-forward %s: %w", into, err)
+		}
+		return nil
+	}
+
+	checkpoints, err := m.checkpointsSince(headCommit, intoCommit)
+	if err != nil {
+		return err
+	}
+	if len(checkpoints) == 0 {
+		return nil
+	}
+
+	if err := simulateCherryPicks(intoCommit, checkpoints); err != nil {
+		return err
+	}
+
+	clean, err := m.IsClean()
+	if err != nil {
+		return err
+	}
+	if !clean {
+		return fmt.Errorf("working tree has uncommitted changes; commit or stash them before merging checkpoints")
+	}
+
+	return m.applyCherryPicks(head.Name(), intoName, checkpoints)
+}
+
+// onlyCheckpointsSince reports whether every commit between base and head was
+// made by reducto, so moving the target ref cannot carry user commits along.
+func onlyCheckpointsSince(head *object.Commit, base plumbing.Hash) (bool, error) {
+	for c := head; c.Hash != base; {
+		if c.NumParents() != 1 || !isCheckpointCommit(c) {
+			return false, nil
+		}
+		parent, err := c.Parent(0)
+		if err != nil {
+			return false, fmt.Errorf("failed to get parent of %s: %w", c.Hash.String()[:8], err)
+		}
+		c = parent
+	}
+	return true, nil
+}
+
+func (m *Manager) checkpointsSince(head, into *object.Commit) ([]checkpointCommit, error) {
+	bases, err := head.MergeBase(into)
+	if err != nil {
+		return nil, m.shallowErr("merge", fmt.Errorf("failed to find merge base: %w", err))
+	}
+	if len(bases) == 0 {
+		return nil, m.shallowErr("merge", fmt.Errorf("branches have no common history"))
+	}
+	base := bases[0].Hash
+
+	boundary, err := m.shallowBoundary()
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*object.Commit
+	for c := head; c.Hash != base; {
+		if boundary[c.Hash] {
+			return nil, &ErrShallowRepo{Op: "merge"}
+		}
+		if c.NumParents() != 1 {
+			return nil, fmt.Errorf("cannot replay merge commit %s", c.Hash.String()[:8])
+		}
+		if isCheckpointCommit(c) {
+			commits = append(commits, c)
+		}
+		parent, err := c.Parent(0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent of %s: %w", c.Hash.String()[:8], err)
+		}
+		c = parent
+	}
+
+	checkpoints := make([]checkpointCommit, 0, len(commits))
+	for i := len(commits) - 1; i >= 0; i-- {
+		changes, err := commitChanges(commits[i])
+		if err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, checkpointCommit{commit: commits[i], changes: changes})
+	}
+
+	return checkpoints, nil
+}
+
+func commitChanges(c *object.Commit) ([]checkpointChange, error) {
+	parent, err := c.Parent(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent of %s: %w", c.Hash.String()[:8], err)
+	}
+
+	parentTree, err := parent.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree: %w", err)
+	}
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree: %w", err)
+	}
+
+	diff, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %s: %w", c.Hash.String()[:8], err)
+	}
+
+	var changes []checkpointChange
+	for _, change := range diff {
+		if change.From.Name != "" && change.From.Name != change.To.Name {
+			changes = append(changes, checkpointChange{path: change.From.Name})
+		}
+		if change.To.Name == "" {
+			changes = append(changes, checkpointChange{path: change.From.Name})
+			continue
+		}
+
+		content, _, err := readTreeFile(tree, change.To.Name)
+		if err != nil {
+			return nil, err
+		}
+		mode, err := change.To.TreeEntry.Mode.ToOSFileMode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get mode of %s: %w", change.To.Name, err)
+		}
+		changes = append(changes, checkpointChange{path: change.To.Name, content: &content, mode: mode.Perm()})
+	}
+
+	return changes, nil
+}
+
+func simulateCherryPicks(into *object.Commit, checkpoints []checkpointCommit) error {
+	intoTree, err := into.Tree()
+	if err != nil {
+		return fmt.Errorf("failed to get tree: %w", err)
+	}
+
+	state := make(map[string]*string)
+	current := func(path string) (*string, error) {
+		if content, ok := state[path]; ok {
+			return content, nil
+		}
+		content, found, err := readTreeFile(intoTree, path)
+		if err != nil || !found {
+			return nil, err
+		}
+		return &content, nil
+	}
+
+	for _, cp := range checkpoints {
+		parent, err := cp.commit.Parent(0)
+		if err != nil {
+			return fmt.Errorf("failed to get parent: %w", err)
+		}
+		parentTree, err := parent.Tree()
+		if err != nil {
+			return fmt.Errorf("failed to get tree: %w", err)
+		}
+
+		var conflicts []string
+		for _, change := range cp.changes {
+			target, err := current(change.path)
+			if err != nil {
+				return err
+			}
+
+			original, found, err := readTreeFile(parentTree, change.path)
+			if err != nil {
+				return err
+			}
+			var before *string
+			if found {
+				before = &original
+			}
+
+			if !sameContent(target, before) && !sameContent(target, change.content) {
+				conflicts = append(conflicts, change.path)
+				continue
+			}
+			state[change.path] = change.content
+		}
+
+		if len(conflicts) > 0 {
+			sort.Strings(conflicts)
+			return &ErrMergeConflict{Commit: cp.commit.Hash.String()[:8], Files: conflicts}
+		}
+	}
+
+	return nil
+}
+
+func sameContent(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func (m *Manager) applyCherryPicks(source, into plumbing.ReferenceName, checkpoints []checkpointCommit) error {
+	wt, err := m.repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+
+	original, err := m.repo.Reference(into, true)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", into.Short(), err)
+	}
+
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: into}); err != nil {
+		return fmt.Errorf("failed to check out %s: %w", into.Short(), err)
+	}
+
+	applyErr := m.replayCheckpoints(wt, checkpoints)
+	if applyErr != nil {
+		if err := m.repo.Storer.SetReference(plumbing.NewHashReference(into, original.Hash())); err != nil {
+			return fmt.Errorf("failed to restore %s after %v: %w", into.Short(), applyErr, err)
+		}
+	}
+
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: source, Force: applyErr != nil}); err != nil {
+		return fmt.Errorf("failed to return to %s: %w", source.Short(), err)
+	}
+
+	return applyErr
+}
+
+func (m *Manager) replayCheckpoints(wt *git.Worktree, checkpoints []checkpointCommit) error {
+	for _, cp := range checkpoints {
+		for _, change := range cp.changes {
+			full := filepath.Join(m.path, filepath.FromSlash(change.path))
+
+			if change.content == nil {
+				if _, err := wt.Remove(change.path); err != nil {
+					return fmt.Errorf("failed to remove %s: %w", change.path, err)
+				}
+				continue
+			}
+
+			if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+				return fmt.Errorf("failed to create directory for %s: %w", change.path, err)
+			}
+			if err := os.WriteFile(full, []byte(*change.content), change.mode); err != nil {
+				return fmt.Errorf("failed to write %s: %w", change.path, err)
+			}
+			// WriteFile only applies the mode when it creates the file.
+			if err := os.Chmod(full, change.mode); err != nil {
+				return fmt.Errorf("failed to set mode of %s: %w", change.path, err)
+			}
+			if _, err := wt.Add(change.path); err != nil {
+				return fmt.Errorf("failed to stage %s: %w", change.path, err)
+			}
+		}
+
+		author := cp.commit.Author
+		_, err := wt.Commit(cp.commit.Message, &git.CommitOptions{
+			Author:            &author,
+			Committer:         committerSignature(),
+			AllowEmptyCommits: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to commit checkpoint %s: %w", cp.commit.Hash.String()[:8], err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+)
+
+func setupCheckpointBranch(t *testing.T) (*git.Repository, string, *Manager) {
+	t.Helper()
+
+	repo, dir := initTestRepo(t)
+	commitFile(t, repo, dir, "app.py", "print('v1')\n", "initial")
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("main"), head.Hash())); err != nil {
+		t.Fatalf("failed to create main: %v", err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("session"), Create: true}); err != nil {
+		t.Fatalf("failed to create session branch: %v", err)
+	}
+
+	mgr := NewManager(dir)
+	if err := os.WriteFile(filepath.Join(dir, "generated.py"), []byte("def helper():\n    pass\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := mgr.CreateCheckpoint("reducto: extract helper"); err != nil {
+		t.Fatalf("CreateCheckpoint returned error: %v", err)
+	}
+
+	return repo, dir, mgr
+}
+
+func checkoutBranch(t *testing.T, repo *git.Repository, name string) {
+	t.Helper()
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	if err := wt.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(name)}); err != nil {
+		t.Fatalf("failed to check out %s: %v", name, err)
+	}
+}
+
+func readBranchFile(t *testing.T, repo *git.Repository, branch, path string) (string, bool) {
+	t.Helper()
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		t.Fatalf("failed to resolve %s: %v", branch, err)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatalf("failed to get commit: %v", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		t.Fatalf("failed to get tree: %v", err)
+	}
+	content, found, err := readTreeFile(tree, path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	return content, found
+}
+
+func TestMergeCheckpoints(t *testing.T) {
+	t.Run("fast-forward", func(t *testing.T) {
+		repo, _, mgr := setupCheckpointBranch(t)
+
+		if err := mgr.MergeCheckpoints("main"); err != nil {
+			t.Fatalf("MergeCheckpoints returned error: %v", err)
+		}
+		if _, found := readBranchFile(t, repo, "main", "generated.py"); !found {
+			t.Error("expected generated.py on main after fast-forward")
+		}
+	})
+
+	t.Run("cherry-pick onto diverged branch", func(t *testing.T) {
+		repo, dir, mgr := setupCheckpointBranch(t)
+
+		checkoutBranch(t, repo, "main")
+		commitFile(t, repo, dir, "README.md", "docs\n", "add docs")
+		checkoutBranch(t, repo, "session")
+
+		if err := mgr.MergeCheckpoints("main"); err != nil {
+			t.Fatalf("MergeCheckpoints returned error: %v", err)
+		}
+
+		if content, found := readBranchFile(t, repo, "main", "generated.py"); !found || content != "def helper():\n    pass\n" {
+			t.Errorf("expected generated.py to land on main, got %q (found=%v)", content, found)
+		}
+		if _, found := readBranchFile(t, repo, "main", "README.md"); !found {
+			t.Error("expected main's own changes to be kept")
+		}
+
+		branch, err := mgr.CurrentBranch()
+		if err != nil || branch != "session" {
+			t.Errorf("expected to stay on session branch, got %q (%v)", branch, err)
+		}
+	})
+
+	t.Run("user commits are not fast-forwarded", func(t *testing.T) {
+		repo, dir, mgr := setupCheckpointBranch(t)
+		commitFile(t, repo, dir, "notes.txt", "wip\n", "user work in progress")
+
+		if err := mgr.MergeCheckpoints("main"); err != nil {
+			t.Fatalf("MergeCheckpoints returned error: %v", err)
+		}
+
+		if _, found := readBranchFile(t, repo, "main", "generated.py"); !found {
+			t.Error("expected the checkpoint to land on main")
+		}
+		if _, found := readBranchFile(t, repo, "main", "notes.txt"); found {
+			t.Error("expected the user commit to stay off main")
+		}
+	})
+
+	t.Run("executable mode is preserved", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file modes are not tracked on windows")
+		}
+		repo, dir, mgr := setupCheckpointBranch(t)
+
+		if err := os.WriteFile(filepath.Join(dir, "run.sh"), []byte("#!/bin/sh\necho hi\n"), 0755); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		if err := mgr.CreateCheckpoint("reducto: add script"); err != nil {
+			t.Fatalf("CreateCheckpoint returned error: %v", err)
+		}
+
+		checkoutBranch(t, repo, "main")
+		commitFile(t, repo, dir, "README.md", "docs\n", "add docs")
+		checkoutBranch(t, repo, "session")
+
+		if err := mgr.MergeCheckpoints("main"); err != nil {
+			t.Fatalf("MergeCheckpoints returned error: %v", err)
+		}
+
+		ref, err := repo.Reference(plumbing.NewBranchReferenceName("main"), true)
+		if err != nil {
+			t.Fatalf("failed to resolve main: %v", err)
+		}
+		commit, err := repo.CommitObject(ref.Hash())
+		if err != nil {
+			t.Fatalf("failed to get commit: %v", err)
+		}
+		file, err := commit.File("run.sh")
+		if err != nil {
+			t.Fatalf("failed to get run.sh: %v", err)
+		}
+		if file.Mode != filemode.Executable {
+			t.Errorf("expected run.sh to stay executable, got mode %v", file.Mode)
+		}
+	})
+
+	t.Run("conflict leaves repo unmodified", func(t *testing.T) {
+		repo, dir, mgr := setupCheckpointBranch(t)
+
+		if err := os.WriteFile(filepath.Join(dir, "app.py"), []byte("print('checkpoint')\n"), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		if err := mgr.CreateCheckpoint("reducto: rewrite app"); err != nil {
+			t.Fatalf("CreateCheckpoint returned error: %v", err)
+		}
+
+		checkoutBranch(t, repo, "main")
+		commitFile(t, repo, dir, "app.py", "print('main')\n", "edit on main")
+		checkoutBranch(t, repo, "session")
+
+		before, err := repo.Reference(plumbing.NewBranchReferenceName("main"), true)
+		if err != nil {
+			t.Fatalf("failed to resolve main: %v", err)
+		}
+
+		err = mgr.MergeCheckpoints("main")
+		var conflict *ErrMergeConflict
+		if !errors.As(err, &conflict) {
+			t.Fatalf("expected ErrMergeConflict, got %v", err)
+		}
+		if len(conflict.Files) != 1 || conflict.Files[0] != "app.py" {
+			t.Errorf("expected conflict in app.py, got %v", conflict.Files)
+		}
+
+		after, err := repo.Reference(plumbing.NewBranchReferenceName("main"), true)
+		if err != nil {
+			t.Fatalf("failed to resolve main: %v", err)
+		}
+		if after.Hash() != before.Hash() {
+			t.Error("expected main to be left unmodified")
+		}
+		if _, found := readBranchFile(t, repo, "main", "generated.py"); found {
+			t.Error("expected no checkpoints to be applied on conflict")
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+type ErrShallowRepo struct {
+	Op string
+}
+
+func (e *ErrShallowRepo) Error() string {
+	return fmt.Sprintf("%s needs history beyond the shallow clone boundary; run `git fetch --unshallow` and retry", e.Op)
+}
+
+func (m *Manager) IsShallow() (bool, error) {
+	boundary, err := m.shallowBoundary()
+	if err != nil {
+		return false, err
+	}
+	return len(boundary) > 0, nil
+}
+
+func (m *Manager) shallowBoundary() (map[plumbing.Hash]bool, error) {
+	if err := m.open(); err != nil {
+		return nil, err
+	}
+
+	hashes, err := m.repo.Storer.Shallow()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shallow commits: %w", err)
+	}
+
+	boundary := make(map[plumbing.Hash]bool, len(hashes))
+	for _, h := range hashes {
+		boundary[h] = true
+	}
+	return boundary, nil
+}
+
+// shallowErr replaces a history lookup failure with ErrShallowRepo when the
+// repository is shallow, since the missing commits are the likely cause.
+func (m *Manager) shallowErr(op string, err error) error {
+	if shallow, shallowErr := m.IsShallow(); shallowErr == nil && shallow {
+		return &ErrShallowRepo{Op: op}
+	}
+	return err
+}
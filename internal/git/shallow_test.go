@@ -0,0 +1,139 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+func markShallow(t *testing.T, dir string, hashes ...plumbing.Hash) {
+	t.Helper()
+
+	var content string
+	for _, h := range hashes {
+		content += h.String() + "\n"
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".git", "shallow"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write shallow file: %v", err)
+	}
+}
+
+func TestIsShallow(t *testing.T) {
+	repo, tmpDir := initTestRepo(t)
+	head := commitFile(t, repo, tmpDir, "a.txt", "a", "add a")
+
+	shallow, err := NewManager(tmpDir).IsShallow()
+	if err != nil {
+		t.Fatalf("IsShallow returned error: %v", err)
+	}
+	if shallow {
+		t.Error("expected full clone not to be shallow")
+	}
+
+	markShallow(t, tmpDir, head)
+
+	shallow, err = NewManager(tmpDir).IsShallow()
+	if err != nil {
+		t.Fatalf("IsShallow returned error: %v", err)
+	}
+	if !shallow {
+		t.Error("expected repository with .git/shallow to be shallow")
+	}
+}
+
+func TestRollbackShallowGuard(t *testing.T) {
+	tests := []struct {
+		name      string
+		boundary  int
+		rollback  int
+		wantGuard bool
+	}{
+		{"depth one", 2, 1, true},
+		{"crosses boundary", 1, 2, true},
+		{"within boundary", 1, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, tmpDir := initTestRepo(t)
+			hashes := []plumbing.Hash{
+				commitFile(t, repo, tmpDir, "a.txt", "a", "add a"),
+				commitFile(t, repo, tmpDir, "b.txt", "b", "add b"),
+				commitFile(t, repo, tmpDir, "c.txt", "c", "add c"),
+			}
+			markShallow(t, tmpDir, hashes[tt.boundary])
+
+			mgr := NewManager(tmpDir)
+			var err error
+			if tt.rollback == 1 {
+				err = mgr.Rollback()
+			} else {
+				err = mgr.RollbackN(tt.rollback)
+			}
+
+			var shallowErr *ErrShallowRepo
+			if got := errors.As(err, &shallowErr); got != tt.wantGuard {
+				t.Fatalf("expected shallow guard=%v, got err %v", tt.wantGuard, err)
+			}
+			if tt.wantGuard {
+				if _, statErr := os.Stat(filepath.Join(tmpDir, "c.txt")); statErr != nil {
+					t.Error("expected worktree to be untouched when guard trips")
+				}
+			} else if err != nil {
+				t.Fatalf("Rollback returned error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDiffWorktreeShallowGuard(t *testing.T) {
+	repo, tmpDir := initTestRepo(t)
+	first := commitFile(t, repo, tmpDir, "a.txt", "a", "add a")
+	second := commitFile(t, repo, tmpDir, "b.txt", "b", "add b")
+
+	// A shallow clone has the boundary commit but none of its ancestors.
+	markShallow(t, tmpDir, second)
+	object := first.String()
+	if err := os.Remove(filepath.Join(tmpDir, ".git", "objects", object[:2], object[2:])); err != nil {
+		t.Fatalf("failed to remove commit object: %v", err)
+	}
+
+	_, err := NewManager(tmpDir).DiffWorktree(object)
+	var shallowErr *ErrShallowRepo
+	if !errors.As(err, &shallowErr) {
+		t.Fatalf("expected ErrShallowRepo, got %v", err)
+	}
+}
+
+func TestMergeCheckpointsShallowGuard(t *testing.T) {
+	repo, dir, mgr := setupCheckpointBranch(t)
+
+	boundary, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "second.py"), []byte("x = 1\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := mgr.CreateCheckpoint("reducto: second"); err != nil {
+		t.Fatalf("CreateCheckpoint returned error: %v", err)
+	}
+
+	checkoutBranch(t, repo, "main")
+	commitFile(t, repo, dir, "README.md", "docs\n", "add docs")
+	checkoutBranch(t, repo, "session")
+
+	markShallow(t, dir, boundary.Hash())
+
+	err = NewManager(dir).MergeCheckpoints("main")
+	var shallowErr *ErrShallowRepo
+	if !errors.As(err, &shallowErr) {
+		t.Fatalf("expected ErrShallowRepo, got %v", err)
+	}
+	if _, found := readBranchFile(t, repo, "main", "second.py"); found {
+		t.Error("expected main to be left unmodified")
+	}
+}
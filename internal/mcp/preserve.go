@@ -0,0 +1,47 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// fileAttrs records what a rewrite must keep from the original file: its
+// permission bits and whether it used CRLF line endings.
+type fileAttrs struct {
+	mode os.FileMode
+	crlf bool
+}
+
+func captureFileAttrs(path string, content string) (fileAttrs, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileAttrs{}, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	return fileAttrs{mode: info.Mode().Perm(), crlf: usesCRLF(content)}, nil
+}
+
+func usesCRLF(content string) bool {
+	crlf := strings.Count(content, "\r\n")
+	lf := strings.Count(content, "\n") - crlf
+	return crlf > 0 && crlf >= lf
+}
+
+func toLF(content string) string {
+	return strings.ReplaceAll(content, "\r\n", "\n")
+}
+
+func (a fileAttrs) restoreLineEndings(content string) string {
+	if !a.crlf {
+		return content
+	}
+	return strings.ReplaceAll(toLF(content), "\n", "\r\n")
+}
+
+func writePreserving(path string, content string, attrs fileAttrs) error {
+	if err := os.WriteFile(path, []byte(attrs.restoreLineEndings(content)), attrs.mode); err != nil {
+		return err
+	}
+	// WriteFile only applies the mode when it creates the file.
+	return os.Chmod(path, attrs.mode)
+}
@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestApplyDiffPreservesFileAttributes(t *testing.T) {
+	diff := `--- a/file
++++ b/file
+@@ -1,2 +1,2 @@
+ line1
+-line2
++changed
+`
+
+	tests := []struct {
+		name     string
+		content  string
+		mode     os.FileMode
+		expected string
+	}{
+		{
+			name:     "executable script",
+			content:  "line1\nline2\n",
+			mode:     0755,
+			expected: "line1\nchanged\n",
+		},
+		{
+			name:     "crlf file",
+			content:  "line1\r\nline2\r\n",
+			mode:     0644,
+			expected: "line1\r\nchanged\r\n",
+		},
+		{
+			name:     "lf file",
+			content:  "line1\nline2\n",
+			mode:     0600,
+			expected: "line1\nchanged\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			path := filepath.Join(root, "file")
+			if err := os.WriteFile(path, []byte(tt.content), tt.mode); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+			if err := os.Chmod(path, tt.mode); err != nil {
+				t.Fatalf("failed to chmod file: %v", err)
+			}
+
+			params, err := json.Marshal(map[string]string{"path": "file", "diff": diff})
+			if err != nil {
+				t.Fatalf("failed to marshal params: %v", err)
+			}
+
+			s := NewServer(root)
+			if _, err := s.handleApplyDiff(context.Background(), params); err != nil {
+				t.Fatalf("handleApplyDiff returned error: %v", err)
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read file: %v", err)
+			}
+			if string(content) != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, content)
+			}
+
+			if runtime.GOOS == "windows" {
+				return
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("failed to stat file: %v", err)
+			}
+			if info.Mode().Perm() != tt.mode {
+				t.Errorf("expected mode %v, got %v", tt.mode, info.Mode().Perm())
+			}
+		})
+	}
+}
+
+func TestUsesCRLF(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected bool
+	}{
+		{"a\r\nb\r\n", true},
+		{"a\nb\n", false},
+		{"a\r\nb\nc\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := usesCRLF(tt.content); got != tt.expected {
+			t.Errorf("usesCRLF(%q) = %v, expected %v", tt.content, got, tt.expected)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -17,6 +18,7 @@ import (
 	"github.com/alexkarsten/reducto/internal/lsp"
 	"github.com/alexkarsten/reducto/internal/runner"
 	"github.com/alexkarsten/reducto/internal/walker"
+	"github.com/alexkarsten/reducto/internal/workflow"
 	"github.com/alexkarsten/reducto/pkg/models"
 )
 
@@ -559,12 +561,22 @@ func (s *Server) handleApplyDiff(ctx context.Context, params json.RawMessage) (i
 		return nil, NewError(FileNotFound, "Failed to read file", err.Error())
 	}
 
-	newContent, err := ApplyUnifiedDiff(string(content), input.Diff)
+	attrs, err := captureFileAttrs(fullPath, string(content))
+	if err != nil {
+		return nil, NewError(FileNotFound, "Failed to read file", err.Error())
+	}
+
+	original, diff := string(content), input.Diff
+	if attrs.crlf {
+		original, diff = toLF(original), toLF(diff)
+	}
+
+	newContent, err := ApplyUnifiedDiff(original, diff)
 	if err != nil {
 		return nil, NewError(ParseFailure, "Failed to apply diff", err.Error())
 	}
 
-	if err := os.WriteFile(fullPath, []byte(newContent), 0644); err != nil {
+	if err := writePreserving(fullPath, newContent, attrs); err != nil {
 		return nil, NewError(InternalError, "Failed to write file", err.Error())
 	}
 
@@ -580,6 +592,7 @@ func (s *Server) handleApplyDiffSafe(ctx context.Context, params json.RawMessage
 		Diff        string `json:"diff"`
 		SessionID   string `json:"session_id,omitempty"`
 		RunTests    bool   `json:"run_tests,omitempty"`
+		VerifyBuild bool   `json:"verify_build,omitempty"`
 		TestCommand string `json:"test_command,omitempty"`
 	}
 	if err := json.Unmarshal(params, &input); err != nil {
@@ -603,6 +616,28 @@ func (s *Server) handleApplyDiffSafe(ctx context.Context, params json.RawMessage
 		return nil, err
 	}
 
+	if input.VerifyBuild {
+		verification, err := workflow.VerifyChanges(s.gitMgr, s.runner, workflow.VerifyOptions{VerifyBuild: true})
+		if err != nil {
+			result := map[string]interface{}{
+				"success":      false,
+				"path":         input.Path,
+				"checkpoint":   checkpointHash,
+				"build_passed": false,
+				"tests_run":    false,
+				"tests_passed": false,
+				"rolled_back":  verification.RolledBack,
+				"error":        err.Error(),
+			}
+			var verr *workflow.VerificationError
+			if errors.As(err, &verr) {
+				result["build_output"] = verr.Output
+				result["build_errors"] = verr.BuildErrors
+			}
+			return result, nil
+		}
+	}
+
 	if !input.RunTests {
 		return map[string]interface{}{
 			"success":      true,
@@ -696,7 +731,16 @@ func (s *Server) handleGitCheckpoint(ctx context.Context, params json.RawMessage
 		input.Message = "checkpoint before refactoring"
 	}
 
-	if err := s.gitMgr.CreateCheckpoint(input.Message); err != nil {
+	err := s.gitMgr.CreateCheckpoint(input.Message)
+	var createdBranch string
+	var detached *git.ErrDetachedHead
+	if errors.As(err, &detached) {
+		createdBranch, err = s.gitMgr.AttachHead()
+		if err == nil {
+			err = s.gitMgr.CreateCheckpoint(input.Message)
+		}
+	}
+	if err != nil {
 		return nil, NewError(GitConflict, "Failed to create checkpoint", err.Error())
 	}
 
@@ -705,10 +749,14 @@ func (s *Server) handleGitCheckpoint(ctx context.Context, params json.RawMessage
 		return nil, NewError(InternalError, "Failed to get commit hash", err.Error())
 	}
 
-	return map[string]interface{}{
+	result := map[string]interface{}{
 		"success":     true,
 		"commit_hash": commit,
-	}, nil
+	}
+	if createdBranch != "" {
+		result["created_branch"] = createdBranch
+	}
+	return result, nil
 }
 
 func (s *Server) handleGitRollback(ctx context.Context, params json.RawMessage) (interface{}, error) {
@@ -728,8 +776,7 @@ func (s *Server) handleListFiles(ctx context.Context, params json.RawMessage) (i
 	}
 	json.Unmarshal(params, &input)
 
-	w := walker.New(input.ExcludePatterns, input.IncludePatterns)
-	files, err := w.Walk(s.rootDir)
+	files, err := walker.CollectFiles(s.rootDir, input.ExcludePatterns, input.IncludePatterns)
 	if err != nil {
 		return nil, NewError(InternalError, "Failed to list files", err.Error())
 	}
@@ -0,0 +1,126 @@
+package reporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+type comparisonRow struct {
+	metric       string
+	a, b         float64
+	format       string
+	higherBetter bool
+}
+
+func (r *Reporter) GenerateResultComparison(a, b *models.RefactorResult) error {
+	if a == nil || b == nil {
+		return fmt.Errorf("two results are required for comparison")
+	}
+
+	if err := os.MkdirAll(r.outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filename := fmt.Sprintf("reducto-comparison-%s-vs-%s.md", a.SessionID, b.SessionID)
+	path := filepath.Join(r.outputDir, filename)
+
+	if err := writeFileAtomic(path, []byte(r.formatComparisonMarkdown(a, b))); err != nil {
+		return fmt.Errorf("failed to write comparison report: %w", err)
+	}
+
+	fmt.Printf("Comparison report generated: %s\n", path)
+	return nil
+}
+
+func (r *Reporter) formatComparisonMarkdown(a, b *models.RefactorResult) string {
+	rows := []comparisonRow{
+		{"Lines of Code Reduced", float64(locReduced(a)), float64(locReduced(b)), "%.0f", true},
+		{"Cyclomatic Complexity Reduced", float64(cyclomaticReduced(a)), float64(cyclomaticReduced(b)), "%.0f", true},
+		{"Cognitive Complexity Reduced", float64(cognitiveReduced(a)), float64(cognitiveReduced(b)), "%.0f", true},
+		{"Maintainability Index Delta", maintainabilityDelta(a), maintainabilityDelta(b), "%+.2f", true},
+		{"Files Touched", float64(len(r.extractModifiedFiles(a.Changes))), float64(len(r.extractModifiedFiles(b.Changes))), "%.0f", false},
+		{"Tests Passed", boolScore(a.TestsPassed), boolScore(b.TestsPassed), "", true},
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# reducto Result Comparison\n\n")
+	sb.WriteString(fmt.Sprintf("**A:** %s\n\n", a.SessionID))
+	sb.WriteString(fmt.Sprintf("**B:** %s\n\n", b.SessionID))
+
+	sb.WriteString("| Metric | A | B | Better |\n")
+	sb.WriteString("|--------|---|---|--------|\n")
+
+	winsA, winsB := 0, 0
+	for _, row := range rows {
+		winner := row.winner()
+		switch winner {
+		case "A":
+			winsA++
+		case "B":
+			winsB++
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+			row.metric, row.formatValue(row.a, winner == "A"), row.formatValue(row.b, winner == "B"), winner))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n**Metrics won:** A %d, B %d\n\n", winsA, winsB))
+	sb.WriteString("---\n")
+	sb.WriteString(reportFooter)
+
+	return sb.String()
+}
+
+func (row comparisonRow) winner() string {
+	switch {
+	case row.a == row.b:
+		return "tie"
+	case (row.a > row.b) == row.higherBetter:
+		return "A"
+	default:
+		return "B"
+	}
+}
+
+func (row comparisonRow) formatValue(v float64, best bool) string {
+	var s string
+	if row.format == "" {
+		s = "no"
+		if v > 0 {
+			s = "yes"
+		}
+	} else {
+		s = fmt.Sprintf(row.format, v)
+	}
+
+	if best {
+		return "**" + s + "**"
+	}
+	return s
+}
+
+func locReduced(result *models.RefactorResult) int {
+	return result.MetricsBefore.LinesOfCode - result.MetricsAfter.LinesOfCode
+}
+
+func cyclomaticReduced(result *models.RefactorResult) int {
+	return result.MetricsBefore.CyclomaticComplexity - result.MetricsAfter.CyclomaticComplexity
+}
+
+func cognitiveReduced(result *models.RefactorResult) int {
+	return result.MetricsBefore.CognitiveComplexity - result.MetricsAfter.CognitiveComplexity
+}
+
+func maintainabilityDelta(result *models.RefactorResult) float64 {
+	return result.MetricsAfter.MaintainabilityIndex - result.MetricsBefore.MaintainabilityIndex
+}
+
+func boolScore(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,57 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestGenerateResultComparison(t *testing.T) {
+	a := &models.RefactorResult{
+		SessionID:     "strategy-a",
+		TestsPassed:   true,
+		Changes:       []models.FileChange{{Path: "a.go"}, {Path: "b.go"}},
+		MetricsBefore: models.ComplexityMetrics{LinesOfCode: 1000, CyclomaticComplexity: 50, CognitiveComplexity: 40, MaintainabilityIndex: 60},
+		MetricsAfter:  models.ComplexityMetrics{LinesOfCode: 800, CyclomaticComplexity: 48, CognitiveComplexity: 38, MaintainabilityIndex: 62},
+	}
+	b := &models.RefactorResult{
+		SessionID:     "strategy-b",
+		TestsPassed:   true,
+		Changes:       []models.FileChange{{Path: "a.go"}},
+		MetricsBefore: models.ComplexityMetrics{LinesOfCode: 1000, CyclomaticComplexity: 50, CognitiveComplexity: 40, MaintainabilityIndex: 60},
+		MetricsAfter:  models.ComplexityMetrics{LinesOfCode: 950, CyclomaticComplexity: 35, CognitiveComplexity: 25, MaintainabilityIndex: 70},
+	}
+
+	r := New(&models.Config{})
+	r.outputDir = t.TempDir()
+
+	if err := r.GenerateResultComparison(a, b); err != nil {
+		t.Fatalf("GenerateResultComparison returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(r.outputDir, "reducto-comparison-strategy-a-vs-strategy-b.md"))
+	if err != nil {
+		t.Fatalf("failed to read comparison: %v", err)
+	}
+	out := string(content)
+
+	for _, want := range []string{
+		"| Lines of Code Reduced | **200** | 50 | A |",
+		"| Cyclomatic Complexity Reduced | 2 | **15** | B |",
+		"| Cognitive Complexity Reduced | 2 | **15** | B |",
+		"| Maintainability Index Delta | +2.00 | **+10.00** | B |",
+		"| Files Touched | 2 | **1** | B |",
+		"| Tests Passed | yes | yes | tie |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected comparison to contain %q, got:\n%s", want, out)
+		}
+	}
+
+	if err := r.GenerateResultComparison(a, nil); err == nil {
+		t.Error("expected error when a result is missing")
+	}
+}
@@ -0,0 +1,39 @@
+package reporter
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func MetricsEMA(reports []*models.Report, alpha float64) (models.MetricsDelta, error) {
+	if alpha <= 0 || alpha > 1 {
+		return models.MetricsDelta{}, fmt.Errorf("alpha must be in (0, 1], got %v", alpha)
+	}
+
+	var cyclomatic, cognitive, maintainability float64
+	seeded := false
+	for _, report := range reports {
+		if report == nil {
+			continue
+		}
+		delta := report.MetricsDelta
+		if !seeded {
+			cyclomatic = float64(delta.CyclomaticComplexityDelta)
+			cognitive = float64(delta.CognitiveComplexityDelta)
+			maintainability = delta.MaintainabilityIndexDelta
+			seeded = true
+			continue
+		}
+		cyclomatic = alpha*float64(delta.CyclomaticComplexityDelta) + (1-alpha)*cyclomatic
+		cognitive = alpha*float64(delta.CognitiveComplexityDelta) + (1-alpha)*cognitive
+		maintainability = alpha*delta.MaintainabilityIndexDelta + (1-alpha)*maintainability
+	}
+
+	return models.MetricsDelta{
+		CyclomaticComplexityDelta: int(math.Round(cyclomatic)),
+		CognitiveComplexityDelta:  int(math.Round(cognitive)),
+		MaintainabilityIndexDelta: maintainability,
+	}, nil
+}
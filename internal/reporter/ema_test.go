@@ -0,0 +1,83 @@
+package reporter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestMetricsEMA(t *testing.T) {
+	report := func(cyclomatic, cognitive int, mi float64) *models.Report {
+		return &models.Report{MetricsDelta: models.MetricsDelta{
+			CyclomaticComplexityDelta: cyclomatic,
+			CognitiveComplexityDelta:  cognitive,
+			MaintainabilityIndexDelta: mi,
+		}}
+	}
+
+	tests := []struct {
+		name     string
+		reports  []*models.Report
+		alpha    float64
+		expected models.MetricsDelta
+		wantErr  bool
+	}{
+		{
+			name:     "recent runs weigh more",
+			reports:  []*models.Report{report(0, 0, 0), report(0, 0, 0), report(10, 20, 8)},
+			alpha:    0.5,
+			expected: models.MetricsDelta{CyclomaticComplexityDelta: 5, CognitiveComplexityDelta: 10, MaintainabilityIndexDelta: 4},
+		},
+		{
+			name:     "old noisy run fades",
+			reports:  []*models.Report{report(10, 20, 8), report(0, 0, 0), report(0, 0, 0)},
+			alpha:    0.5,
+			expected: models.MetricsDelta{CyclomaticComplexityDelta: 3, CognitiveComplexityDelta: 5, MaintainabilityIndexDelta: 2},
+		},
+		{
+			name:     "alpha of one keeps latest",
+			reports:  []*models.Report{report(4, 4, 1), nil, report(2, 6, 3)},
+			alpha:    1,
+			expected: models.MetricsDelta{CyclomaticComplexityDelta: 2, CognitiveComplexityDelta: 6, MaintainabilityIndexDelta: 3},
+		},
+		{
+			name:     "no reports",
+			alpha:    0.3,
+			expected: models.MetricsDelta{},
+		},
+		{
+			name:    "zero alpha",
+			reports: []*models.Report{report(1, 1, 1)},
+			alpha:   0,
+			wantErr: true,
+		},
+		{
+			name:    "alpha above one",
+			reports: []*models.Report{report(1, 1, 1)},
+			alpha:   1.5,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MetricsEMA(tt.reports, tt.alpha)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error for invalid alpha")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MetricsEMA returned error: %v", err)
+			}
+
+			if got.CyclomaticComplexityDelta != tt.expected.CyclomaticComplexityDelta ||
+				got.CognitiveComplexityDelta != tt.expected.CognitiveComplexityDelta ||
+				math.Abs(got.MaintainabilityIndexDelta-tt.expected.MaintainabilityIndexDelta) > 1e-9 {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
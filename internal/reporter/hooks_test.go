@@ -0,0 +1,62 @@
+package reporter
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestOnGenerated(t *testing.T) {
+	result := &models.RefactorResult{
+		SessionID:     "hook-session",
+		Changes:       []models.FileChange{{Path: "a.go", Original: "a", Modified: "b"}},
+		MetricsBefore: models.ComplexityMetrics{LinesOfCode: 120},
+		MetricsAfter:  models.ComplexityMetrics{LinesOfCode: 100},
+	}
+
+	t.Run("hooks run in order with path and report", func(t *testing.T) {
+		r := New(&models.Config{})
+		r.outputDir = t.TempDir()
+
+		var calls []string
+		var gotPath string
+		var gotReport *models.Report
+		r.OnGenerated(func(path string, report *models.Report) error {
+			calls = append(calls, "first")
+			gotPath, gotReport = path, report
+			return nil
+		})
+		r.OnGenerated(func(path string, report *models.Report) error {
+			calls = append(calls, "second")
+			return nil
+		})
+
+		if err := r.Generate(result); err != nil {
+			t.Fatalf("Generate returned error: %v", err)
+		}
+
+		if len(calls) != 2 || calls[0] != "first" || calls[1] != "second" {
+			t.Errorf("expected hooks to run in order, got %v", calls)
+		}
+		if want := filepath.Join(r.outputDir, "reducto-report-hook-session.md"); gotPath != want {
+			t.Errorf("expected path %s, got %s", want, gotPath)
+		}
+		if gotReport == nil || gotReport.SessionID != "hook-session" || gotReport.LOCReduced != 20 {
+			t.Errorf("unexpected report %+v", gotReport)
+		}
+	})
+
+	t.Run("hook errors are surfaced", func(t *testing.T) {
+		r := New(&models.Config{})
+		r.outputDir = t.TempDir()
+
+		errUpload := errors.New("upload failed")
+		r.OnGenerated(func(string, *models.Report) error { return errUpload })
+
+		if err := r.Generate(result); !errors.Is(err, errUpload) {
+			t.Errorf("expected hook error to be returned, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,180 @@
+package reporter
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+const htmlStyle = `body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ccc; padding: 4px 8px; }
+pre { background: #f6f8fa; padding: 8px; overflow-x: auto; }
+.diff-add { background: #e6ffed; display: block; }
+.diff-del { background: #ffeef0; display: block; }
+.diff-ctx { display: block; }
+.diff-mod { background: #fffbdd; display: block; }
+.intra-add { background: #acf2bd; text-decoration: none; }
+.intra-del { background: #fdb8c0; }
+.tok-kw { color: #d73a49; font-weight: bold; }
+.tok-str { color: #032f62; }
+.tok-com { color: #6a737d; font-style: italic; }
+.tok-num { color: #005cc5; }
+`
+
+var highlightKeywords = map[models.Language]map[string]bool{
+	models.LanguageGo:         wordSet("break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var nil true false"),
+	models.LanguagePython:     wordSet("and as assert async await break class continue def del elif else except finally for from global if import in is lambda nonlocal not or pass raise return try while with yield None True False"),
+	models.LanguageJavaScript: wordSet("async await break case catch class const continue default delete do else export extends finally for function if import in instanceof let new of return switch this throw try typeof var void while yield null undefined true false"),
+	models.LanguageTypeScript: wordSet("async await break case catch class const continue default delete do else enum export extends finally for function if implements import in instanceof interface let new of private protected public readonly return switch this throw try type typeof var void while yield null undefined true false"),
+}
+
+var highlightTokenRegex = map[models.Language]*regexp.Regexp{
+	models.LanguageGo:         regexp.MustCompile("(//.*)|(\"(?:\\\\.|[^\"\\\\])*\"|`[^`]*`|'(?:\\\\.|[^'\\\\])*')|(\\b\\d+(?:\\.\\d+)?\\b)|([A-Za-z_]\\w*)"),
+	models.LanguagePython:     regexp.MustCompile(`(#.*)|("(?:\\.|[^"\\])*"|'(?:\\.|[^'\\])*')|(\b\d+(?:\.\d+)?\b)|([A-Za-z_]\w*)`),
+	models.LanguageJavaScript: regexp.MustCompile("(//.*)|(\"(?:\\\\.|[^\"\\\\])*\"|'(?:\\\\.|[^'\\\\])*'|`[^`]*`)|(\\b\\d+(?:\\.\\d+)?\\b)|([A-Za-z_$][\\w$]*)"),
+}
+
+func init() {
+	highlightTokenRegex[models.LanguageTypeScript] = highlightTokenRegex[models.LanguageJavaScript]
+}
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+func (r *Reporter) SetSyntaxHighlight(enabled bool) {
+	r.highlight = enabled
+}
+
+func (r *Reporter) formatHTML(report *models.Report, result *models.RefactorResult) string {
+	var sb strings.Builder
+
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString(fmt.Sprintf("<title>reducto Compression Report %s</title>\n", html.EscapeString(report.SessionID)))
+	sb.WriteString("<style>\n" + htmlStyle + "</style>\n</head>\n<body>\n")
+
+	sb.WriteString("<h1>reducto Compression Report</h1>\n")
+	sb.WriteString(fmt.Sprintf("<p><strong>Session ID:</strong> %s</p>\n", html.EscapeString(report.SessionID)))
+	sb.WriteString(fmt.Sprintf("<p><strong>Generated:</strong> %s</p>\n", report.GeneratedAt.Format(time.RFC3339)))
+
+	sb.WriteString("<h2>Summary</h2>\n<table>\n")
+	sb.WriteString("<tr><th>Metric</th><th>Before</th><th>After</th><th>Delta</th></tr>\n")
+	sb.WriteString(fmt.Sprintf("<tr><td>Lines of Code</td><td>%d</td><td>%d</td><td><strong>%d</strong></td></tr>\n",
+		report.LOCBefore, report.LOCAfter, report.LOCReduced))
+	sb.WriteString(fmt.Sprintf("<tr><td>Cyclomatic Complexity</td><td>%d</td><td>%d</td><td>%d</td></tr>\n",
+		result.MetricsBefore.CyclomaticComplexity, result.MetricsAfter.CyclomaticComplexity,
+		report.MetricsDelta.CyclomaticComplexityDelta))
+	sb.WriteString(fmt.Sprintf("<tr><td>Cognitive Complexity</td><td>%d</td><td>%d</td><td>%d</td></tr>\n",
+		result.MetricsBefore.CognitiveComplexity, result.MetricsAfter.CognitiveComplexity,
+		report.MetricsDelta.CognitiveComplexityDelta))
+	sb.WriteString(fmt.Sprintf("<tr><td>Maintainability Index</td><td>%.2f</td><td>%.2f</td><td>%.2f</td></tr>\n",
+		result.MetricsBefore.MaintainabilityIndex, result.MetricsAfter.MaintainabilityIndex,
+		report.MetricsDelta.MaintainabilityIndexDelta))
+	sb.WriteString("</table>\n")
+
+	if len(report.Metadata) > 0 {
+		sb.WriteString("<h2>Context</h2>\n<ul>\n")
+		for _, key := range sortedKeys(report.Metadata) {
+			sb.WriteString(fmt.Sprintf("<li><strong>%s:</strong> %s</li>\n", html.EscapeString(key), html.EscapeString(report.Metadata[key])))
+		}
+		sb.WriteString("</ul>\n")
+	}
+
+	sb.WriteString("<h2>Changes</h2>\n")
+	for i, change := range result.Changes {
+		sb.WriteString(fmt.Sprintf("<h3>%d. %s</h3>\n", i+1, html.EscapeString(change.Path)))
+		sb.WriteString(fmt.Sprintf("<p>%s</p>\n", html.EscapeString(change.Description)))
+		sb.WriteString(r.formatHTMLDiff(change))
+	}
+
+	sb.WriteString("<hr>\n<p><em>Generated by reducto - Semantic Code Compression Engine</em></p>\n")
+	sb.WriteString("</body>\n</html>\n")
+
+	return sb.String()
+}
+
+func (r *Reporter) formatHTMLDiff(change models.FileChange) string {
+	lang := models.LanguageFromPath(change.Path)
+	_, supported := highlightTokenRegex[lang]
+	highlight := r.highlight && supported
+
+	var sb strings.Builder
+	if highlight {
+		sb.WriteString(fmt.Sprintf("<pre><code class=\"language-%s\">", lang))
+	} else {
+		sb.WriteString("<pre>")
+	}
+
+	for _, line := range r.diffLines(change.Original, change.Modified) {
+		class := "diff-ctx"
+		switch line.op {
+		case '+':
+			class = "diff-add"
+		case '-':
+			class = "diff-del"
+		case '~':
+			class = "diff-mod"
+		}
+
+		var body string
+		switch {
+		case line.op == '~':
+			body = formatIntraLineHTML(intraLineDiff(line.orig, line.text))
+		case highlight:
+			body = highlightLine(line.text, lang)
+		default:
+			body = html.EscapeString(line.text)
+		}
+		sb.WriteString(fmt.Sprintf("<span class=\"%s\">%c %s</span>", class, line.op, body))
+	}
+
+	if highlight {
+		sb.WriteString("</code></pre>\n")
+	} else {
+		sb.WriteString("</pre>\n")
+	}
+	return sb.String()
+}
+
+func highlightLine(line string, lang models.Language) string {
+	re := highlightTokenRegex[lang]
+	keywords := highlightKeywords[lang]
+
+	var sb strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringSubmatchIndex(line, -1) {
+		sb.WriteString(html.EscapeString(line[last:m[0]]))
+		token := line[m[0]:m[1]]
+
+		class := ""
+		switch {
+		case m[2] >= 0:
+			class = "tok-com"
+		case m[4] >= 0:
+			class = "tok-str"
+		case m[6] >= 0:
+			class = "tok-num"
+		case keywords[token]:
+			class = "tok-kw"
+		}
+
+		if class == "" {
+			sb.WriteString(html.EscapeString(token))
+		} else {
+			sb.WriteString(fmt.Sprintf("<span class=\"%s\">%s</span>", class, html.EscapeString(token)))
+		}
+		last = m[1]
+	}
+	sb.WriteString(html.EscapeString(line[last:]))
+
+	return sb.String()
+}
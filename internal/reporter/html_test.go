@@ -0,0 +1,67 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestGenerateHTMLHighlighting(t *testing.T) {
+	result := &models.RefactorResult{
+		SessionID: "html-session",
+		Changes: []models.FileChange{
+			{
+				Path:     "main.go",
+				Original: "func add(a, b int) int {\n\treturn a + b\n}",
+				Modified: "func add(a, b int) int {\n\treturn b + a // swapped\n}",
+			},
+			{
+				Path:     "notes.txt",
+				Original: "<old>",
+				Modified: "<new>",
+			},
+		},
+	}
+
+	r := New(&models.Config{OutputFormat: "html"})
+	r.outputDir = t.TempDir()
+	r.SetSyntaxHighlight(true)
+
+	if err := r.Generate(result); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(r.outputDir, "reducto-report-html-session.html"))
+	if err != nil {
+		t.Fatalf("failed to read HTML report: %v", err)
+	}
+	out := string(content)
+
+	for _, want := range []string{
+		`<code class="language-go">`,
+		`<span class="tok-kw">func</span>`,
+		`<span class="diff-add">+ 	<span class="tok-kw">return</span> b + a <span class="tok-com">// swapped</span></span>`,
+		`<span class="diff-del">- 	<span class="tok-kw">return</span> a + b</span>`,
+		"<pre><span class=\"diff-del\">- &lt;old&gt;</span>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected HTML to contain %q", want)
+		}
+	}
+
+	if strings.Contains(out, "language-unknown") {
+		t.Error("expected plain <pre> fallback for unsupported languages")
+	}
+}
+
+func TestFormatHTMLDiffWithoutHighlighting(t *testing.T) {
+	r := New(&models.Config{OutputFormat: "html"})
+
+	out := r.formatHTMLDiff(models.FileChange{Path: "main.go", Original: "a", Modified: "b"})
+	if strings.Contains(out, "language-go") || strings.Contains(out, "tok-") {
+		t.Errorf("expected plain output when highlighting is off, got %s", out)
+	}
+}
@@ -0,0 +1,124 @@
+package reporter
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+type diffSpan struct {
+	op   byte
+	text string
+}
+
+func tokenizeWords(s string) []string {
+	var tokens []string
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); {
+		j := i + 1
+		switch {
+		case isWordRune(runes[i]):
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+		case unicode.IsSpace(runes[i]):
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, string(runes[i:j]))
+		i = j
+	}
+
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func intraLineDiff(original, modified string) []diffSpan {
+	a := tokenizeWords(original)
+	b := tokenizeWords(modified)
+
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var spans []diffSpan
+	add := func(op byte, text string) {
+		if n := len(spans); n > 0 && spans[n-1].op == op {
+			spans[n-1].text += text
+			return
+		}
+		spans = append(spans, diffSpan{op: op, text: text})
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			add('=', a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			add('-', a[i])
+			i++
+		default:
+			add('+', b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		add('-', a[i])
+	}
+	for ; j < len(b); j++ {
+		add('+', b[j])
+	}
+
+	return spans
+}
+
+func formatIntraLineMarkdown(spans []diffSpan) string {
+	var sb strings.Builder
+	for _, span := range spans {
+		switch span.op {
+		case '-':
+			sb.WriteString("[-" + span.text + "-]")
+		case '+':
+			sb.WriteString("{+" + span.text + "+}")
+		default:
+			sb.WriteString(span.text)
+		}
+	}
+	return sb.String()
+}
+
+func formatIntraLineHTML(spans []diffSpan) string {
+	var sb strings.Builder
+	for _, span := range spans {
+		text := html.EscapeString(span.text)
+		switch span.op {
+		case '-':
+			sb.WriteString(`<del class="intra-del">` + text + `</del>`)
+		case '+':
+			sb.WriteString(`<ins class="intra-add">` + text + `</ins>`)
+		default:
+			sb.WriteString(text)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,45 @@
+package reporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestIntraLineDiff(t *testing.T) {
+	original := "func computeTotal(items []Item, discount float64, taxRate float64) (float64, error) {"
+	modified := "func computeTotal(items []Item, discount float64, taxRate float32) (float64, error) {"
+
+	r := New(&models.Config{})
+
+	t.Run("whole-line diff by default", func(t *testing.T) {
+		diff := r.generateDiff(original, modified)
+		expected := "- " + original + "\n+ " + modified + "\n"
+		if diff != expected {
+			t.Errorf("expected whole-line diff, got:\n%s", diff)
+		}
+	})
+
+	t.Run("markdown markers wrap only the changed token", func(t *testing.T) {
+		r.SetIntraLineDiff(true)
+		defer r.SetIntraLineDiff(false)
+
+		diff := r.generateDiff(original, modified)
+		expected := "~ func computeTotal(items []Item, discount float64, taxRate [-float64-]{+float32+}) (float64, error) {\n"
+		if diff != expected {
+			t.Errorf("expected %q, got %q", expected, diff)
+		}
+	})
+
+	t.Run("html spans wrap only the changed token", func(t *testing.T) {
+		r.SetIntraLineDiff(true)
+		defer r.SetIntraLineDiff(false)
+
+		out := r.formatHTMLDiff(models.FileChange{Path: "calc.go", Original: original, Modified: modified})
+		want := `taxRate <del class="intra-del">float64</del><ins class="intra-add">float32</ins>) (float64, error)`
+		if !strings.Contains(out, want) {
+			t.Errorf("expected HTML to contain %q, got:\n%s", want, out)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+type fileLOCDelta struct {
+	Path   string
+	Before int
+	After  int
+}
+
+func (d fileLOCDelta) Delta() int {
+	return d.After - d.Before
+}
+
+func countLOC(content string) int {
+	count := 0
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count
+}
+
+func perFileLOC(changes []models.FileChange) []fileLOCDelta {
+	var deltas []fileLOCDelta
+	index := make(map[string]int)
+
+	for _, change := range changes {
+		i, ok := index[change.Path]
+		if !ok {
+			i = len(deltas)
+			index[change.Path] = i
+			deltas = append(deltas, fileLOCDelta{Path: change.Path})
+		}
+		deltas[i].Before += countLOC(change.Original)
+		deltas[i].After += countLOC(change.Modified)
+	}
+
+	return deltas
+}
+
+func NetLOCAcrossFiles(changes []models.FileChange) int {
+	net := 0
+	for _, d := range perFileLOC(changes) {
+		net += d.Delta()
+	}
+	return net
+}
+
+func formatLOCMarkdown(changes []models.FileChange) string {
+	deltas := perFileLOC(changes)
+	if len(deltas) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Line Count by File\n\n")
+	sb.WriteString("| File | Before | After | Delta |\n")
+	sb.WriteString("|------|--------|-------|-------|\n")
+
+	removed := 0
+	for _, d := range deltas {
+		sb.WriteString(fmt.Sprintf("| `%s` | %d | %d | %+d |\n", d.Path, d.Before, d.After, d.Delta()))
+		if d.Delta() < 0 {
+			removed -= d.Delta()
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n**Per-file reduction:** %d lines\n\n", removed))
+	sb.WriteString(fmt.Sprintf("**Global net change:** %+d lines (code moved between files is not counted as a reduction)\n\n", NetLOCAcrossFiles(changes)))
+
+	return sb.String()
+}
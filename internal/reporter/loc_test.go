@@ -0,0 +1,67 @@
+package reporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestNetLOCAcrossFiles(t *testing.T) {
+	helper := "func validate(x int) error {\n\tif x < 0 {\n\t\treturn errNegative\n\t}\n\treturn nil\n}\n"
+
+	tests := []struct {
+		name     string
+		changes  []models.FileChange
+		expected int
+	}{
+		{
+			name: "code moved between files",
+			changes: []models.FileChange{
+				{Path: "a.go", Original: "package a\n\n" + helper, Modified: "package a\n"},
+				{Path: "shared.go", Original: "package a\n", Modified: "package a\n\n" + helper},
+			},
+			expected: 0,
+		},
+		{
+			name: "deduplicated code",
+			changes: []models.FileChange{
+				{Path: "a.go", Original: "package a\n\n" + helper, Modified: "package a\n"},
+				{Path: "b.go", Original: "package a\n\n" + helper, Modified: "package a\n"},
+				{Path: "shared.go", Original: "", Modified: "package a\n\n" + helper},
+			},
+			expected: -5,
+		},
+		{
+			name:     "no changes",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NetLOCAcrossFiles(tt.changes); got != tt.expected {
+				t.Errorf("expected net %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFormatLOCMarkdown(t *testing.T) {
+	changes := []models.FileChange{
+		{Path: "a.go", Original: "one\ntwo\nthree", Modified: "one"},
+		{Path: "b.go", Original: "one", Modified: "one\ntwo\nthree"},
+	}
+
+	out := formatLOCMarkdown(changes)
+	for _, want := range []string{
+		"| `a.go` | 3 | 1 | -2 |",
+		"| `b.go` | 1 | 3 | +2 |",
+		"**Per-file reduction:** 2 lines",
+		"**Global net change:** +0 lines",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+const (
+	prCommentLimit     = 65536
+	prCommentTruncated = "\n_Diff summary truncated, see full report for details._\n"
+)
+
+func (r *Reporter) FormatPRComment(report *models.Report) string {
+	var head strings.Builder
+	head.WriteString("### reducto summary\n\n")
+	head.WriteString("| Metric | Delta |\n")
+	head.WriteString("|--------|-------|\n")
+	head.WriteString(fmt.Sprintf("| Lines of Code | %d → %d (**%d** reduced) |\n",
+		report.LOCBefore, report.LOCAfter, report.LOCReduced))
+	head.WriteString(fmt.Sprintf("| Cyclomatic Complexity | %d |\n", report.MetricsDelta.CyclomaticComplexityDelta))
+	head.WriteString(fmt.Sprintf("| Cognitive Complexity | %d |\n", report.MetricsDelta.CognitiveComplexityDelta))
+	head.WriteString(fmt.Sprintf("| Maintainability Index | %.2f |\n", report.MetricsDelta.MaintainabilityIndexDelta))
+	head.WriteString(fmt.Sprintf("| Duplicates Found | %d |\n\n", report.DuplicatesFound))
+
+	var details strings.Builder
+	for _, p := range report.PatternsApplied {
+		details.WriteString(fmt.Sprintf("- **%s**: %d file(s)\n", p.Pattern, len(p.Files)))
+	}
+	for _, file := range report.FilesModified {
+		details.WriteString(fmt.Sprintf("- `%s`\n", file))
+	}
+
+	open := fmt.Sprintf("<details>\n<summary>Changes (%d files)</summary>\n\n", len(report.FilesModified))
+	closing := "\n</details>\n\n"
+	footer := fmt.Sprintf("---\n<sub>Session `%s` · %s</sub>\n", report.SessionID, strings.TrimSpace(reportFooter))
+
+	body := details.String()
+	budget := prCommentLimit - head.Len() - len(open) - len(closing) - len(footer)
+	if len(body) > budget {
+		body = truncateLines(body, budget-len(prCommentTruncated)) + prCommentTruncated
+	}
+
+	var sb strings.Builder
+	sb.WriteString(head.String())
+	sb.WriteString(open)
+	sb.WriteString(body)
+	sb.WriteString(closing)
+	sb.WriteString(footer)
+
+	return sb.String()
+}
+
+func truncateLines(s string, limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	if len(s) <= limit {
+		return s
+	}
+	cut := strings.LastIndex(s[:limit], "\n")
+	if cut < 0 {
+		return ""
+	}
+	return s[:cut+1]
+}
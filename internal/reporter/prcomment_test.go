@@ -0,0 +1,54 @@
+package reporter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestFormatPRComment(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     int
+		truncated bool
+	}{
+		{name: "small diff", files: 3, truncated: false},
+		{name: "huge diff", files: 5000, truncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &models.Report{
+				SessionID:  "abc123",
+				LOCBefore:  100,
+				LOCAfter:   80,
+				LOCReduced: 20,
+			}
+			for i := 0; i < tt.files; i++ {
+				report.FilesModified = append(report.FilesModified,
+					fmt.Sprintf("internal/some/deeply/nested/package/file_%04d.go", i))
+			}
+
+			r := New(&models.Config{})
+			out := r.FormatPRComment(report)
+
+			if !strings.Contains(out, "<details>") || !strings.Contains(out, "</details>") {
+				t.Error("expected a collapsible <details> block")
+			}
+			if !strings.Contains(out, "| Lines of Code |") {
+				t.Error("expected metrics table")
+			}
+			if len(out) > prCommentLimit {
+				t.Errorf("comment length %d exceeds limit %d", len(out), prCommentLimit)
+			}
+			if got := strings.Contains(out, "see full report"); got != tt.truncated {
+				t.Errorf("expected truncated=%v, got %v", tt.truncated, got)
+			}
+			if !strings.HasSuffix(out, "</sub>\n") {
+				t.Error("expected footer at the end of the comment")
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package reporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type PruneOptions struct {
+	MaxAge time.Duration
+	KeepN  int
+	DryRun bool
+}
+
+type pruneCandidate struct {
+	path    string
+	modTime time.Time
+}
+
+var pruneKinds = []struct {
+	dir    string
+	prefix string
+	suffix string
+}{
+	{dir: "", prefix: "reducto-report-"},
+	{dir: "", prefix: "reducto-baseline-", suffix: ".md"},
+	{dir: "", prefix: "reducto-baseline-", suffix: ".json"},
+	{dir: "", prefix: "reducto-comparison-"},
+	{dir: "snapshots", prefix: ""},
+}
+
+func (r *Reporter) Prune(opts PruneOptions) ([]string, error) {
+	now := time.Now()
+
+	var removed []string
+	for _, kind := range pruneKinds {
+		candidates, err := r.pruneCandidates(kind.dir, kind.prefix, kind.suffix)
+		if err != nil {
+			return removed, err
+		}
+
+		for _, c := range selectForPrune(candidates, opts, now) {
+			if !opts.DryRun {
+				if err := os.RemoveAll(c.path); err != nil {
+					return removed, fmt.Errorf("failed to remove %s: %w", c.path, err)
+				}
+			}
+			removed = append(removed, c.path)
+		}
+	}
+
+	return removed, nil
+}
+
+func (r *Reporter) pruneCandidates(dir, prefix, suffix string) ([]pruneCandidate, error) {
+	root := filepath.Join(r.outputDir, dir)
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", root, err)
+	}
+
+	var candidates []pruneCandidate
+	for _, entry := range entries {
+		name := entry.Name()
+		if dir == "" && (entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix)) {
+			continue
+		}
+		if dir != "" && !entry.IsDir() {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", name, err)
+		}
+		candidates = append(candidates, pruneCandidate{
+			path:    filepath.Join(root, name),
+			modTime: info.ModTime(),
+		})
+	}
+
+	return candidates, nil
+}
+
+func selectForPrune(candidates []pruneCandidate, opts PruneOptions, now time.Time) []pruneCandidate {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if !candidates[i].modTime.Equal(candidates[j].modTime) {
+			return candidates[i].modTime.After(candidates[j].modTime)
+		}
+		return candidates[i].path > candidates[j].path
+	})
+
+	var selected []pruneCandidate
+	for i, c := range candidates {
+		tooOld := opts.MaxAge > 0 && now.Sub(c.modTime) > opts.MaxAge
+		overLimit := opts.KeepN > 0 && i >= opts.KeepN
+		if tooOld || overLimit {
+			selected = append(selected, c)
+		}
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].path < selected[j].path
+	})
+	return selected
+}
@@ -0,0 +1,114 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestPrune(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     PruneOptions
+		expected []string
+	}{
+		{
+			name:     "max age",
+			opts:     PruneOptions{MaxAge: 48 * time.Hour},
+			expected: []string{"reducto-report-old.md", "reducto-report-oldest.md", "snapshots/old"},
+		},
+		{
+			name:     "keep most recent",
+			opts:     PruneOptions{KeepN: 2},
+			expected: []string{"reducto-report-old.md", "reducto-report-oldest.md"},
+		},
+		{
+			name:     "age and count combined",
+			opts:     PruneOptions{MaxAge: 96 * time.Hour, KeepN: 1},
+			expected: []string{"reducto-report-mid.md", "reducto-report-old.md", "reducto-report-oldest.md", "snapshots/old"},
+		},
+		{
+			name:     "dry run",
+			opts:     PruneOptions{MaxAge: 48 * time.Hour, DryRun: true},
+			expected: []string{"reducto-report-old.md", "reducto-report-oldest.md", "snapshots/old"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			now := time.Now()
+
+			files := map[string]time.Duration{
+				"reducto-report-new.md":      time.Hour,
+				"reducto-report-mid.md":      24 * time.Hour,
+				"reducto-report-old.md":      72 * time.Hour,
+				"reducto-report-oldest.md":   120 * time.Hour,
+				"reducto-baseline-recent.md": time.Hour,
+				"analyze-cache.json":         200 * time.Hour,
+				"manifest.json":              200 * time.Hour,
+			}
+			dirs := map[string]time.Duration{
+				"snapshots/new": time.Hour,
+				"snapshots/old": 72 * time.Hour,
+			}
+
+			for name, age := range files {
+				path := filepath.Join(dir, name)
+				if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+				if err := os.Chtimes(path, now.Add(-age), now.Add(-age)); err != nil {
+					t.Fatalf("failed to set time on %s: %v", name, err)
+				}
+			}
+			for name, age := range dirs {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(path, 0755); err != nil {
+					t.Fatalf("failed to create %s: %v", name, err)
+				}
+				if err := os.Chtimes(path, now.Add(-age), now.Add(-age)); err != nil {
+					t.Fatalf("failed to set time on %s: %v", name, err)
+				}
+			}
+
+			r := New(&models.Config{})
+			r.outputDir = dir
+
+			removed, err := r.Prune(tt.opts)
+			if err != nil {
+				t.Fatalf("Prune returned error: %v", err)
+			}
+
+			var got []string
+			for _, path := range removed {
+				rel, _ := filepath.Rel(dir, path)
+				got = append(got, filepath.ToSlash(rel))
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected removed %v, got %v", tt.expected, got)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("expected removed %v, got %v", tt.expected, got)
+					break
+				}
+			}
+
+			for _, rel := range tt.expected {
+				_, err := os.Stat(filepath.Join(dir, rel))
+				if exists := err == nil; exists != tt.opts.DryRun {
+					t.Errorf("expected %s exists=%v after prune", rel, tt.opts.DryRun)
+				}
+			}
+			for _, keep := range []string{"analyze-cache.json", "manifest.json", "reducto-baseline-recent.md"} {
+				if _, err := os.Stat(filepath.Join(dir, keep)); err != nil {
+					t.Errorf("expected %s to be preserved", keep)
+				}
+			}
+		})
+	}
+}
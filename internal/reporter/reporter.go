@@ -1,20 +1,35 @@
 package reporter
 
 import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/alexkarsten/reducto/pkg/models"
 )
 
+const fingerprintPrefix = "<!-- fingerprint: "
+
 type Reporter struct {
 	cfg       *models.Config
 	outputDir string
+	force     bool
+	diffOpts  models.DiffOptions
+	highlight bool
+	hooks     []GeneratedHook
+	intraLine bool
 }
 
+type GeneratedHook func(path string, report *models.Report) error
+
 func New(cfg *models.Config) *Reporter {
 	return &Reporter{
 		cfg:       cfg,
@@ -22,12 +37,45 @@ func New(cfg *models.Config) *Reporter {
 	}
 }
 
+func (r *Reporter) SetForce(force bool) {
+	r.force = force
+}
+
+func (r *Reporter) SetIgnoreWhitespace(ignore bool) {
+	r.diffOpts.IgnoreWhitespace = ignore
+}
+
+func (r *Reporter) SetIntraLineDiff(enabled bool) {
+	r.intraLine = enabled
+}
+
+func (r *Reporter) OnGenerated(hook GeneratedHook) {
+	if hook != nil {
+		r.hooks = append(r.hooks, hook)
+	}
+}
+
 func (r *Reporter) Generate(result *models.RefactorResult) error {
 	if err := os.MkdirAll(r.outputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	ext := "md"
+	if r.isHTML() {
+		ext = "html"
+	}
+
+	filename := fmt.Sprintf("reducto-report-%s.%s", result.SessionID, ext)
+	path := filepath.Join(r.outputDir, filename)
+	fingerprint := r.fingerprint(result)
+
+	if !r.force && fingerprint != "" && readFingerprint(path) == fingerprint {
+		fmt.Printf("Report unchanged: %s\n", path)
+		return nil
+	}
+
 	report := &models.Report{
+		SchemaVersion: models.CurrentSchemaVersion,
 		SessionID:     result.SessionID,
 		GeneratedAt:   time.Now(),
 		LOCBefore:     result.MetricsBefore.LinesOfCode,
@@ -39,54 +87,202 @@ func (r *Reporter) Generate(result *models.RefactorResult) error {
 			CognitiveComplexityDelta:  result.MetricsBefore.CognitiveComplexity - result.MetricsAfter.CognitiveComplexity,
 			MaintainabilityIndexDelta: result.MetricsAfter.MaintainabilityIndex - result.MetricsBefore.MaintainabilityIndex,
 		},
+		Fingerprint: fingerprint,
+		Metadata:    result.Metadata,
 	}
 
-	content := r.formatMarkdown(report, result)
-
-	filename := fmt.Sprintf("reducto-report-%s.md", result.SessionID)
-	path := filepath.Join(r.outputDir, filename)
+	var body string
+	if r.isHTML() {
+		body = r.formatHTML(report, result)
+	} else {
+		body = r.formatMarkdown(report, result)
+	}
+	content := fingerprintPrefix + fingerprint + " -->\n" + body
 
-	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+	if err := writeFileAtomic(path, []byte(content)); err != nil {
 		return fmt.Errorf("failed to write report: %w", err)
 	}
 
 	fmt.Printf("Report generated: %s\n", path)
+
+	for i, hook := range r.hooks {
+		if err := hook(path, report); err != nil {
+			return fmt.Errorf("post-generation hook %d failed: %w", i+1, err)
+		}
+	}
+
 	return nil
 }
 
+// fingerprint extends the result's fingerprint with the render options, so
+// regenerating with a different option rewrites the report. It is "" whenever
+// the result has no fingerprint.
+func (r *Reporter) fingerprint(result *models.RefactorResult) string {
+	base := result.Fingerprint()
+	if base == "" {
+		return ""
+	}
+
+	data, err := json.Marshal(struct {
+		Result           string `json:"result"`
+		HTML             bool   `json:"html"`
+		IgnoreWhitespace bool   `json:"ignore_whitespace"`
+		Highlight        bool   `json:"highlight"`
+		IntraLine        bool   `json:"intra_line"`
+	}{base, r.isHTML(), r.diffOpts.IgnoreWhitespace, r.highlight, r.intraLine})
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *Reporter) isHTML() bool {
+	return r.cfg != nil && strings.EqualFold(r.cfg.OutputFormat, "html")
+}
+
+func readFingerprint(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && line == "" {
+		return ""
+	}
+
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, fingerprintPrefix) || !strings.HasSuffix(line, " -->") {
+		return ""
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(line, fingerprintPrefix), " -->")
+}
+
+func (r *Reporter) SaveSnapshots(result *models.RefactorResult) error {
+	if result.SessionID == "" || strings.ContainsAny(result.SessionID, `/\`) || result.SessionID == ".." {
+		return fmt.Errorf("invalid session ID: %q", result.SessionID)
+	}
+
+	snapshotDir := filepath.Join(r.outputDir, "snapshots", result.SessionID)
+
+	for _, change := range result.Changes {
+		base, err := snapshotPath(snapshotDir, change.Path)
+		if err != nil {
+			return err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(base), 0755); err != nil {
+			return fmt.Errorf("failed to create snapshot directory: %w", err)
+		}
+
+		if err := os.WriteFile(base+".orig", []byte(change.Original), 0644); err != nil {
+			return fmt.Errorf("failed to write snapshot for %s: %w", change.Path, err)
+		}
+
+		if err := os.WriteFile(base+".new", []byte(change.Modified), 0644); err != nil {
+			return fmt.Errorf("failed to write snapshot for %s: %w", change.Path, err)
+		}
+	}
+
+	return nil
+}
+
+func snapshotPath(snapshotDir, path string) (string, error) {
+	if path == "" || filepath.IsAbs(path) {
+		return "", fmt.Errorf("invalid snapshot path: %q", path)
+	}
+
+	target := filepath.Join(snapshotDir, filepath.Clean(path))
+	rel, err := filepath.Rel(snapshotDir, target)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("snapshot path escapes output directory: %q", path)
+	}
+
+	return target, nil
+}
+
+const baselinePrefix = "reducto-baseline-"
+
 type BaselineResult struct {
-	TotalFiles   int
-	TotalSymbols int
-	Hotspots     []ComplexityHotspot
+	GeneratedAt        time.Time           `json:"generated_at"`
+	TotalFiles         int                 `json:"total_files"`
+	TotalSymbols       int                 `json:"total_symbols"`
+	Hotspots           []ComplexityHotspot `json:"hotspots,omitempty"`
+	Symbols            []ComplexityHotspot `json:"symbols,omitempty"`
+	SymbolComplexities []int               `json:"symbol_complexities,omitempty"`
 }
 
 type ComplexityHotspot struct {
-	File                 string
-	Line                 int
-	Symbol               string
-	CyclomaticComplexity int
-	CognitiveComplexity  int
+	File                 string `json:"file"`
+	Line                 int    `json:"line"`
+	Symbol               string `json:"symbol"`
+	CyclomaticComplexity int    `json:"cyclomatic_complexity"`
+	CognitiveComplexity  int    `json:"cognitive_complexity"`
 }
 
+// GenerateBaseline writes the markdown report plus a JSON copy of result
+// that LoadBaselines reads back for trend reports.
 func (r *Reporter) GenerateBaseline(result *BaselineResult) error {
 	if err := os.MkdirAll(r.outputDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	sessionID := fmt.Sprintf("baseline-%d", time.Now().Unix())
+	if result.GeneratedAt.IsZero() {
+		result.GeneratedAt = time.Now()
+	}
+
+	sessionID := fmt.Sprintf("baseline-%d", result.GeneratedAt.Unix())
 	content := r.formatBaselineMarkdown(sessionID, result)
 
-	filename := fmt.Sprintf("reducto-baseline-%s.md", time.Now().Format("20060102-150405"))
-	path := filepath.Join(r.outputDir, filename)
+	stamp := result.GeneratedAt.Format("20060102-150405")
+	path := filepath.Join(r.outputDir, baselinePrefix+stamp+".md")
 
-	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+	if err := writeFileAtomic(path, []byte(content)); err != nil {
 		return fmt.Errorf("failed to write baseline report: %w", err)
 	}
 
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal baseline: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(r.outputDir, baselinePrefix+stamp+".json"), data); err != nil {
+		return fmt.Errorf("failed to write baseline data: %w", err)
+	}
+
 	fmt.Printf("Baseline report generated: %s\n", path)
 	return nil
 }
 
+// LoadBaselines returns every baseline saved by GenerateBaseline, oldest first.
+func (r *Reporter) LoadBaselines() ([]*BaselineResult, error) {
+	paths, err := filepath.Glob(filepath.Join(r.outputDir, baselinePrefix+"*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list baselines: %w", err)
+	}
+
+	var baselines []*BaselineResult
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read baseline: %w", err)
+		}
+		var baseline BaselineResult
+		if err := json.Unmarshal(data, &baseline); err != nil {
+			return nil, fmt.Errorf("failed to parse baseline %s: %w", filepath.Base(path), err)
+		}
+		baselines = append(baselines, &baseline)
+	}
+
+	sort.SliceStable(baselines, func(i, j int) bool {
+		return baselines[i].GeneratedAt.Before(baselines[j].GeneratedAt)
+	})
+	return baselines, nil
+}
+
 func (r *Reporter) formatBaselineMarkdown(sessionID string, result *BaselineResult) string {
 	var sb strings.Builder
 
@@ -111,56 +307,177 @@ func (r *Reporter) formatBaselineMarkdown(sessionID string, result *BaselineResu
 		sb.WriteString("\n")
 	}
 
+	if len(result.SymbolComplexities) > 0 {
+		sb.WriteString("## Complexity Distribution\n\n")
+		sb.WriteString("```\n")
+		sb.WriteString(r.formatComplexityHistogram(result.SymbolComplexities))
+		sb.WriteString("```\n\n")
+	}
+
 	sb.WriteString("---\n")
 	sb.WriteString("*Generated by reducto - Semantic Code Compression Engine*\n")
 
 	return sb.String()
 }
 
+type complexityBucket struct {
+	label string
+	min   int
+	max   int
+}
+
+var complexityBuckets = []complexityBucket{
+	{label: "1-5", min: 0, max: 5},
+	{label: "6-10", min: 6, max: 10},
+	{label: "11-20", min: 11, max: 20},
+	{label: "21+", min: 21, max: -1},
+}
+
+const histogramWidth = 40
+
+func (r *Reporter) formatComplexityHistogram(complexities []int) string {
+	counts := make([]int, len(complexityBuckets))
+	for _, cc := range complexities {
+		for i, bucket := range complexityBuckets {
+			if cc >= bucket.min && (bucket.max < 0 || cc <= bucket.max) {
+				counts[i]++
+				break
+			}
+		}
+	}
+
+	maxCount := 0
+	for _, count := range counts {
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+
+	var sb strings.Builder
+	for i, bucket := range complexityBuckets {
+		barLen := 0
+		if maxCount > 0 {
+			barLen = counts[i] * histogramWidth / maxCount
+			if counts[i] > 0 && barLen == 0 {
+				barLen = 1
+			}
+		}
+		sb.WriteString(fmt.Sprintf("%-5s | %s %d\n", bucket.label, strings.Repeat("#", barLen), counts[i]))
+	}
+
+	return sb.String()
+}
+
+const (
+	reportHeader = "# reducto Compression Report"
+	reportFooter = "*Generated by reducto - Semantic Code Compression Engine*\n"
+)
+
 func (r *Reporter) Load(sessionID string) error {
-	if sessionID == "" {
-		entries, err := os.ReadDir(r.outputDir)
+	content, err := r.loadReport(sessionID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(content)
+	return nil
+}
+
+func (r *Reporter) loadReport(sessionID string) (string, error) {
+	if sessionID != "" {
+		path := filepath.Join(r.outputDir, fmt.Sprintf("reducto-report-%s.md", sessionID))
+		content, err := os.ReadFile(path)
 		if err != nil {
-			return fmt.Errorf("no reports found")
-		}
-
-		var latest string
-		var latestTime time.Time
-		for _, entry := range entries {
-			if strings.HasPrefix(entry.Name(), "reducto-report-") && strings.HasSuffix(entry.Name(), ".md") {
-				info, err := entry.Info()
-				if err != nil {
-					continue
-				}
-				if info.ModTime().After(latestTime) {
-					latestTime = info.ModTime()
-					latest = entry.Name()
-				}
+			return "", fmt.Errorf("failed to read report: %w", err)
+		}
+		if !isValidReport(string(content)) {
+			return "", fmt.Errorf("report %s is incomplete or corrupt", sessionID)
+		}
+		return string(content), nil
+	}
+
+	entries, err := os.ReadDir(r.outputDir)
+	if err != nil {
+		return "", fmt.Errorf("no reports found")
+	}
+
+	type candidate struct {
+		name    string
+		modTime time.Time
+	}
+
+	var candidates []candidate
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "reducto-report-") && strings.HasSuffix(entry.Name(), ".md") {
+			info, err := entry.Info()
+			if err != nil {
+				continue
 			}
+			candidates = append(candidates, candidate{name: entry.Name(), modTime: info.ModTime()})
 		}
+	}
 
-		if latest == "" {
-			return fmt.Errorf("no reports found")
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].modTime.After(candidates[j].modTime)
+	})
+
+	for _, c := range candidates {
+		content, err := os.ReadFile(filepath.Join(r.outputDir, c.name))
+		if err != nil || !isValidReport(string(content)) {
+			fmt.Fprintf(os.Stderr, "Warning: skipping unreadable report %s\n", c.name)
+			continue
 		}
+		return string(content), nil
+	}
+
+	return "", fmt.Errorf("no reports found")
+}
+
+func isValidReport(content string) bool {
+	if !utf8.ValidString(content) {
+		return false
+	}
 
-		sessionID = strings.TrimPrefix(latest, "reducto-report-")
-		sessionID = strings.TrimSuffix(sessionID, ".md")
+	if strings.HasPrefix(content, fingerprintPrefix) {
+		return strings.HasSuffix(content, reportFooter)
 	}
 
-	path := filepath.Join(r.outputDir, fmt.Sprintf("reducto-report-%s.md", sessionID))
-	content, err := os.ReadFile(path)
+	return strings.HasPrefix(content, reportHeader)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
 	if err != nil {
-		return fmt.Errorf("failed to read report: %w", err)
+		return err
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Chmod(tmpName, 0644); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+
+	if err := os.Rename(tmpName, path); err != nil {
+		os.Remove(tmpName)
+		return err
 	}
 
-	fmt.Println(string(content))
 	return nil
 }
 
 func (r *Reporter) formatMarkdown(report *models.Report, result *models.RefactorResult) string {
 	var sb strings.Builder
 
-	sb.WriteString("# reducto Compression Report\n\n")
+	sb.WriteString(reportHeader + "\n\n")
 	sb.WriteString(fmt.Sprintf("**Session ID:** %s\n\n", report.SessionID))
 	sb.WriteString(fmt.Sprintf("**Generated:** %s\n\n", report.GeneratedAt.Format(time.RFC3339)))
 
@@ -179,6 +496,16 @@ func (r *Reporter) formatMarkdown(report *models.Report, result *models.Refactor
 		result.MetricsBefore.MaintainabilityIndex, result.MetricsAfter.MaintainabilityIndex,
 		report.MetricsDelta.MaintainabilityIndexDelta))
 
+	sb.WriteString(formatLOCMarkdown(result.Changes))
+
+	if len(report.Metadata) > 0 {
+		sb.WriteString("## Context\n\n")
+		for _, key := range sortedKeys(report.Metadata) {
+			sb.WriteString(fmt.Sprintf("- **%s:** %s\n", key, report.Metadata[key]))
+		}
+		sb.WriteString("\n")
+	}
+
 	sb.WriteString("## Files Modified\n\n")
 	for _, file := range report.FilesModified {
 		sb.WriteString(fmt.Sprintf("- `%s`\n", file))
@@ -208,7 +535,32 @@ func (r *Reporter) formatMarkdown(report *models.Report, result *models.Refactor
 	}
 
 	sb.WriteString("---\n")
-	sb.WriteString("*Generated by reducto - Semantic Code Compression Engine*\n")
+	sb.WriteString(reportFooter)
+
+	return sb.String()
+}
+
+func (r *Reporter) FormatLintMarkdown(issues []models.LintIssue) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Lint Issues\n\n")
+	if len(issues) == 0 {
+		sb.WriteString("No lint issues found.\n\n")
+		return sb.String()
+	}
+
+	sb.WriteString("| File | Line | Severity | Rule | Message |\n")
+	sb.WriteString("|------|------|----------|------|---------|\n")
+	for _, issue := range issues {
+		rule := issue.Rule
+		if rule != "" && issue.DocURL != "" {
+			rule = fmt.Sprintf("[%s](%s)", issue.Rule, issue.DocURL)
+		}
+		message := strings.ReplaceAll(issue.Message, "|", "\\|")
+		sb.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s |\n",
+			issue.File, issue.Line, issue.Severity, rule, message))
+	}
+	sb.WriteString("\n")
 
 	return sb.String()
 }
@@ -225,31 +577,63 @@ func (r *Reporter) extractModifiedFiles(changes []models.FileChange) []string {
 	return files
 }
 
-func (r *Reporter) generateDiff(original, modified string) string {
+type diffLine struct {
+	op   byte
+	text string
+	orig string
+}
+
+func (r *Reporter) diffLines(original, modified string) []diffLine {
 	origLines := strings.Split(original, "\n")
 	modLines := strings.Split(modified, "\n")
 
-	var diff strings.Builder
-
 	maxLen := len(origLines)
 	if len(modLines) > maxLen {
 		maxLen = len(modLines)
 	}
 
+	var lines []diffLine
 	for i := 0; i < maxLen; i++ {
 		if i < len(origLines) && i < len(modLines) {
-			if origLines[i] == modLines[i] {
-				diff.WriteString("  " + origLines[i] + "\n")
-			} else {
-				diff.WriteString("- " + origLines[i] + "\n")
-				diff.WriteString("+ " + modLines[i] + "\n")
+			switch {
+			case r.diffOpts.LinesEqual(origLines[i], modLines[i]):
+				lines = append(lines, diffLine{op: ' ', text: modLines[i]})
+			case r.intraLine:
+				lines = append(lines, diffLine{op: '~', text: modLines[i], orig: origLines[i]})
+			default:
+				lines = append(lines, diffLine{op: '-', text: origLines[i]})
+				lines = append(lines, diffLine{op: '+', text: modLines[i]})
 			}
 		} else if i < len(origLines) {
-			diff.WriteString("- " + origLines[i] + "\n")
+			lines = append(lines, diffLine{op: '-', text: origLines[i]})
 		} else {
-			diff.WriteString("+ " + modLines[i] + "\n")
+			lines = append(lines, diffLine{op: '+', text: modLines[i]})
 		}
 	}
 
+	return lines
+}
+
+func (r *Reporter) generateDiff(original, modified string) string {
+	var diff strings.Builder
+
+	for _, line := range r.diffLines(original, modified) {
+		text := line.text
+		if line.op == '~' {
+			text = formatIntraLineMarkdown(intraLineDiff(line.orig, line.text))
+		}
+		diff.WriteByte(line.op)
+		diff.WriteString(" " + text + "\n")
+	}
+
 	return diff.String()
 }
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
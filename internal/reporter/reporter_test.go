@@ -1,6 +1,7 @@
 package reporter
 
 import (
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
@@ -373,3 +374,399 @@ func TestLoad(t *testing.T) {
 		}
 	})
 }
+
+func TestFormatComplexityHistogram(t *testing.T) {
+	cfg := &models.Config{}
+	r := New(cfg)
+
+	complexities := []int{
+		1, 2, 3, 4, 5, 1, 2, 3,
+		6, 7, 8, 9,
+		12, 15,
+		30,
+	}
+
+	content := r.formatBaselineMarkdown("test-session", &BaselineResult{
+		TotalFiles:         3,
+		TotalSymbols:       len(complexities),
+		SymbolComplexities: complexities,
+	})
+
+	if !strings.Contains(content, "## Complexity Distribution") {
+		t.Fatal("should contain distribution section")
+	}
+
+	expected := map[string]int{
+		"1-5":   40,
+		"6-10":  20,
+		"11-20": 10,
+		"21+":   5,
+	}
+	for label, barLen := range expected {
+		var line string
+		for _, l := range strings.Split(content, "\n") {
+			if strings.HasPrefix(l, label+" ") {
+				line = l
+				break
+			}
+		}
+		if line == "" {
+			t.Errorf("missing bucket %s", label)
+			continue
+		}
+		if got := strings.Count(line, "#"); got != barLen {
+			t.Errorf("bucket %s: expected bar length %d, got %d (%q)", label, barLen, got, line)
+		}
+	}
+
+	t.Run("omitted without symbols", func(t *testing.T) {
+		content := r.formatBaselineMarkdown("test-session", &BaselineResult{TotalFiles: 1})
+		if strings.Contains(content, "Complexity Distribution") {
+			t.Error("should not render distribution without symbol complexities")
+		}
+	})
+}
+
+func TestFormatLintMarkdown(t *testing.T) {
+	r := New(&models.Config{})
+
+	content := r.FormatLintMarkdown([]models.LintIssue{
+		{File: "app.py", Line: 10, Severity: "warning", Message: "Line too long", Rule: "E501", DocURL: "https://docs.astral.sh/ruff/rules/?q=E501"},
+		{File: "main.go", Line: 3, Severity: "warning", Message: "unreachable code"},
+	})
+
+	if !strings.Contains(content, "[E501](https://docs.astral.sh/ruff/rules/?q=E501)") {
+		t.Error("should render rule as a link")
+	}
+	if !strings.Contains(content, "| main.go | 3 | warning |  | unreachable code |") {
+		t.Error("should render issues without a rule")
+	}
+
+	empty := r.FormatLintMarkdown(nil)
+	if !strings.Contains(empty, "No lint issues found.") {
+		t.Error("should note when there are no issues")
+	}
+}
+
+func TestSaveSnapshots(t *testing.T) {
+	t.Run("writes original and modified files", func(t *testing.T) {
+		r := New(&models.Config{})
+		r.outputDir = t.TempDir()
+
+		result := &models.RefactorResult{
+			SessionID: "session-1",
+			Changes: []models.FileChange{
+				{Path: "main.py", Original: "x = 1\n", Modified: "x = 2\n"},
+				{Path: "pkg/util.py", Original: "def f(): pass\n", Modified: "def g(): pass\n"},
+			},
+		}
+
+		if err := r.SaveSnapshots(result); err != nil {
+			t.Fatalf("SaveSnapshots returned error: %v", err)
+		}
+
+		expected := map[string]string{
+			"main.py.orig":     "x = 1\n",
+			"main.py.new":      "x = 2\n",
+			"pkg/util.py.orig": "def f(): pass\n",
+			"pkg/util.py.new":  "def g(): pass\n",
+		}
+
+		for name, want := range expected {
+			path := filepath.Join(r.outputDir, "snapshots", "session-1", filepath.FromSlash(name))
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read snapshot %s: %v", name, err)
+			}
+			if string(content) != want {
+				t.Errorf("snapshot %s: expected %q, got %q", name, want, string(content))
+			}
+		}
+	})
+
+	t.Run("rejects escaping paths", func(t *testing.T) {
+		r := New(&models.Config{})
+		r.outputDir = t.TempDir()
+
+		result := &models.RefactorResult{
+			SessionID: "session-2",
+			Changes: []models.FileChange{
+				{Path: "../../outside.py", Original: "a", Modified: "b"},
+			},
+		}
+
+		if err := r.SaveSnapshots(result); err == nil {
+			t.Error("expected error for path escaping the output directory")
+		}
+	})
+}
+
+func TestGenerateFingerprint(t *testing.T) {
+	r := New(&models.Config{})
+	r.outputDir = t.TempDir()
+
+	result := &models.RefactorResult{
+		SessionID: "fp-session",
+		Changes: []models.FileChange{
+			{Path: "a.py", Original: "x = 1\n", Modified: "x = 2\n"},
+		},
+		MetricsBefore: models.ComplexityMetrics{LinesOfCode: 10},
+		MetricsAfter:  models.ComplexityMetrics{LinesOfCode: 8},
+	}
+
+	path := filepath.Join(r.outputDir, "reducto-report-fp-session.md")
+	past := time.Now().Add(-time.Hour)
+
+	if err := r.Generate(result); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if err := os.Chtimes(path, past, past); err != nil {
+		t.Fatalf("failed to set mtime: %v", err)
+	}
+
+	t.Run("unchanged result is a no-op", func(t *testing.T) {
+		if err := r.Generate(result); err != nil {
+			t.Fatalf("Generate returned error: %v", err)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("failed to stat report: %v", err)
+		}
+		if !info.ModTime().Equal(past) {
+			t.Error("expected report not to be rewritten")
+		}
+	})
+
+	t.Run("force rewrites", func(t *testing.T) {
+		r.SetForce(true)
+		defer r.SetForce(false)
+
+		if err := r.Generate(result); err != nil {
+			t.Fatalf("Generate returned error: %v", err)
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("failed to stat report: %v", err)
+		}
+		if info.ModTime().Equal(past) {
+			t.Error("expected forced report to be rewritten")
+		}
+		if err := os.Chtimes(path, past, past); err != nil {
+			t.Fatalf("failed to set mtime: %v", err)
+		}
+	})
+
+	t.Run("changed result rewrites", func(t *testing.T) {
+		changed := *result
+		changed.MetricsAfter.LinesOfCode = 7
+
+		if err := r.Generate(&changed); err != nil {
+			t.Fatalf("Generate returned error: %v", err)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read report: %v", err)
+		}
+		if !strings.HasPrefix(string(content), fingerprintPrefix+r.fingerprint(&changed)) {
+			t.Error("expected report to carry the new fingerprint")
+		}
+	})
+
+	t.Run("empty fingerprint never matches", func(t *testing.T) {
+		unencodable := *result
+		unencodable.MetricsAfter.MaintainabilityIndex = math.NaN()
+
+		if err := os.WriteFile(path, []byte("no fingerprint\n"), 0644); err != nil {
+			t.Fatalf("failed to write report: %v", err)
+		}
+		if err := os.Chtimes(path, past, past); err != nil {
+			t.Fatalf("failed to set mtime: %v", err)
+		}
+
+		for i := 0; i < 2; i++ {
+			if err := r.Generate(&unencodable); err != nil {
+				t.Fatalf("Generate returned error: %v", err)
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("failed to stat report: %v", err)
+			}
+			if info.ModTime().Equal(past) {
+				t.Error("expected report without a fingerprint to be rewritten")
+			}
+			if err := os.Chtimes(path, past, past); err != nil {
+				t.Fatalf("failed to set mtime: %v", err)
+			}
+		}
+	})
+
+	t.Run("changed render option rewrites", func(t *testing.T) {
+		if err := r.Generate(result); err != nil {
+			t.Fatalf("Generate returned error: %v", err)
+		}
+		if err := os.Chtimes(path, past, past); err != nil {
+			t.Fatalf("failed to set mtime: %v", err)
+		}
+
+		for _, set := range []func(bool){r.SetIgnoreWhitespace, r.SetIntraLineDiff, r.SetSyntaxHighlight} {
+			set(true)
+			if err := r.Generate(result); err != nil {
+				t.Fatalf("Generate returned error: %v", err)
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("failed to stat report: %v", err)
+			}
+			if info.ModTime().Equal(past) {
+				t.Error("expected report to be rewritten after changing a render option")
+			}
+			if err := os.Chtimes(path, past, past); err != nil {
+				t.Fatalf("failed to set mtime: %v", err)
+			}
+		}
+	})
+}
+
+func TestGenerateDiffIgnoreWhitespace(t *testing.T) {
+	original := "def f():\n  if x:\n    return 1\n  return 0\n"
+	reindented := "def f():\n    if x:\n        return 1\n    return 0   \n"
+
+	r := New(&models.Config{})
+
+	diff := r.generateDiff(original, reindented)
+	if !strings.Contains(diff, "- ") || !strings.Contains(diff, "+ ") {
+		t.Error("expected reindented block to show as a diff by default")
+	}
+
+	r.SetIgnoreWhitespace(true)
+	diff = r.generateDiff(original, reindented)
+	if strings.Contains(diff, "- ") || strings.Contains(diff, "+ ") {
+		t.Errorf("expected no diff when ignoring whitespace, got:\n%s", diff)
+	}
+
+	diff = r.generateDiff(original, strings.Replace(reindented, "return 1", "return 2", 1))
+	if !strings.Contains(diff, "+         return 2") {
+		t.Errorf("expected real change to remain visible, got:\n%s", diff)
+	}
+}
+
+func TestLoadSkipsCorruptReports(t *testing.T) {
+	r := New(&models.Config{})
+	r.outputDir = t.TempDir()
+
+	valid := &models.RefactorResult{SessionID: "valid", MetricsBefore: models.ComplexityMetrics{LinesOfCode: 10}}
+	if err := r.Generate(valid); err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+
+	validPath := filepath.Join(r.outputDir, "reducto-report-valid.md")
+	content, err := os.ReadFile(validPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+
+	corruptPath := filepath.Join(r.outputDir, "reducto-report-corrupt.md")
+	if err := os.WriteFile(corruptPath, content[:len(content)/2], 0644); err != nil {
+		t.Fatalf("failed to write corrupt report: %v", err)
+	}
+
+	now := time.Now()
+	if err := os.Chtimes(validPath, now.Add(-time.Hour), now.Add(-time.Hour)); err != nil {
+		t.Fatalf("failed to set mtime: %v", err)
+	}
+	if err := os.Chtimes(corruptPath, now, now); err != nil {
+		t.Fatalf("failed to set mtime: %v", err)
+	}
+
+	loaded, err := r.loadReport("")
+	if err != nil {
+		t.Fatalf("loadReport returned error: %v", err)
+	}
+	if loaded != string(content) {
+		t.Error("expected latest valid report to be loaded")
+	}
+
+	if _, err := r.loadReport("corrupt"); err == nil {
+		t.Error("expected error when loading a corrupt report by session")
+	}
+
+	entries, err := os.ReadDir(r.outputDir)
+	if err != nil {
+		t.Fatalf("failed to read output dir: %v", err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("unexpected leftover temp file %s", entry.Name())
+		}
+	}
+}
+
+func TestGenerateMetadataContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     bool
+		metadata map[string]string
+		expected []string
+		absent   []string
+	}{
+		{
+			name:     "markdown",
+			metadata: map[string]string{"pr": "42", "user": "dana"},
+			expected: []string{"## Context", "- **pr:** 42", "- **user:** dana"},
+		},
+		{
+			name:     "html",
+			html:     true,
+			metadata: map[string]string{"reason": "<cleanup>"},
+			expected: []string{"<h2>Context</h2>", "<li><strong>reason:</strong> &lt;cleanup&gt;</li>"},
+		},
+		{
+			name:   "omitted when empty",
+			absent: []string{"Context"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &models.Config{}
+			if tt.html {
+				cfg.OutputFormat = "html"
+			}
+			r := New(cfg)
+			r.outputDir = t.TempDir()
+
+			result := &models.RefactorResult{
+				SessionID: "meta",
+				Metadata:  tt.metadata,
+			}
+			if err := r.Generate(result); err != nil {
+				t.Fatalf("Generate returned error: %v", err)
+			}
+
+			ext := "md"
+			if tt.html {
+				ext = "html"
+			}
+			content, err := os.ReadFile(filepath.Join(r.outputDir, "reducto-report-meta."+ext))
+			if err != nil {
+				t.Fatalf("failed to read report: %v", err)
+			}
+
+			for _, want := range tt.expected {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("expected report to contain %q", want)
+				}
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(string(content), unwanted) {
+					t.Errorf("expected report not to contain %q", unwanted)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package reporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+// StreamingReporter writes each applied change to disk as soon as it is
+// recorded, so a session that dies part way still leaves a usable log.
+type StreamingReporter struct {
+	mu        sync.Mutex
+	file      *os.File
+	diff      *Reporter
+	changes   int
+	finalized bool
+}
+
+func NewStreaming(path string) (*StreamingReporter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open streaming report: %w", err)
+	}
+
+	s := &StreamingReporter{file: f, diff: New(nil)}
+	header := fmt.Sprintf("%s\n\n**Started:** %s\n\n## Changes\n\n", reportHeader, time.Now().Format(time.RFC3339))
+	if err := s.write(header); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *StreamingReporter) AppendChange(change models.FileChange) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.finalized {
+		return fmt.Errorf("streaming report already finalized")
+	}
+
+	s.changes++
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("### %d. %s\n\n", s.changes, change.Path))
+	if change.Description != "" {
+		sb.WriteString(change.Description + "\n\n")
+	}
+	sb.WriteString("```diff\n")
+	sb.WriteString(s.diff.generateDiff(change.Original, change.Modified))
+	sb.WriteString("```\n\n")
+
+	return s.write(sb.String())
+}
+
+func (s *StreamingReporter) Finalize(report *models.Report) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.finalized {
+		return fmt.Errorf("streaming report already finalized")
+	}
+	s.finalized = true
+
+	var sb strings.Builder
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString(fmt.Sprintf("**Session ID:** %s\n\n", report.SessionID))
+	sb.WriteString(fmt.Sprintf("**Changes applied:** %d\n\n", s.changes))
+	sb.WriteString("| Metric | Value |\n")
+	sb.WriteString("|--------|-------|\n")
+	sb.WriteString(fmt.Sprintf("| Lines of Code | %d → %d (**%d** reduced) |\n",
+		report.LOCBefore, report.LOCAfter, report.LOCReduced))
+	sb.WriteString(fmt.Sprintf("| Cyclomatic Complexity Delta | %d |\n", report.MetricsDelta.CyclomaticComplexityDelta))
+	sb.WriteString(fmt.Sprintf("| Cognitive Complexity Delta | %d |\n", report.MetricsDelta.CognitiveComplexityDelta))
+	sb.WriteString(fmt.Sprintf("| Maintainability Index Delta | %.2f |\n\n", report.MetricsDelta.MaintainabilityIndexDelta))
+
+	if len(report.FilesModified) > 0 {
+		sb.WriteString("## Files Modified\n\n")
+		for _, file := range report.FilesModified {
+			sb.WriteString(fmt.Sprintf("- `%s`\n", file))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("---\n\n" + reportFooter)
+
+	if err := s.write(sb.String()); err != nil {
+		s.file.Close()
+		return err
+	}
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close streaming report: %w", err)
+	}
+	return nil
+}
+
+// Close releases the file without writing a summary, leaving the partial log.
+func (s *StreamingReporter) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.finalized {
+		return nil
+	}
+	s.finalized = true
+	return s.file.Close()
+}
+
+func (s *StreamingReporter) write(content string) error {
+	if _, err := s.file.WriteString(content); err != nil {
+		return fmt.Errorf("failed to write streaming report: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync streaming report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package reporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestStreamingReporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".reducto", "stream.md")
+
+	s, err := NewStreaming(path)
+	if err != nil {
+		t.Fatalf("NewStreaming returned error: %v", err)
+	}
+
+	changes := []models.FileChange{
+		{Path: "a.go", Description: "extract helper", Original: "old a\n", Modified: "new a\n"},
+		{Path: "b.go", Description: "inline call", Original: "old b\n", Modified: "new b\n"},
+		{Path: "c.go", Original: "", Modified: "added c\n"},
+	}
+	for i, change := range changes {
+		if err := s.AppendChange(change); err != nil {
+			t.Fatalf("AppendChange returned error: %v", err)
+		}
+
+		partial, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read partial log: %v", err)
+		}
+		if !strings.Contains(string(partial), change.Path) {
+			t.Errorf("expected change %d to be on disk before finalize", i+1)
+		}
+		if strings.Contains(string(partial), "## Summary") {
+			t.Error("expected no summary before finalize")
+		}
+	}
+
+	report := &models.Report{
+		SessionID:     "stream-1",
+		LOCBefore:     120,
+		LOCAfter:      100,
+		LOCReduced:    20,
+		FilesModified: []string{"a.go", "b.go", "c.go"},
+	}
+	if err := s.Finalize(report); err != nil {
+		t.Fatalf("Finalize returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+
+	expected := []string{
+		reportHeader,
+		"### 1. a.go", "extract helper", "- old a", "+ new a",
+		"### 2. b.go", "inline call",
+		"### 3. c.go", "+ added c",
+		"## Summary", "**Session ID:** stream-1", "**Changes applied:** 3", "120 → 100 (**20** reduced)",
+		"- `c.go`",
+		strings.TrimSpace(reportFooter),
+	}
+	for _, want := range expected {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+
+	if err := s.AppendChange(changes[0]); err == nil {
+		t.Error("expected error appending after finalize")
+	}
+}
+
+func TestStreamingReporterCloseKeepsPartialLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stream.md")
+
+	s, err := NewStreaming(path)
+	if err != nil {
+		t.Fatalf("NewStreaming returned error: %v", err)
+	}
+	if err := s.AppendChange(models.FileChange{Path: "a.go", Original: "x\n", Modified: "y\n"}); err != nil {
+		t.Fatalf("AppendChange returned error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if !strings.Contains(string(content), "### 1. a.go") || strings.Contains(string(content), "## Summary") {
+		t.Errorf("expected partial log without summary, got %q", content)
+	}
+}
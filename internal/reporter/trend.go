@@ -0,0 +1,114 @@
+package reporter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const maxTrendingSymbols = 10
+
+// TrendPoint is a symbol's complexity in one baseline. Present is false when
+// the baseline did not record the symbol.
+type TrendPoint struct {
+	Value   int
+	Present bool
+}
+
+// SymbolTrend returns one point per baseline for every symbol seen in any of
+// them, so series stay aligned. The full symbol list is used when present,
+// falling back to hotspots for baselines recorded without it.
+func SymbolTrend(baselines []*BaselineResult) map[string][]TrendPoint {
+	trend := make(map[string][]TrendPoint)
+	for i, baseline := range baselines {
+		if baseline == nil {
+			continue
+		}
+		symbols := baseline.Symbols
+		if len(symbols) == 0 {
+			symbols = baseline.Hotspots
+		}
+		for _, sym := range symbols {
+			key := sym.File + "::" + sym.Symbol
+			points, ok := trend[key]
+			if !ok {
+				points = make([]TrendPoint, len(baselines))
+				trend[key] = points
+			}
+			points[i] = TrendPoint{Value: sym.CyclomaticComplexity, Present: true}
+		}
+	}
+	return trend
+}
+
+type symbolTrendEntry struct {
+	key    string
+	points []TrendPoint
+	delta  int
+}
+
+func worstTrendingSymbols(trend map[string][]TrendPoint, limit int) []symbolTrendEntry {
+	var entries []symbolTrendEntry
+	for key, points := range trend {
+		first, last := -1, -1
+		for i, p := range points {
+			if !p.Present {
+				continue
+			}
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+		if first < 0 || first == last {
+			continue
+		}
+		delta := points[last].Value - points[first].Value
+		if delta <= 0 {
+			continue
+		}
+		entries = append(entries, symbolTrendEntry{key: key, points: points, delta: delta})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].delta != entries[j].delta {
+			return entries[i].delta > entries[j].delta
+		}
+		return entries[i].key < entries[j].key
+	})
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
+
+func (r *Reporter) FormatSymbolTrendMarkdown(baselines []*BaselineResult) string {
+	entries := worstTrendingSymbols(SymbolTrend(baselines), maxTrendingSymbols)
+
+	var sb strings.Builder
+	sb.WriteString("## Worst-Trending Symbols\n\n")
+
+	if len(entries) == 0 {
+		sb.WriteString("No symbols increased in complexity.\n\n")
+		return sb.String()
+	}
+
+	sb.WriteString("| Symbol | Cyclomatic Trend | Change |\n")
+	sb.WriteString("|--------|------------------|--------|\n")
+	for _, e := range entries {
+		points := make([]string, len(e.points))
+		for i, p := range e.points {
+			if !p.Present {
+				points[i] = "–"
+				continue
+			}
+			points[i] = strconv.Itoa(p.Value)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | +%d |\n", e.key, strings.Join(points, " → "), e.delta))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
@@ -0,0 +1,149 @@
+package reporter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestSymbolTrend(t *testing.T) {
+	baselines := []*BaselineResult{
+		{
+			Hotspots: []ComplexityHotspot{
+				{File: "app.py", Symbol: "process", CyclomaticComplexity: 8},
+				{File: "app.py", Symbol: "render", CyclomaticComplexity: 12},
+				{File: "util.py", Symbol: "process", CyclomaticComplexity: 5},
+			},
+		},
+		{
+			Hotspots: []ComplexityHotspot{
+				{File: "app.py", Symbol: "process", CyclomaticComplexity: 15},
+				{File: "app.py", Symbol: "render", CyclomaticComplexity: 10},
+			},
+		},
+	}
+
+	trend := SymbolTrend(baselines)
+
+	tests := []struct {
+		key      string
+		expected []TrendPoint
+	}{
+		{"app.py::process", []TrendPoint{{8, true}, {15, true}}},
+		{"app.py::render", []TrendPoint{{12, true}, {10, true}}},
+		{"util.py::process", []TrendPoint{{5, true}, {}}},
+	}
+
+	for _, tt := range tests {
+		got := trend[tt.key]
+		if len(got) != len(tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.key, tt.expected, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expected[i] {
+				t.Errorf("%s: expected %v, got %v", tt.key, tt.expected, got)
+				break
+			}
+		}
+	}
+
+	r := New(&models.Config{})
+	md := r.FormatSymbolTrendMarkdown(baselines)
+
+	if !strings.Contains(md, "## Worst-Trending Symbols") {
+		t.Error("expected trend section header")
+	}
+	if !strings.Contains(md, "| app.py::process | 8 → 15 | +7 |") {
+		t.Errorf("expected worsening symbol row, got:\n%s", md)
+	}
+	if strings.Contains(md, "app.py::render") {
+		t.Error("improving symbol should not be listed")
+	}
+}
+
+func TestSymbolTrendKeepsGapsAligned(t *testing.T) {
+	baselines := []*BaselineResult{
+		{Symbols: []ComplexityHotspot{
+			{File: "app.go", Symbol: "Serve", CyclomaticComplexity: 4},
+			{File: "app.go", Symbol: "parse", CyclomaticComplexity: 2},
+		}},
+		{Symbols: []ComplexityHotspot{
+			{File: "app.go", Symbol: "parse", CyclomaticComplexity: 3},
+		}},
+		{Symbols: []ComplexityHotspot{
+			{File: "app.go", Symbol: "Serve", CyclomaticComplexity: 9},
+			{File: "app.go", Symbol: "parse", CyclomaticComplexity: 3},
+		}},
+	}
+
+	trend := SymbolTrend(baselines)
+
+	expected := map[string][]TrendPoint{
+		"app.go::Serve": {{4, true}, {}, {9, true}},
+		"app.go::parse": {{2, true}, {3, true}, {3, true}},
+	}
+	for key, want := range expected {
+		got := trend[key]
+		if len(got) != len(want) {
+			t.Fatalf("%s: expected %v, got %v", key, want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: expected %v, got %v", key, want, got)
+				break
+			}
+		}
+	}
+
+	md := New(&models.Config{}).FormatSymbolTrendMarkdown(baselines)
+	if !strings.Contains(md, "| app.go::Serve | 4 → – → 9 | +5 |") {
+		t.Errorf("expected gap to be rendered in place, got:\n%s", md)
+	}
+}
+
+func TestSymbolTrendZeroComplexity(t *testing.T) {
+	baselines := []*BaselineResult{
+		{Symbols: []ComplexityHotspot{{File: "a.go", Symbol: "F", CyclomaticComplexity: 0}}},
+		{},
+	}
+
+	got := SymbolTrend(baselines)["a.go::F"]
+	if len(got) != 2 || !got[0].Present || got[0].Value != 0 || got[1].Present {
+		t.Errorf("expected a recorded zero followed by a gap, got %+v", got)
+	}
+}
+
+func TestLoadBaselines(t *testing.T) {
+	r := New(&models.Config{})
+	r.outputDir = t.TempDir()
+
+	older := &BaselineResult{
+		GeneratedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Symbols:     []ComplexityHotspot{{File: "a.go", Symbol: "F", CyclomaticComplexity: 3}},
+	}
+	newer := &BaselineResult{
+		GeneratedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Symbols:     []ComplexityHotspot{{File: "a.go", Symbol: "F", CyclomaticComplexity: 7}},
+	}
+	for _, b := range []*BaselineResult{newer, older} {
+		if err := r.GenerateBaseline(b); err != nil {
+			t.Fatalf("GenerateBaseline returned error: %v", err)
+		}
+	}
+
+	baselines, err := r.LoadBaselines()
+	if err != nil {
+		t.Fatalf("LoadBaselines returned error: %v", err)
+	}
+	if len(baselines) != 2 {
+		t.Fatalf("expected 2 baselines, got %d", len(baselines))
+	}
+
+	got := SymbolTrend(baselines)["a.go::F"]
+	if len(got) != 2 || got[0] != (TrendPoint{3, true}) || got[1] != (TrendPoint{7, true}) {
+		t.Errorf("expected trend [3 7] from loaded baselines, got %v", got)
+	}
+}
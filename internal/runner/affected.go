@@ -0,0 +1,154 @@
+package runner
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const goListTemplate = `{{.ImportPath}}|{{.Dir}}|{{join .Deps ","}}|{{join .TestImports ","}}|{{join .XTestImports ","}}`
+
+type goPackage struct {
+	importPath  string
+	dir         string
+	deps        []string
+	testImports []string
+}
+
+func (r *Runner) AffectedGoPackages(changed []string) ([]string, error) {
+	packages, err := r.listGoPackages()
+	if err != nil {
+		return nil, err
+	}
+
+	byDir := make(map[string]*goPackage, len(packages))
+	byPath := make(map[string]*goPackage, len(packages))
+	for _, pkg := range packages {
+		byDir[filepath.Clean(pkg.dir)] = pkg
+		byPath[pkg.importPath] = pkg
+	}
+
+	changedPkgs := make(map[string]bool)
+	for _, dir := range goPackageDirs(changed) {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(r.path, dir)
+		}
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			continue
+		}
+		if pkg, ok := byDir[abs]; ok {
+			changedPkgs[pkg.importPath] = true
+		}
+	}
+	if len(changedPkgs) == 0 {
+		return nil, nil
+	}
+
+	var affected []string
+	for _, pkg := range packages {
+		if dependsOnAny(pkg, byPath, changedPkgs) {
+			affected = append(affected, pkg.importPath)
+		}
+	}
+	sort.Strings(affected)
+
+	return affected, nil
+}
+
+func dependsOnAny(pkg *goPackage, byPath map[string]*goPackage, targets map[string]bool) bool {
+	if targets[pkg.importPath] {
+		return true
+	}
+	for _, dep := range pkg.deps {
+		if targets[dep] {
+			return true
+		}
+	}
+	for _, imp := range pkg.testImports {
+		if targets[imp] {
+			return true
+		}
+		if dep, ok := byPath[imp]; ok {
+			for _, d := range dep.deps {
+				if targets[d] {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (r *Runner) listGoPackages() ([]*goPackage, error) {
+	cmd := exec.Command("go", "list", "-e", "-f", goListTemplate, "./...")
+	cmd.Dir = r.path
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("failed to list go packages: %w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to list go packages: %w", err)
+	}
+
+	var packages []*goPackage
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "|")
+		if len(fields) != 5 {
+			continue
+		}
+		packages = append(packages, &goPackage{
+			importPath:  fields[0],
+			dir:         fields[1],
+			deps:        splitList(fields[2]),
+			testImports: append(splitList(fields[3]), splitList(fields[4])...),
+		})
+	}
+
+	return packages, nil
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func (r *Runner) RunAffectedGoTests(changed []string) (*TestResult, error) {
+	packages, err := r.AffectedGoPackages(changed)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(packages) == 0 {
+		return &TestResult{
+			Success: true,
+			Output:  "No Go packages affected by the changed files",
+		}, nil
+	}
+
+	if err := r.prepareCoverProfile(); err != nil {
+		return nil, err
+	}
+
+	cmd := []string{"go", "test"}
+	if r.wantsCoverProfile() {
+		cmd = append(cmd, r.coverProfileArg())
+	}
+
+	result, err := r.execute(append(cmd, packages...))
+	if err != nil {
+		return nil, err
+	}
+
+	result.Passed, result.Total, result.CountsMeasured = parseTestCounts(result.Output)
+	result.Coverage, result.CoverageMeasured = parseCoverage(result.Output)
+	r.applyGoCoverProfile(result)
+	r.applyCoverageThreshold(result)
+
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAffectedGoPackages(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":                 "module example.com/m\n\ngo 1.21\n",
+		"lib/lib.go":             "package lib\n\nfunc Add(a, b int) int { return a + b }\n",
+		"app/app.go":             "package app\n\nimport \"example.com/m/lib\"\n\nfunc Sum() int { return lib.Add(1, 2) }\n",
+		"cli/cli.go":             "package cli\n\nimport \"example.com/m/app\"\n\nfunc Run() int { return app.Sum() }\n",
+		"checks/checks.go":       "package checks\n",
+		"checks/checks_test.go":  "package checks\n\nimport (\n\t\"testing\"\n\n\t\"example.com/m/lib\"\n)\n\nfunc TestAdd(t *testing.T) { _ = lib.Add(1, 1) }\n",
+		"unrelated/unrelated.go": "package unrelated\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	r := New(root)
+
+	tests := []struct {
+		name     string
+		changed  []string
+		expected []string
+	}{
+		{
+			name:     "library change selects dependents",
+			changed:  []string{"lib/lib.go"},
+			expected: []string{"example.com/m/app", "example.com/m/checks", "example.com/m/cli", "example.com/m/lib"},
+		},
+		{
+			name:     "leaf change selects only itself",
+			changed:  []string{"cli/cli.go"},
+			expected: []string{"example.com/m/cli"},
+		},
+		{
+			name:    "non-go change selects nothing",
+			changed: []string{"README.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.AffectedGoPackages(tt.changed)
+			if err != nil {
+				t.Fatalf("AffectedGoPackages returned error: %v", err)
+			}
+			if strings.Join(got, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package runner
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+var (
+	annotationDataEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	annotationPropEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func FormatGitHubAnnotations(issues []models.LintIssue, w io.Writer) error {
+	for _, issue := range issues {
+		props := []string{"file=" + annotationPropEscaper.Replace(issue.File)}
+		if issue.Line > 0 {
+			props = append(props, fmt.Sprintf("line=%d", issue.Line))
+		}
+		if issue.Column > 0 {
+			props = append(props, fmt.Sprintf("col=%d", issue.Column))
+		}
+		if issue.Rule != "" {
+			props = append(props, "title="+annotationPropEscaper.Replace(issue.Rule))
+		}
+
+		_, err := fmt.Fprintf(w, "::%s %s::%s\n", annotationLevel(issue), strings.Join(props, ","), annotationDataEscaper.Replace(issue.Message))
+		if err != nil {
+			return fmt.Errorf("failed to write annotation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func annotationLevel(issue models.LintIssue) string {
+	if issue.IsError() {
+		return "error"
+	}
+	return "warning"
+}
@@ -0,0 +1,29 @@
+package runner
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestFormatGitHubAnnotations(t *testing.T) {
+	issues := []models.LintIssue{
+		{File: "src/app.ts", Line: 3, Column: 7, Message: "Unexpected any", Severity: "error", Rule: "no-explicit-any"},
+		{File: "main.py", Line: 10, Column: 1, Message: "line too long\n(120 > 88)", Severity: "warning"},
+		{File: "c:odd,name.go", Line: 2, Message: "100% wrong", Severity: "info"},
+	}
+
+	var buf bytes.Buffer
+	if err := FormatGitHubAnnotations(issues, &buf); err != nil {
+		t.Fatalf("FormatGitHubAnnotations returned error: %v", err)
+	}
+
+	expected := "::error file=src/app.ts,line=3,col=7,title=no-explicit-any::Unexpected any\n" +
+		"::warning file=main.py,line=10,col=1::line too long%0A(120 > 88)\n" +
+		"::warning file=c%3Aodd%2Cname.go,line=2::100%25 wrong\n"
+
+	if buf.String() != expected {
+		t.Errorf("unexpected output:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
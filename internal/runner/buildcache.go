@@ -0,0 +1,89 @@
+package runner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/alexkarsten/reducto/internal/walker"
+)
+
+const buildCacheFile = "build-cache.json"
+
+// The walker skips lockfiles, but they still change what gets built.
+var buildLockfiles = []string{"go.sum", "yarn.lock", "poetry.lock", "Pipfile.lock", "gradle.lockfile"}
+
+type buildCache struct {
+	InputHash string    `json:"input_hash"`
+	BuiltAt   time.Time `json:"built_at"`
+}
+
+func (r *Runner) SetBuildCache(enabled bool) {
+	r.buildCache = enabled
+}
+
+func (r *Runner) buildCachePath() string {
+	return filepath.Join(r.path, ".reducto", buildCacheFile)
+}
+
+func (r *Runner) buildInputHash() (string, error) {
+	exclude := append([]string{".reducto"}, r.config.ExcludePatterns...)
+	files, err := walker.CollectFiles(r.path, exclude, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to collect build inputs: %w", err)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	h := sha256.New()
+	fmt.Fprintf(h, "command\x00%s\x00", r.config.BuildCommand)
+	for _, f := range files {
+		fmt.Fprintf(h, "file\x00%s\x00%s\x00", f.Path, f.Hash)
+	}
+	for _, name := range buildLockfiles {
+		content, err := os.ReadFile(filepath.Join(r.path, name))
+		if err != nil {
+			continue
+		}
+		sum := sha256.Sum256(content)
+		fmt.Fprintf(h, "lockfile\x00%s\x00%x\x00", name, sum)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (r *Runner) loadBuildCache() *buildCache {
+	data, err := os.ReadFile(r.buildCachePath())
+	if err != nil {
+		return nil
+	}
+
+	var cache buildCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil
+	}
+	return &cache
+}
+
+func (r *Runner) saveBuildCache(inputHash string) error {
+	path := r.buildCachePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.Marshal(buildCache{InputHash: inputHash, BuiltAt: time.Now()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal build cache: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write build cache: %w", err)
+	}
+	return nil
+}
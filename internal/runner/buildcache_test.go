@@ -0,0 +1,82 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	write("go.mod", "module example.com/app\n")
+	write("main.go", "package main\n\nfunc main() {}\n")
+
+	r := New(tmpDir)
+	r.SetConfig(RunnerConfig{BuildCommand: "mkdir -p build && echo built >> build/log", Shell: true})
+	r.SetBuildCache(true)
+
+	builds := func() int {
+		data, _ := os.ReadFile(filepath.Join(tmpDir, "build", "log"))
+		return strings.Count(string(data), "built")
+	}
+
+	steps := []struct {
+		name   string
+		before func()
+		cached bool
+		builds int
+	}{
+		{name: "first build", cached: false, builds: 1},
+		{name: "unchanged inputs", cached: true, builds: 1},
+		{name: "source changed", before: func() { write("main.go", "package main\n\nfunc main() { println() }\n") }, cached: false, builds: 2},
+		{name: "unchanged after rebuild", cached: true, builds: 2},
+		{name: "manifest changed", before: func() { write("go.mod", "module example.com/app\n\ngo 1.22\n") }, cached: false, builds: 3},
+	}
+
+	for _, step := range steps {
+		if step.before != nil {
+			step.before()
+		}
+
+		result, err := r.Build()
+		if err != nil {
+			t.Fatalf("%s: Build returned error: %v", step.name, err)
+		}
+		if !result.Success {
+			t.Fatalf("%s: expected successful build, got output: %s", step.name, result.Output)
+		}
+		if result.Cached != step.cached {
+			t.Errorf("%s: expected cached=%v, got %v", step.name, step.cached, result.Cached)
+		}
+		if got := builds(); got != step.builds {
+			t.Errorf("%s: expected %d builds, got %d", step.name, step.builds, got)
+		}
+	}
+}
+
+func TestBuildCacheDisabled(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/app\n"), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	r := New(tmpDir)
+	r.SetConfig(RunnerConfig{BuildCommand: "true", Shell: true})
+
+	for i := 0; i < 2; i++ {
+		result, err := r.Build()
+		if err != nil {
+			t.Fatalf("Build returned error: %v", err)
+		}
+		if result.Cached {
+			t.Error("expected no caching when the build cache is disabled")
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const RepoConfigFile = ".dehydrator.yml"
+
+var knownConfigKeys = configKeys(reflect.TypeOf(RunnerConfig{}))
+
+func configKeys(t reflect.Type) map[string]bool {
+	keys := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("mapstructure"), ",")
+		if tag != "" && tag != "-" {
+			keys[tag] = true
+		}
+	}
+	return keys
+}
+
+// LoadConfig reads RepoConfigFile from repoRoot. Keys that RunnerConfig does
+// not know are returned as warnings for the caller to report.
+func LoadConfig(repoRoot string) (RunnerConfig, []string, error) {
+	var cfg RunnerConfig
+
+	path := filepath.Join(repoRoot, RepoConfigFile)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return cfg, nil, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+
+	if err := v.ReadInConfig(); err != nil {
+		return cfg, nil, fmt.Errorf("failed to read %s: %w", RepoConfigFile, err)
+	}
+
+	var unknown []string
+	for _, key := range v.AllKeys() {
+		if !knownConfigKeys[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+
+	var warnings []string
+	for _, key := range unknown {
+		warnings = append(warnings, fmt.Sprintf("unknown key %q in %s", key, RepoConfigFile))
+	}
+
+	if err := v.Unmarshal(&cfg); err != nil {
+		return cfg, warnings, fmt.Errorf("failed to parse %s: %w", RepoConfigFile, err)
+	}
+
+	if cfg.Timeout < 0 {
+		return cfg, warnings, fmt.Errorf("invalid timeout in %s: %v", RepoConfigFile, cfg.Timeout)
+	}
+	if cfg.Retries < 0 {
+		return cfg, warnings, fmt.Errorf("invalid retries in %s: %d", RepoConfigFile, cfg.Retries)
+	}
+
+	return cfg, warnings, nil
+}
+
+func NewFromRepo(path string) (*Runner, error) {
+	cfg, warnings, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := New(path)
+	r.SetConfig(cfg)
+	r.configWarnings = warnings
+	if cfg.Timeout > 0 {
+		r.SetTimeout(cfg.Timeout)
+	}
+
+	return r, nil
+}
+
+func (r *Runner) ConfigWarnings() []string {
+	return r.configWarnings
+}
@@ -0,0 +1,140 @@
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		cfg, _, err := LoadConfig(t.TempDir())
+		if err != nil {
+			t.Fatalf("LoadConfig returned error: %v", err)
+		}
+		if cfg.TestCommand != "" || cfg.Timeout != 0 {
+			t.Errorf("expected zero config, got %+v", cfg)
+		}
+	})
+
+	t.Run("invalid retries", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		err := os.WriteFile(filepath.Join(tmpDir, RepoConfigFile), []byte("retries: -1\n"), 0644)
+		if err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+
+		if _, _, err := LoadConfig(tmpDir); err == nil {
+			t.Error("expected error for negative retries")
+		}
+	})
+}
+
+func TestLoadConfigKnownKeys(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := `test_command: "make test"
+lint_command: "make lint"
+build_command: "make build"
+shell: true
+timeout: 90s
+retries: 2
+pty: true
+per_test_timeout: 30s
+exclude_patterns: [legacy]
+suppress_rules: [E501]
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, RepoConfigFile), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, warnings, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+	if !cfg.PTY {
+		t.Error("expected pty to be enabled")
+	}
+	if cfg.PerTestTimeout != 30*time.Second {
+		t.Errorf("expected per-test timeout 30s, got %v", cfg.PerTestTimeout)
+	}
+}
+
+func TestNewFromRepo(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := `test_command: "make test"
+lint_command: "make lint"
+build_command: "make build"
+shell: true
+timeout: 90s
+retries: 2
+exclude_patterns:
+  - legacy
+  - generated
+unknown_setting: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, RepoConfigFile), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test"), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	r, err := NewFromRepo(tmpDir)
+	if err != nil {
+		t.Fatalf("NewFromRepo returned error: %v", err)
+	}
+
+	if warnings := r.ConfigWarnings(); len(warnings) != 1 || !strings.Contains(warnings[0], `"unknown_setting"`) {
+		t.Errorf("expected a warning for unknown_setting, got %v", warnings)
+	}
+
+	if r.timeout != 90*time.Second {
+		t.Errorf("expected timeout 90s, got %v", r.timeout)
+	}
+	if r.config.Retries != 2 {
+		t.Errorf("expected 2 retries, got %d", r.config.Retries)
+	}
+	if len(r.config.ExcludePatterns) != 2 || r.config.ExcludePatterns[0] != "legacy" {
+		t.Errorf("unexpected exclude patterns: %v", r.config.ExcludePatterns)
+	}
+
+	testCmd := r.getTestCommand(r.detectProjectType())
+	if len(testCmd) != 3 || testCmd[2] != "make test" {
+		t.Errorf("expected shell-wrapped test override, got %v", testCmd)
+	}
+	lintCmd := r.getLintCommand(r.detectProjectType())
+	if len(lintCmd) != 3 || lintCmd[2] != "make lint" {
+		t.Errorf("expected shell-wrapped lint override, got %v", lintCmd)
+	}
+}
+
+func TestRunTestsRetries(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	tmpDir := t.TempDir()
+	counter := filepath.Join(tmpDir, "attempts")
+
+	r := New(tmpDir)
+	r.SetConfig(RunnerConfig{
+		TestCommand: "echo x >> " + counter + "; [ $(wc -l < " + counter + ") -ge 3 ]",
+		Shell:       true,
+		Retries:     2,
+	})
+
+	result, err := r.RunTests()
+	if err != nil {
+		t.Fatalf("RunTests returned error: %v", err)
+	}
+	if !result.Success {
+		t.Error("expected success on the third attempt")
+	}
+}
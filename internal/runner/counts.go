@@ -0,0 +1,46 @@
+package runner
+
+import (
+	"regexp"
+	"strconv"
+)
+
+var (
+	goTestRunRegex  = regexp.MustCompile(`(?m)^\s*=== RUN `)
+	goTestPassRegex = regexp.MustCompile(`(?m)^\s*--- PASS: `)
+	goTestFailRegex = regexp.MustCompile(`(?m)^\s*--- FAIL: `)
+	pytestRegex     = regexp.MustCompile(`=+ (?:(\d+) failed)?(?:, )?(?:(\d+) passed)?.* in [\d.]+s`)
+	jestRegex       = regexp.MustCompile(`(?m)^Tests:\s+(?:(\d+) failed, )?(?:\d+ skipped, )?(?:(\d+) passed, )?(\d+) total`)
+)
+
+func parseTestCounts(output string) (passed, total int, ok bool) {
+	if m := jestRegex.FindStringSubmatch(output); m != nil {
+		passed = atoiOrZero(m[2])
+		total = atoiOrZero(m[3])
+		return passed, total, true
+	}
+
+	if m := pytestRegex.FindStringSubmatch(output); m != nil && (m[1] != "" || m[2] != "") {
+		failed := atoiOrZero(m[1])
+		passed = atoiOrZero(m[2])
+		return passed, passed + failed, true
+	}
+
+	// Without -v, go test prints only failures, so passes cannot be counted.
+	if !goTestRunRegex.MatchString(output) {
+		return 0, 0, false
+	}
+
+	passes := len(goTestPassRegex.FindAllStringIndex(output, -1))
+	fails := len(goTestFailRegex.FindAllStringIndex(output, -1))
+	if passes+fails > 0 {
+		return passes, passes + fails, true
+	}
+
+	return 0, 0, false
+}
+
+func atoiOrZero(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,30 @@
+package runner
+
+import "testing"
+
+func TestParseTestCounts(t *testing.T) {
+	tests := []struct {
+		name          string
+		output        string
+		expectedPass  int
+		expectedTotal int
+		expectedOK    bool
+	}{
+		{"go", "=== RUN   TestA\n--- PASS: TestA (0.00s)\n--- FAIL: TestB (0.00s)\n    --- PASS: TestB/sub (0.00s)\n", 2, 3, true},
+		{"pytest", "============ 1 failed, 4 passed in 0.52s ============", 4, 5, true},
+		{"pytest all passed", "===== 3 passed in 0.10s =====", 3, 3, true},
+		{"jest", "Tests:       1 failed, 6 passed, 7 total", 6, 7, true},
+		{"none", "ok  \tgithub.com/example/pkg\t0.01s", 0, 0, false},
+		{"go without -v", "--- FAIL: TestB (0.00s)\n    b_test.go:9: got 1, want 2\nFAIL\nFAIL\tgithub.com/example/b\t0.01s\nok  \tgithub.com/example/a\t0.02s\n", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passed, total, ok := parseTestCounts(tt.output)
+			if passed != tt.expectedPass || total != tt.expectedTotal || ok != tt.expectedOK {
+				t.Errorf("expected (%d, %d, %v), got (%d, %d, %v)",
+					tt.expectedPass, tt.expectedTotal, tt.expectedOK, passed, total, ok)
+			}
+		})
+	}
+}
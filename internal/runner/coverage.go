@@ -0,0 +1,41 @@
+package runner
+
+import (
+	"regexp"
+	"strconv"
+)
+
+var coverageRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`coverage: (\d+(?:\.\d+)?)% of statements`),
+	regexp.MustCompile(`(?m)^TOTAL\s+.*?(\d+(?:\.\d+)?)%\s*$`),
+	regexp.MustCompile(`(?m)^All files\s*\|\s*(\d+(?:\.\d+)?)`),
+}
+
+func parseCoverage(output string) (float64, bool) {
+	for _, re := range coverageRegexes {
+		matches := re.FindAllStringSubmatch(output, -1)
+		if len(matches) == 0 {
+			continue
+		}
+
+		if len(matches) == 1 || re != coverageRegexes[0] {
+			pct, err := strconv.ParseFloat(matches[len(matches)-1][1], 64)
+			if err != nil {
+				continue
+			}
+			return pct, true
+		}
+
+		total := 0.0
+		for _, m := range matches {
+			pct, err := strconv.ParseFloat(m[1], 64)
+			if err != nil {
+				continue
+			}
+			total += pct
+		}
+		return total / float64(len(matches)), true
+	}
+
+	return 0, false
+}
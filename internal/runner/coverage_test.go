@@ -0,0 +1,153 @@
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseCoverage(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected float64
+		measured bool
+	}{
+		{
+			name:     "go test",
+			output:   "ok  \texample.com/pkg\t0.01s\tcoverage: 82.5% of statements",
+			expected: 82.5,
+			measured: true,
+		},
+		{
+			name:     "go test multiple packages",
+			output:   "ok  \ta\t0.01s\tcoverage: 80.0% of statements\nok  \tb\t0.01s\tcoverage: 60.0% of statements",
+			expected: 70,
+			measured: true,
+		},
+		{
+			name:     "pytest-cov",
+			output:   "Name    Stmts   Miss  Cover\n---------------------------\napp.py     10      2    80%\nTOTAL      10      2    80%",
+			expected: 80,
+			measured: true,
+		},
+		{
+			name:     "jest",
+			output:   "----------|---------|\nFile      | % Stmts |\n----------|---------|\nAll files |   91.3 |",
+			expected: 91.3,
+			measured: true,
+		},
+		{
+			name:     "no coverage",
+			output:   "PASS\nok",
+			measured: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pct, measured := parseCoverage(tt.output)
+			if measured != tt.measured {
+				t.Fatalf("expected measured %v, got %v", tt.measured, measured)
+			}
+			if pct != tt.expected {
+				t.Errorf("expected %.1f, got %.1f", tt.expected, pct)
+			}
+		})
+	}
+}
+
+func TestCoverageThreshold(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	t.Run("below threshold", func(t *testing.T) {
+		r := New(t.TempDir())
+		r.SetConfig(RunnerConfig{TestCommand: "echo 'coverage: 60.0% of statements'", Shell: true})
+		r.SetCoverageThreshold(70)
+
+		result, err := r.RunTests()
+		if err != nil {
+			t.Fatalf("RunTests returned error: %v", err)
+		}
+		if result.Success {
+			t.Error("expected run to be marked failed")
+		}
+		if !result.CoverageBelowThreshold {
+			t.Error("expected coverage indicator to be set")
+		}
+		if result.ExitCode != 0 {
+			t.Errorf("expected tests themselves to pass, got exit code %d", result.ExitCode)
+		}
+		if !strings.Contains(result.Output, "Coverage 60.0% is below the required threshold of 70.0%") {
+			t.Errorf("expected coverage message, got %q", result.Output)
+		}
+	})
+
+	t.Run("above threshold", func(t *testing.T) {
+		r := New(t.TempDir())
+		r.SetConfig(RunnerConfig{TestCommand: "echo 'coverage: 75.0% of statements'", Shell: true})
+		r.SetCoverageThreshold(70)
+
+		result, err := r.RunTests()
+		if err != nil {
+			t.Fatalf("RunTests returned error: %v", err)
+		}
+		if !result.Success || result.CoverageBelowThreshold {
+			t.Errorf("expected passing run, got %+v", result)
+		}
+	})
+
+	t.Run("go profile is weighted by statements", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com\n"), 0644); err != nil {
+			t.Fatalf("failed to write go.mod: %v", err)
+		}
+		profile := "mode: set\nexample.com/a/a.go:1.1,2.2 10 1\nexample.com/b/b.go:1.1,2.2 100 1\nexample.com/b/b.go:3.1,4.2 100 0\n"
+
+		r := New(dir)
+		r.SetConfig(RunnerConfig{
+			TestCommand: "printf '" + profile + "' > .reducto/coverage.out; " +
+				"echo 'ok a 0.1s coverage: 100.0% of statements'; echo 'ok b 0.1s coverage: 50.0% of statements'",
+			Shell: true,
+		})
+		r.SetCoverageThreshold(70)
+
+		result, err := r.RunTests()
+		if err != nil {
+			t.Fatalf("RunTests returned error: %v", err)
+		}
+		if !result.CoverageBelowThreshold {
+			t.Errorf("expected weighted coverage %.1f%% to fall below 70%%", result.Coverage)
+		}
+	})
+
+	t.Run("not measured", func(t *testing.T) {
+		r := New(t.TempDir())
+		r.SetConfig(RunnerConfig{TestCommand: "echo PASS", Shell: true})
+		r.SetCoverageThreshold(70)
+
+		result, err := r.RunTests()
+		if err != nil {
+			t.Fatalf("RunTests returned error: %v", err)
+		}
+		if !result.Success || result.CoverageMeasured {
+			t.Errorf("expected check to be skipped, got %+v", result)
+		}
+	})
+}
+
+func TestGoTestCommandCoverProfile(t *testing.T) {
+	r := New(t.TempDir())
+	if cmd := strings.Join(r.getTestCommand(projectGo), " "); strings.Contains(cmd, "-coverprofile") {
+		t.Errorf("expected no cover profile without a threshold, got %q", cmd)
+	}
+
+	r.SetCoverageThreshold(70)
+	if cmd := strings.Join(r.getTestCommand(projectGo), " "); !strings.Contains(cmd, "-coverprofile=") {
+		t.Errorf("expected a cover profile with a threshold, got %q", cmd)
+	}
+}
@@ -0,0 +1,61 @@
+package runner
+
+import (
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+type ExecutionPlan struct {
+	ProjectType  string
+	WorkDir      string
+	TestCommand  []string
+	LintCommand  []string
+	BuildCommand []string
+	Timeout      time.Duration
+	Overrides    map[string]string
+}
+
+// DescribeExecution resolves the commands RunTests, RunLint and Build would
+// run without executing any of them.
+func (r *Runner) DescribeExecution() ExecutionPlan {
+	pt := r.detectProjectType()
+
+	workDir, err := filepath.Abs(r.path)
+	if err != nil {
+		workDir = r.path
+	}
+
+	return ExecutionPlan{
+		ProjectType:  string(pt),
+		WorkDir:      workDir,
+		TestCommand:  r.getTestCommand(pt),
+		LintCommand:  r.getLintCommand(pt),
+		BuildCommand: r.getBuildCommand(pt),
+		Timeout:      r.timeout,
+		Overrides:    r.overrides(),
+	}
+}
+
+func (r *Runner) overrides() map[string]string {
+	overrides := make(map[string]string)
+	if r.config.TestCommand != "" {
+		overrides["test_command"] = r.config.TestCommand
+	}
+	if r.config.LintCommand != "" {
+		overrides["lint_command"] = r.config.LintCommand
+	}
+	if r.config.BuildCommand != "" {
+		overrides["build_command"] = r.config.BuildCommand
+	}
+	if r.config.Shell {
+		overrides["shell"] = "true"
+	}
+	if r.config.PTY {
+		overrides["pty"] = "true"
+	}
+	if r.config.Retries > 0 {
+		overrides["retries"] = strconv.Itoa(r.config.Retries)
+	}
+	return overrides
+}
@@ -0,0 +1,94 @@
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDescribeExecution(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go not available")
+	}
+
+	tests := []struct {
+		name          string
+		config        RunnerConfig
+		expectedTest  string
+		expectedLint  string
+		expectedBuild string
+		overrides     map[string]string
+	}{
+		{
+			name:          "detected commands",
+			expectedTest:  "go test ./...",
+			expectedLint:  "go vet ./...",
+			expectedBuild: "go build ./...",
+			overrides:     map[string]string{},
+		},
+		{
+			name:          "overrides",
+			config:        RunnerConfig{TestCommand: "echo testing", LintCommand: "echo linting", Shell: true},
+			expectedTest:  "sh -c echo testing",
+			expectedLint:  "sh -c echo linting",
+			expectedBuild: "go build ./...",
+			overrides:     map[string]string{"test_command": "echo testing", "lint_command": "echo linting", "shell": "true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			files := map[string]string{
+				"go.mod":       "module example.com/mixed\n\ngo 1.21\n",
+				"package.json": `{"name": "mixed", "scripts": {"test": "jest"}}`,
+				"app.py":       "print('hi')\n",
+			}
+			for name, content := range files {
+				if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+
+			r := New(tmpDir)
+			r.SetConfig(tt.config)
+			r.lookPath = func(string) (string, error) { return "", exec.ErrNotFound }
+
+			plan := r.DescribeExecution()
+
+			if plan.ProjectType != string(projectGo) {
+				t.Errorf("expected go project, got %s", plan.ProjectType)
+			}
+			if !filepath.IsAbs(plan.WorkDir) {
+				t.Errorf("expected absolute work dir, got %s", plan.WorkDir)
+			}
+			if got := strings.Join(plan.TestCommand, " "); got != tt.expectedTest {
+				t.Errorf("expected test command %q, got %q", tt.expectedTest, got)
+			}
+			if got := strings.Join(plan.LintCommand, " "); got != tt.expectedLint {
+				t.Errorf("expected lint command %q, got %q", tt.expectedLint, got)
+			}
+			if got := strings.Join(plan.BuildCommand, " "); got != tt.expectedBuild {
+				t.Errorf("expected build command %q, got %q", tt.expectedBuild, got)
+			}
+			if len(plan.Overrides) != len(tt.overrides) {
+				t.Errorf("expected overrides %v, got %v", tt.overrides, plan.Overrides)
+			}
+			for key, value := range tt.overrides {
+				if plan.Overrides[key] != value {
+					t.Errorf("expected override %s=%q, got %q", key, value, plan.Overrides[key])
+				}
+			}
+
+			result, err := r.RunTests()
+			if err != nil {
+				t.Fatalf("RunTests returned error: %v", err)
+			}
+			if result.Command != strings.Join(plan.TestCommand, " ") {
+				t.Errorf("described command %q does not match executed %q", strings.Join(plan.TestCommand, " "), result.Command)
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package runner
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	coverProfileFile = "coverage.out"
+	coverJSONFile    = "coverage.json"
+	coverDataFile    = ".coverage"
+)
+
+func (r *Runner) SetFileCoverage(enabled bool) {
+	r.fileCoverage = enabled
+}
+
+func (r *Runner) coverageDir() string {
+	return filepath.Join(r.path, ".reducto")
+}
+
+func (r *Runner) collectFileCoverage(pt projectType) map[string]float64 {
+	switch pt {
+	case projectGo:
+		data, err := os.ReadFile(filepath.Join(r.coverageDir(), coverProfileFile))
+		if err != nil {
+			return nil
+		}
+		return parseGoCoverProfile(string(data))
+	case projectPython:
+		if _, err := os.Stat(filepath.Join(r.coverageDir(), coverDataFile)); err != nil {
+			return nil
+		}
+		report := filepath.Join(r.coverageDir(), coverJSONFile)
+		result, err := r.executeEnv([]string{"python", "-m", "coverage", "json", "-q", "-o", report}, r.pythonCoverageEnv())
+		if err != nil || !result.Success {
+			return nil
+		}
+		data, err := os.ReadFile(report)
+		if err != nil {
+			return nil
+		}
+		coverage, err := parseCoverageJSON(data)
+		if err != nil {
+			return nil
+		}
+		return coverage
+	default:
+		return nil
+	}
+}
+
+type coverBlock struct {
+	stmts   int
+	covered bool
+}
+
+// goCoverBlocks groups a Go cover profile's blocks by file. Blocks listed more
+// than once (e.g. with -coverpkg) count as covered if any entry was hit.
+func goCoverBlocks(profile string) map[string]map[string]*coverBlock {
+	blocks := make(map[string]map[string]*coverBlock)
+
+	scanner := bufio.NewScanner(strings.NewReader(profile))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		colon := strings.LastIndex(fields[0], ":")
+		if colon < 0 {
+			continue
+		}
+		stmts, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		count, err := strconv.Atoi(fields[2])
+		if err != nil {
+			continue
+		}
+
+		file, span := fields[0][:colon], fields[0][colon+1:]
+		if blocks[file] == nil {
+			blocks[file] = make(map[string]*coverBlock)
+		}
+		b, ok := blocks[file][span]
+		if !ok {
+			b = &coverBlock{stmts: stmts}
+			blocks[file][span] = b
+		}
+		if count > 0 {
+			b.covered = true
+		}
+	}
+
+	return blocks
+}
+
+func countStatements(blocks map[string]*coverBlock) (total, covered int) {
+	for _, b := range blocks {
+		total += b.stmts
+		if b.covered {
+			covered += b.stmts
+		}
+	}
+	return total, covered
+}
+
+// parseGoCoverProfile weights each file's coverage by statement count, so a
+// large uncovered function counts for more than a one-line covered helper.
+func parseGoCoverProfile(profile string) map[string]float64 {
+	blocks := goCoverBlocks(profile)
+
+	coverage := make(map[string]float64, len(blocks))
+	for file, fileBlocks := range blocks {
+		total, covered := countStatements(fileBlocks)
+		if total == 0 {
+			continue
+		}
+		coverage[file] = float64(covered) * 100 / float64(total)
+	}
+
+	if len(coverage) == 0 {
+		return nil
+	}
+	return coverage
+}
+
+// goCoverProfileTotal is the statement-weighted coverage across every package
+// in the profile, matching what `go tool cover -func` reports as the total.
+func goCoverProfileTotal(profile string) (float64, bool) {
+	var total, covered int
+	for _, fileBlocks := range goCoverBlocks(profile) {
+		t, c := countStatements(fileBlocks)
+		total += t
+		covered += c
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(covered) * 100 / float64(total), true
+}
+
+// wantsCoverProfile reports whether Go test runs should write a cover profile,
+// either for per-file coverage or to check the threshold against it.
+func (r *Runner) wantsCoverProfile() bool {
+	return r.fileCoverage || r.coverageThreshold > 0
+}
+
+func (r *Runner) coverProfileArg() string {
+	return "-coverprofile=" + filepath.Join(r.coverageDir(), coverProfileFile)
+}
+
+// prepareCoverProfile removes the previous run's profile so a run that does
+// not write one is never judged by stale data.
+func (r *Runner) prepareCoverProfile() error {
+	if !r.wantsCoverProfile() {
+		return nil
+	}
+	if err := os.MkdirAll(r.coverageDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create coverage directory: %w", err)
+	}
+	os.Remove(filepath.Join(r.coverageDir(), coverProfileFile))
+	return nil
+}
+
+// preparePythonCoverage points coverage.py at a data file under .reducto and
+// removes the previous run's copy, so per-file coverage only ever reflects the
+// run that just finished rather than a stale .coverage in the project root.
+func (r *Runner) preparePythonCoverage() ([]string, error) {
+	if !r.fileCoverage {
+		return nil, nil
+	}
+	if err := os.MkdirAll(r.coverageDir(), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create coverage directory: %w", err)
+	}
+	os.Remove(filepath.Join(r.coverageDir(), coverDataFile))
+	return r.pythonCoverageEnv(), nil
+}
+
+func (r *Runner) pythonCoverageEnv() []string {
+	return []string{"COVERAGE_FILE=" + filepath.Join(r.coverageDir(), coverDataFile)}
+}
+
+// applyGoCoverProfile replaces the coverage parsed from go test's output,
+// which only reports per-package percentages, with the statement-weighted
+// total from the cover profile when one was written.
+func (r *Runner) applyGoCoverProfile(result *TestResult) {
+	if !r.wantsCoverProfile() {
+		return
+	}
+	data, err := os.ReadFile(filepath.Join(r.coverageDir(), coverProfileFile))
+	if err != nil {
+		return
+	}
+	if pct, ok := goCoverProfileTotal(string(data)); ok {
+		result.Coverage, result.CoverageMeasured = pct, true
+	}
+}
+
+func parseCoverageJSON(data []byte) (map[string]float64, error) {
+	var report struct {
+		Files map[string]struct {
+			Summary struct {
+				PercentCovered float64 `json:"percent_covered"`
+			} `json:"summary"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+
+	coverage := make(map[string]float64, len(report.Files))
+	for file, entry := range report.Files {
+		coverage[file] = entry.Summary.PercentCovered
+	}
+	return coverage, nil
+}
@@ -0,0 +1,128 @@
+package runner
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGoCoverProfile(t *testing.T) {
+	profile := `mode: set
+example.com/app/handler.go:12.20,13.10 1 1
+example.com/app/handler.go:30.30,60.2 99 0
+example.com/app/store.go:8.14,10.2 2 1
+example.com/app/store.go:12.14,14.2 2 0
+example.com/app/store.go:12.14,14.2 2 1
+example.com/app/util.go:5.20,9.2 4 0
+`
+
+	coverage := parseGoCoverProfile(profile)
+
+	expected := map[string]float64{
+		"example.com/app/handler.go": 1,
+		"example.com/app/store.go":   100,
+		"example.com/app/util.go":    0,
+	}
+	if len(coverage) != len(expected) {
+		t.Fatalf("expected %d files, got %v", len(expected), coverage)
+	}
+	for file, pct := range expected {
+		if got, ok := coverage[file]; !ok || got != pct {
+			t.Errorf("expected %s at %.1f%%, got %.1f%% (present=%v)", file, pct, got, ok)
+		}
+	}
+
+	if got := parseGoCoverProfile("mode: set\n"); got != nil {
+		t.Errorf("expected nil for empty profile, got %v", got)
+	}
+}
+
+func TestGoCoverProfileTotal(t *testing.T) {
+	// A small, fully covered package must not pull a large, barely covered
+	// one up to the average of the two percentages.
+	profile := `mode: set
+example.com/small/a.go:1.1,2.2 10 1
+example.com/big/b.go:1.1,2.2 10 1
+example.com/big/b.go:3.1,4.2 90 0
+`
+
+	pct, ok := goCoverProfileTotal(profile)
+	if !ok {
+		t.Fatal("expected coverage to be measured")
+	}
+	if want := 20.0 * 100 / 110; pct != want {
+		t.Errorf("expected %.2f%%, got %.2f%%", want, pct)
+	}
+
+	if _, ok := goCoverProfileTotal("mode: set\n"); ok {
+		t.Error("expected empty profile to be unmeasured")
+	}
+}
+
+func TestParseCoverageJSON(t *testing.T) {
+	data := []byte(`{
+		"meta": {"version": "7.4.0"},
+		"files": {
+			"app/models.py": {"summary": {"percent_covered": 92.5}},
+			"app/views.py": {"summary": {"percent_covered": 40.0}}
+		},
+		"totals": {"percent_covered": 70.0}
+	}`)
+
+	coverage, err := parseCoverageJSON(data)
+	if err != nil {
+		t.Fatalf("parseCoverageJSON returned error: %v", err)
+	}
+	if coverage["app/models.py"] != 92.5 || coverage["app/views.py"] != 40 {
+		t.Errorf("unexpected coverage %v", coverage)
+	}
+
+	if _, err := parseCoverageJSON([]byte("not json")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestPythonCoverageDataFile(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "pyproject.toml"), nil, 0644); err != nil {
+		t.Fatalf("failed to write pyproject.toml: %v", err)
+	}
+	stale := filepath.Join(dir, ".reducto", coverDataFile)
+	if err := os.MkdirAll(filepath.Dir(stale), 0755); err != nil {
+		t.Fatalf("failed to create coverage directory: %v", err)
+	}
+	if err := os.WriteFile(stale, []byte("stale"), 0644); err != nil {
+		t.Fatalf("failed to write stale data file: %v", err)
+	}
+
+	r := New(dir)
+	r.SetFileCoverage(true)
+	r.SetConfig(RunnerConfig{
+		TestCommand: `printf '%s' "$COVERAGE_FILE" > seen.txt; test ! -e .reducto/.coverage`,
+		Shell:       true,
+	})
+
+	result, err := r.RunTests()
+	if err != nil {
+		t.Fatalf("RunTests returned error: %v", err)
+	}
+	if !result.Success {
+		t.Errorf("expected the stale data file to be removed before the run, got output %q", result.Output)
+	}
+	if result.FileCoverage != nil {
+		t.Errorf("expected no per-file coverage without fresh data, got %v", result.FileCoverage)
+	}
+
+	seen, err := os.ReadFile(filepath.Join(dir, "seen.txt"))
+	if err != nil {
+		t.Fatalf("failed to read seen.txt: %v", err)
+	}
+	if string(seen) != stale {
+		t.Errorf("expected COVERAGE_FILE=%s, got %q", stale, seen)
+	}
+}
@@ -0,0 +1,89 @@
+package runner
+
+import (
+	"bufio"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+)
+
+type goTestEvent struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	Elapsed float64   `json:"Elapsed"`
+	Output  string    `json:"Output"`
+}
+
+func parseGoTestEvents(output string) ([]goTestEvent, bool) {
+	var events []goTestEvent
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var ev goTestEvent
+		if err := json.Unmarshal([]byte(line), &ev); err != nil || ev.Action == "" {
+			continue
+		}
+		events = append(events, ev)
+	}
+
+	return events, len(events) > 0
+}
+
+func goTestPlainOutput(events []goTestEvent) string {
+	var sb strings.Builder
+	for _, ev := range events {
+		if ev.Action == "output" {
+			sb.WriteString(ev.Output)
+		}
+	}
+	return sb.String()
+}
+
+func findSlowTests(events []goTestEvent, budget time.Duration) []string {
+	started := make(map[string]time.Time)
+	finished := make(map[string]bool)
+	slow := make(map[string]bool)
+	var last time.Time
+
+	for _, ev := range events {
+		if ev.Time.After(last) {
+			last = ev.Time
+		}
+		if ev.Test == "" {
+			continue
+		}
+
+		key := ev.Package + "." + ev.Test
+		switch ev.Action {
+		case "run":
+			started[key] = ev.Time
+		case "pass", "fail", "skip":
+			finished[key] = true
+			if time.Duration(ev.Elapsed*float64(time.Second)) > budget {
+				slow[key] = true
+			}
+		}
+	}
+
+	for key, start := range started {
+		if !finished[key] && !start.IsZero() && last.Sub(start) > budget {
+			slow[key] = true
+		}
+	}
+
+	names := make([]string, 0, len(slow))
+	for key := range slow {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,79 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const goTestJSONTranscript = `{"Time":"2026-01-01T10:00:00Z","Action":"start","Package":"example.com/m/store"}
+{"Time":"2026-01-01T10:00:00Z","Action":"run","Package":"example.com/m/store","Test":"TestFast"}
+{"Time":"2026-01-01T10:00:00Z","Action":"output","Package":"example.com/m/store","Test":"TestFast","Output":"=== RUN   TestFast\n"}
+{"Time":"2026-01-01T10:00:00Z","Action":"output","Package":"example.com/m/store","Test":"TestFast","Output":"--- PASS: TestFast (0.01s)\n"}
+{"Time":"2026-01-01T10:00:00Z","Action":"pass","Package":"example.com/m/store","Test":"TestFast","Elapsed":0.01}
+{"Time":"2026-01-01T10:00:00Z","Action":"run","Package":"example.com/m/store","Test":"TestMigration"}
+{"Time":"2026-01-01T10:00:00Z","Action":"output","Package":"example.com/m/store","Test":"TestMigration","Output":"=== RUN   TestMigration\n"}
+{"Time":"2026-01-01T10:00:42Z","Action":"output","Package":"example.com/m/store","Test":"TestMigration","Output":"--- PASS: TestMigration (42.00s)\n"}
+{"Time":"2026-01-01T10:00:42Z","Action":"pass","Package":"example.com/m/store","Test":"TestMigration","Elapsed":42}
+{"Time":"2026-01-01T10:00:42Z","Action":"output","Package":"example.com/m/store","Output":"coverage: 75.0% of statements\n"}
+{"Time":"2026-01-01T10:00:42Z","Action":"pass","Package":"example.com/m/store","Elapsed":42.01}
+`
+
+func TestFindSlowTests(t *testing.T) {
+	events, ok := parseGoTestEvents(goTestJSONTranscript)
+	if !ok {
+		t.Fatal("expected transcript to parse as go test -json events")
+	}
+
+	slow := findSlowTests(events, 10*time.Second)
+	if len(slow) != 1 || slow[0] != "example.com/m/store.TestMigration" {
+		t.Errorf("expected only TestMigration to be slow, got %v", slow)
+	}
+
+	if slow := findSlowTests(events, time.Minute); len(slow) != 0 {
+		t.Errorf("expected no slow tests with a generous budget, got %v", slow)
+	}
+
+	plain := goTestPlainOutput(events)
+	if passed, total, ok := parseTestCounts(plain); !ok || passed != 2 || total != 2 {
+		t.Errorf("expected 2/2 from plain output, got %d/%d (ok=%v)", passed, total, ok)
+	}
+	if pct, ok := parseCoverage(plain); !ok || pct != 75 {
+		t.Errorf("expected coverage 75, got %v (ok=%v)", pct, ok)
+	}
+}
+
+func TestFindSlowTestsUnfinished(t *testing.T) {
+	transcript := `{"Time":"2026-01-01T10:00:00Z","Action":"run","Package":"p","Test":"TestHang"}
+{"Time":"2026-01-01T10:05:00Z","Action":"output","Package":"p","Output":"panic: test timed out after 5m0s\n"}
+`
+	events, _ := parseGoTestEvents(transcript)
+	if slow := findSlowTests(events, time.Minute); len(slow) != 1 || slow[0] != "p.TestHang" {
+		t.Errorf("expected hanging test to be flagged, got %v", slow)
+	}
+}
+
+func TestRunTestsPerTestTimeout(t *testing.T) {
+	r := New(t.TempDir())
+	r.SetConfig(RunnerConfig{
+		TestCommand:    "cat transcript.json",
+		PerTestTimeout: 10 * time.Second,
+	})
+	if err := os.WriteFile(filepath.Join(r.path, "transcript.json"), []byte(goTestJSONTranscript), 0644); err != nil {
+		t.Fatalf("failed to write transcript: %v", err)
+	}
+
+	result, err := r.RunTests()
+	if err != nil {
+		t.Fatalf("RunTests returned error: %v", err)
+	}
+
+	if strings.Join(result.Slow, ",") != "example.com/m/store.TestMigration" {
+		t.Errorf("expected TestMigration to be flagged slow, got %v", result.Slow)
+	}
+	if !result.CountsMeasured || result.Total != 2 {
+		t.Errorf("expected counts from JSON output, got %d/%d", result.Passed, result.Total)
+	}
+}
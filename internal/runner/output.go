@@ -0,0 +1,78 @@
+package runner
+
+import "fmt"
+
+const defaultMaxOutputBytes = 10 * 1024 * 1024
+
+type cappedBuffer struct {
+	max     int
+	head    []byte
+	tail    []byte
+	tailPos int
+	tailLen int
+	dropped int64
+}
+
+func newCappedBuffer(max int) *cappedBuffer {
+	if max <= 0 {
+		max = defaultMaxOutputBytes
+	}
+	headMax := max / 2
+	return &cappedBuffer{
+		max:  max,
+		head: make([]byte, 0, headMax),
+		tail: make([]byte, max-headMax),
+	}
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+
+	if room := cap(b.head) - len(b.head); room > 0 {
+		take := room
+		if take > len(p) {
+			take = len(p)
+		}
+		b.head = append(b.head, p[:take]...)
+		p = p[take:]
+	}
+
+	if len(b.tail) == 0 {
+		b.dropped += int64(len(p))
+		return n, nil
+	}
+
+	for _, c := range p {
+		if b.tailLen == len(b.tail) {
+			b.dropped++
+		} else {
+			b.tailLen++
+		}
+		b.tail[b.tailPos] = c
+		b.tailPos = (b.tailPos + 1) % len(b.tail)
+	}
+
+	return n, nil
+}
+
+func (b *cappedBuffer) Len() int {
+	return len(b.head) + b.tailLen
+}
+
+func (b *cappedBuffer) Truncated() bool {
+	return b.dropped > 0
+}
+
+func (b *cappedBuffer) String() string {
+	start := (b.tailPos - b.tailLen + len(b.tail)) % max(len(b.tail), 1)
+	tail := make([]byte, 0, b.tailLen)
+	for i := 0; i < b.tailLen; i++ {
+		tail = append(tail, b.tail[(start+i)%len(b.tail)])
+	}
+
+	if b.dropped == 0 {
+		return string(b.head) + string(tail)
+	}
+
+	return fmt.Sprintf("%s\n…[truncated %d bytes]…\n%s", b.head, b.dropped, tail)
+}
@@ -0,0 +1,71 @@
+package runner
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestCappedBuffer(t *testing.T) {
+	t.Run("under cap", func(t *testing.T) {
+		b := newCappedBuffer(16)
+		b.Write([]byte("hello "))
+		b.Write([]byte("world"))
+
+		if b.Truncated() {
+			t.Error("expected no truncation")
+		}
+		if b.String() != "hello world" {
+			t.Errorf("expected %q, got %q", "hello world", b.String())
+		}
+	})
+
+	t.Run("over cap keeps head and tail", func(t *testing.T) {
+		b := newCappedBuffer(10)
+		b.Write([]byte("abcde"))
+		b.Write([]byte(strings.Repeat("-", 100)))
+		b.Write([]byte("vwxyz"))
+
+		if !b.Truncated() {
+			t.Fatal("expected truncation")
+		}
+		expected := "abcde\n…[truncated 100 bytes]…\nvwxyz"
+		if b.String() != expected {
+			t.Errorf("expected %q, got %q", expected, b.String())
+		}
+		if b.Len() != 10 {
+			t.Errorf("expected 10 retained bytes, got %d", b.Len())
+		}
+	})
+}
+
+func TestExecuteTruncatesOutput(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	r := New(t.TempDir())
+	r.SetMaxOutputBytes(1000)
+	r.SetConfig(RunnerConfig{
+		TestCommand: `printf START; i=0; while [ $i -lt 500 ]; do printf xxxxxxxxxx; i=$((i+1)); done; printf END`,
+		Shell:       true,
+	})
+
+	result, err := r.RunTests()
+	if err != nil {
+		t.Fatalf("RunTests returned error: %v", err)
+	}
+
+	if !result.Truncated {
+		t.Fatal("expected result to be marked truncated")
+	}
+	if !strings.HasPrefix(result.Output, "START") {
+		t.Errorf("expected output to keep the head, got %q", result.Output[:20])
+	}
+	if !strings.HasSuffix(strings.TrimSpace(result.Output), "END") {
+		t.Error("expected output to keep the tail")
+	}
+	if !strings.Contains(result.Output, "…[truncated 4008 bytes]…") {
+		t.Errorf("expected truncation marker, got %q", result.Output)
+	}
+}
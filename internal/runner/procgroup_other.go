@@ -0,0 +1,9 @@
+//go:build !unix
+
+package runner
+
+import "os/exec"
+
+func killOnCancel(c *exec.Cmd) {
+	c.WaitDelay = killWaitDelay
+}
@@ -0,0 +1,23 @@
+//go:build unix
+
+package runner
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killOnCancel runs the command in its own process group so that a timeout
+// also stops any children it spawned (go test binaries, npm scripts).
+func killOnCancel(c *exec.Cmd) {
+	if c.SysProcAttr == nil {
+		c.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	if !c.SysProcAttr.Setsid {
+		c.SysProcAttr.Setpgid = true
+	}
+	c.Cancel = func() error {
+		return syscall.Kill(-c.Process.Pid, syscall.SIGKILL)
+	}
+	c.WaitDelay = killWaitDelay
+}
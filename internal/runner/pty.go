@@ -0,0 +1,87 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/creack/pty"
+)
+
+var errPTYUnsupported = errors.New("pseudo-terminal not supported on this platform")
+
+func openPTY() (*os.File, *os.File, error) {
+	master, slave, err := pty.Open()
+	if errors.Is(err, pty.ErrUnsupported) {
+		return nil, nil, errPTYUnsupported
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open pty: %w", err)
+	}
+	return master, slave, nil
+}
+
+func (r *Runner) executePTY(ctx context.Context, cmd, env []string) (*TestResult, error) {
+	master, slave, err := openPTY()
+	if err != nil {
+		return nil, err
+	}
+	defer master.Close()
+
+	start := time.Now()
+
+	c := exec.CommandContext(ctx, cmd[0], cmd[1:]...)
+	c.Dir = r.path
+	c.Env = commandEnv(env)
+	c.Stdin = slave
+	c.Stdout = slave
+	c.Stderr = slave
+	c.SysProcAttr = ptyProcAttr()
+	killOnCancel(c)
+
+	if err := c.Start(); err != nil {
+		slave.Close()
+		return nil, fmt.Errorf("failed to run command: %w", err)
+	}
+	slave.Close()
+
+	output := newCappedBuffer(r.maxOutputBytes)
+	var w io.Writer = output
+	if r.stream != nil {
+		w = io.MultiWriter(output, r.stream)
+	}
+
+	// Reading the master fails with EIO once the child side is closed, which
+	// is the PTY equivalent of EOF.
+	io.Copy(w, master)
+
+	err = c.Wait()
+	duration := time.Since(start)
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return r.timeoutResult(cmd, output.String(), duration, output.Truncated()), nil
+	}
+
+	exitCode := 0
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			exitCode = exitErr.ExitCode()
+		} else {
+			return nil, fmt.Errorf("failed to run command: %w", err)
+		}
+	}
+
+	return &TestResult{
+		Success:   exitCode == 0,
+		Output:    output.String(),
+		Duration:  duration,
+		Command:   strings.Join(cmd, " "),
+		ExitCode:  exitCode,
+		Truncated: output.Truncated(),
+	}, nil
+}
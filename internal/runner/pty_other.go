@@ -0,0 +1,9 @@
+//go:build !unix
+
+package runner
+
+import "syscall"
+
+func ptyProcAttr() *syscall.SysProcAttr {
+	return nil
+}
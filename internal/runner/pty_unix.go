@@ -0,0 +1,12 @@
+//go:build unix
+
+package runner
+
+import "syscall"
+
+func ptyProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		Setsid:  true,
+		Setctty: true,
+	}
+}
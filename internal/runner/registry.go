@@ -0,0 +1,141 @@
+package runner
+
+import (
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+type DetectorFunc func(path string) (projectType, bool)
+
+type ProjectSpec struct {
+	TestCommand  []string
+	LintCommand  []string
+	BuildCommand []string
+}
+
+type detectorEntry struct {
+	id       int
+	priority int
+	detect   func(r *Runner) (projectType, bool)
+}
+
+var (
+	registryMu     sync.RWMutex
+	detectors      []detectorEntry
+	nextDetectorID int
+	specs          = make(map[projectType]ProjectSpec)
+)
+
+func init() {
+	registerBuiltin(100, projectGo, func(r *Runner) bool {
+		return r.fileExists("go.mod")
+	})
+	registerBuiltin(200, projectPython, func(r *Runner) bool {
+		return r.fileExists("pyproject.toml") || r.fileExists("setup.py") || r.fileExists("requirements.txt")
+	})
+	registerDetector(300, func(r *Runner) (projectType, bool) {
+		if !r.fileExists("package.json") {
+			return "", false
+		}
+		if r.isTypeScriptProject() {
+			return projectTypeScript, true
+		}
+		return projectJavaScript, true
+	})
+	registerBuiltin(400, projectScala, func(r *Runner) bool {
+		return r.fileExists("build.sbt")
+	})
+	registerBuiltin(500, projectKotlin, (*Runner).isKotlinProject)
+	registerBuiltin(600, projectJava, func(r *Runner) bool {
+		return r.fileExists("pom.xml") || r.fileExists("build.gradle") || r.fileExists("build.gradle.kts")
+	})
+}
+
+// RegisterDetector adds a detector whose matches use spec for their commands.
+// The spec is keyed by the project type the detector returns, which need not
+// equal name. The returned function removes the detector and its specs again.
+func RegisterDetector(name string, priority int, detect DetectorFunc, spec ProjectSpec) (unregister func()) {
+	var mu sync.Mutex
+	returned := make(map[projectType]bool)
+
+	id := registerDetector(priority, func(r *Runner) (projectType, bool) {
+		pt, ok := detect(r.path)
+		if !ok {
+			return pt, false
+		}
+
+		mu.Lock()
+		returned[pt] = true
+		mu.Unlock()
+
+		registryMu.Lock()
+		specs[pt] = spec
+		registryMu.Unlock()
+		return pt, true
+	})
+
+	return func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+
+		for i, entry := range detectors {
+			if entry.id == id {
+				detectors = append(detectors[:i:i], detectors[i+1:]...)
+				break
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		for pt := range returned {
+			delete(specs, pt)
+		}
+	}
+}
+
+func MarkerDetector(name string, markers ...string) DetectorFunc {
+	return func(path string) (projectType, bool) {
+		for _, marker := range markers {
+			if matches, _ := filepath.Glob(filepath.Join(path, marker)); len(matches) > 0 {
+				return projectType(name), true
+			}
+		}
+		return "", false
+	}
+}
+
+func registerBuiltin(priority int, pt projectType, match func(r *Runner) bool) {
+	registerDetector(priority, func(r *Runner) (projectType, bool) {
+		return pt, match(r)
+	})
+}
+
+func registerDetector(priority int, detect func(r *Runner) (projectType, bool)) int {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	nextDetectorID++
+	detectors = append(detectors, detectorEntry{id: nextDetectorID, priority: priority, detect: detect})
+	sort.SliceStable(detectors, func(i, j int) bool {
+		return detectors[i].priority < detectors[j].priority
+	})
+	return nextDetectorID
+}
+
+func registeredDetectors() []detectorEntry {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	entries := make([]detectorEntry, len(detectors))
+	copy(entries, detectors)
+	return entries
+}
+
+func registeredSpec(pt projectType) (ProjectSpec, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	spec, ok := specs[pt]
+	return spec, ok
+}
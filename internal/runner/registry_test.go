@@ -0,0 +1,94 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRegisterDetector(t *testing.T) {
+	t.Cleanup(RegisterDetector("elixir", 50, MarkerDetector("elixir", "mix.exs"), ProjectSpec{
+		TestCommand: []string{"mix", "test"},
+		LintCommand: []string{"mix", "credo"},
+	}))
+	t.Cleanup(RegisterDetector("zig-stack", 60, MarkerDetector("zig", "build.zig"), ProjectSpec{
+		TestCommand: []string{"zig", "build", "test"},
+	}))
+
+	tests := []struct {
+		name         string
+		files        map[string]string
+		expected     projectType
+		expectedTest string
+	}{
+		{
+			name:         "custom detector matches",
+			files:        map[string]string{"mix.exs": "defmodule App.MixProject do\nend\n"},
+			expected:     "elixir",
+			expectedTest: "mix test",
+		},
+		{
+			name:         "custom detector takes priority over builtins",
+			files:        map[string]string{"mix.exs": "", "package.json": `{"name": "assets"}`},
+			expected:     "elixir",
+			expectedTest: "mix test",
+		},
+		{
+			name:         "spec follows the returned type",
+			files:        map[string]string{"build.zig": ""},
+			expected:     "zig",
+			expectedTest: "zig build test",
+		},
+		{
+			name:         "builtins still apply",
+			files:        map[string]string{"go.mod": "module test"},
+			expected:     projectGo,
+			expectedTest: "go test ./...",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("failed to create %s: %v", name, err)
+				}
+			}
+
+			r := New(tmpDir)
+			pt := r.detectProjectType()
+			if pt != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, pt)
+			}
+			if got := strings.Join(r.getTestCommand(pt), " "); got != tt.expectedTest {
+				t.Errorf("expected test command %q, got %q", tt.expectedTest, got)
+			}
+		})
+	}
+}
+
+func TestUnregisterDetector(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "mix.exs"), []byte(""), 0644); err != nil {
+		t.Fatalf("failed to create mix.exs: %v", err)
+	}
+
+	unregister := RegisterDetector("elixir", 50, MarkerDetector("elixir", "mix.exs"), ProjectSpec{
+		TestCommand: []string{"mix", "test"},
+	})
+	if pt := New(tmpDir).detectProjectType(); pt != "elixir" {
+		t.Fatalf("expected elixir while registered, got %s", pt)
+	}
+
+	unregister()
+
+	r := New(tmpDir)
+	if pt := r.detectProjectType(); pt != projectUnknown {
+		t.Errorf("expected unknown after unregister, got %s", pt)
+	}
+	if cmd := r.specCommand("elixir", func(spec ProjectSpec) []string { return spec.TestCommand }); cmd != nil {
+		t.Errorf("expected spec to be removed, got %v", cmd)
+	}
+}
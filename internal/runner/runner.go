@@ -1,24 +1,61 @@
 package runner
 
 import (
-	"bytes"
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
 )
 
 type Runner struct {
-	path    string
-	timeout time.Duration
+	path              string
+	timeout           time.Duration
+	maxOutputBytes    int
+	coverageThreshold float64
+	fileCoverage      bool
+	buildCache        bool
+	config            RunnerConfig
+	configWarnings    []string
+	stream            io.Writer
+	stat              func(string) (os.FileInfo, error)
+	lookPath          func(string) (string, error)
+
+	detectMu    sync.Mutex
+	detected    projectType
+	hasDetected bool
+}
+
+type RunnerConfig struct {
+	TestCommand     string        `mapstructure:"test_command" yaml:"test_command"`
+	LintCommand     string        `mapstructure:"lint_command" yaml:"lint_command"`
+	BuildCommand    string        `mapstructure:"build_command" yaml:"build_command"`
+	Shell           bool          `mapstructure:"shell" yaml:"shell"`
+	Timeout         time.Duration `mapstructure:"timeout" yaml:"timeout"`
+	Retries         int           `mapstructure:"retries" yaml:"retries"`
+	PTY             bool          `mapstructure:"pty" yaml:"pty"`
+	PerTestTimeout  time.Duration `mapstructure:"per_test_timeout" yaml:"per_test_timeout"`
+	ExcludePatterns []string      `mapstructure:"exclude_patterns" yaml:"exclude_patterns"`
+	SuppressRules   []string      `mapstructure:"suppress_rules" yaml:"suppress_rules"`
 }
 
 func New(path string) *Runner {
 	return &Runner{
-		path:    path,
-		timeout: 5 * time.Minute,
+		path:           path,
+		timeout:        5 * time.Minute,
+		maxOutputBytes: defaultMaxOutputBytes,
+		stat:           os.Stat,
+		lookPath:       exec.LookPath,
 	}
 }
 
@@ -26,29 +63,74 @@ func (r *Runner) SetTimeout(timeout time.Duration) {
 	r.timeout = timeout
 }
 
-type TestResult struct {
-	Success  bool
-	Output   string
-	Duration time.Duration
-	Command  string
-	ExitCode int
+func (r *Runner) SetMaxOutputBytes(n int) {
+	r.maxOutputBytes = n
 }
 
-type LintResult struct {
-	Success  bool
-	Output   string
-	Issues   []LintIssue
-	Duration time.Duration
+func (r *Runner) SetCoverageThreshold(pct float64) {
+	r.coverageThreshold = pct
+}
+
+func (r *Runner) SetConfig(cfg RunnerConfig) {
+	r.config = cfg
+}
+
+func (r *Runner) SetShell(shell bool) {
+	r.config.Shell = shell
+}
+
+func (r *Runner) SetPTY(enabled bool) {
+	r.config.PTY = enabled
+}
+
+func (r *Runner) SetPerTestTimeout(budget time.Duration) {
+	r.config.PerTestTimeout = budget
+}
+
+func (r *Runner) SetStreamOutput(w io.Writer) {
+	r.stream = w
+}
+
+type TestResult struct {
+	Success     bool
+	Output      string
+	Duration    time.Duration
+	Command     string
+	ExitCode    int
+	Truncated   bool
+	BuildErrors []BuildError
+
+	Coverage               float64
+	CoverageMeasured       bool
+	CoverageBelowThreshold bool
+	FileCoverage           map[string]float64
+
+	Passed         int
+	Total          int
+	CountsMeasured bool
+
+	TestCases []TestCaseResult
+	Slow      []string
+
+	Cached bool
 }
 
-type LintIssue struct {
+type BuildError struct {
 	File     string
 	Line     int
 	Column   int
+	Code     string
 	Message  string
 	Severity string
 }
 
+type LintResult struct {
+	Success  bool
+	Output   string
+	Issues   []models.LintIssue
+	Duration time.Duration
+}
+
 func (r *Runner) RunTests() (*TestResult, error) {
 	detector := r.detectProjectType()
 	testCmd := r.getTestCommand(detector)
@@ -60,7 +142,61 @@ func (r *Runner) RunTests() (*TestResult, error) {
 		}, nil
 	}
 
-	return r.execute(testCmd)
+	var env []string
+	switch detector {
+	case projectGo:
+		if err := r.prepareCoverProfile(); err != nil {
+			return nil, err
+		}
+	case projectPython:
+		var err error
+		if env, err = r.preparePythonCoverage(); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := r.executeEnv(testCmd, env)
+	for attempt := 0; attempt < r.config.Retries && err == nil && !result.Success; attempt++ {
+		result, err = r.executeEnv(testCmd, env)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	output := result.Output
+	if events, ok := parseGoTestEvents(result.Output); ok {
+		output = goTestPlainOutput(events)
+		if r.config.PerTestTimeout > 0 {
+			result.Slow = findSlowTests(events, r.config.PerTestTimeout)
+		}
+	}
+
+	result.Passed, result.Total, result.CountsMeasured = parseTestCounts(output)
+	if detector == projectPython && !result.Success {
+		result.TestCases = parsePythonTestCases(output)
+	}
+	result.Coverage, result.CoverageMeasured = parseCoverage(output)
+	if detector == projectGo {
+		r.applyGoCoverProfile(result)
+	}
+	if r.fileCoverage {
+		result.FileCoverage = r.collectFileCoverage(detector)
+	}
+	r.applyCoverageThreshold(result)
+
+	return result, nil
+}
+
+func (r *Runner) applyCoverageThreshold(result *TestResult) {
+	if r.coverageThreshold <= 0 || !result.CoverageMeasured || !result.Success {
+		return
+	}
+
+	if result.Coverage < r.coverageThreshold {
+		result.Success = false
+		result.CoverageBelowThreshold = true
+		result.Output += fmt.Sprintf("\nCoverage %.1f%% is below the required threshold of %.1f%%", result.Coverage, r.coverageThreshold)
+	}
 }
 
 func (r *Runner) RunLint() (*LintResult, error) {
@@ -79,28 +215,107 @@ func (r *Runner) RunLint() (*LintResult, error) {
 		return nil, err
 	}
 
-	lintResult := &LintResult{
-		Success:  result.Success,
-		Output:   result.Output,
-		Duration: result.Duration,
+	return r.lintResult(result, detector), nil
+}
+
+func (r *Runner) RunLintForFiles(files []string) (*LintResult, error) {
+	detector := r.detectProjectType()
+
+	lintCmd, ok := r.getLintCommandForFiles(detector, files)
+	if !ok {
+		return r.RunLint()
+	}
+
+	if lintCmd == nil {
+		return &LintResult{
+			Success: true,
+			Output:  "No lintable files in the provided set",
+		}, nil
 	}
 
-	lintResult.Issues = r.parseLintOutput(result.Output, detector)
+	result, err := r.execute(lintCmd)
+	if err != nil {
+		return nil, err
+	}
 
-	return lintResult, nil
+	return r.lintResult(result, detector), nil
 }
 
+const (
+	timeoutExitCode = 124
+	killWaitDelay   = 2 * time.Second
+)
+
 func (r *Runner) execute(cmd []string) (*TestResult, error) {
+	ctx := context.Background()
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	return r.executeContext(ctx, cmd, nil)
+}
+
+// executeEnv runs cmd like execute with env added to the inherited
+// environment.
+func (r *Runner) executeEnv(cmd, env []string) (*TestResult, error) {
+	ctx := context.Background()
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	return r.executeContext(ctx, cmd, env)
+}
+
+func commandEnv(env []string) []string {
+	if len(env) == 0 {
+		return nil
+	}
+	return append(os.Environ(), env...)
+}
+
+func (r *Runner) timeoutResult(cmd []string, output string, duration time.Duration, truncated bool) *TestResult {
+	if output != "" && !strings.HasSuffix(output, "\n") {
+		output += "\n"
+	}
+	output += fmt.Sprintf("command timed out after %v", r.timeout)
+
+	return &TestResult{
+		Success:   false,
+		Output:    output,
+		Duration:  duration,
+		Command:   strings.Join(cmd, " "),
+		ExitCode:  timeoutExitCode,
+		Truncated: truncated,
+	}
+}
+
+func (r *Runner) executeContext(ctx context.Context, cmd, env []string) (*TestResult, error) {
+	if r.config.PTY {
+		result, err := r.executePTY(ctx, cmd, env)
+		if !errors.Is(err, errPTYUnsupported) {
+			return result, err
+		}
+	}
+
 	start := time.Now()
 
-	ctx := exec.Command(cmd[0], cmd[1:]...)
-	ctx.Dir = r.path
+	c := exec.CommandContext(ctx, cmd[0], cmd[1:]...)
+	c.Dir = r.path
+	c.Env = commandEnv(env)
+	killOnCancel(c)
 
-	var stdout, stderr bytes.Buffer
-	ctx.Stdout = &stdout
-	ctx.Stderr = &stderr
+	stdout := newCappedBuffer(r.maxOutputBytes)
+	stderr := newCappedBuffer(r.maxOutputBytes)
+	c.Stdout = stdout
+	c.Stderr = stderr
+	if r.stream != nil {
+		c.Stdout = io.MultiWriter(stdout, r.stream)
+		c.Stderr = io.MultiWriter(stderr, r.stream)
+	}
 
-	err := ctx.Run()
+	err := c.Run()
 	duration := time.Since(start)
 
 	output := stdout.String()
@@ -108,6 +323,10 @@ func (r *Runner) execute(cmd []string) (*TestResult, error) {
 		output += "\n" + stderr.String()
 	}
 
+	if ctx.Err() == context.DeadlineExceeded {
+		return r.timeoutResult(cmd, output, duration, stdout.Truncated() || stderr.Truncated()), nil
+	}
+
 	exitCode := 0
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
@@ -118,11 +337,12 @@ func (r *Runner) execute(cmd []string) (*TestResult, error) {
 	}
 
 	return &TestResult{
-		Success:  exitCode == 0,
-		Output:   output,
-		Duration: duration,
-		Command:  strings.Join(cmd, " "),
-		ExitCode: exitCode,
+		Success:   exitCode == 0,
+		Output:    output,
+		Duration:  duration,
+		Command:   strings.Join(cmd, " "),
+		ExitCode:  exitCode,
+		Truncated: stdout.Truncated() || stderr.Truncated(),
 	}, nil
 }
 
@@ -133,31 +353,108 @@ const (
 	projectJavaScript projectType = "javascript"
 	projectTypeScript projectType = "typescript"
 	projectGo         projectType = "go"
+	projectJava       projectType = "java"
+	projectKotlin     projectType = "kotlin"
+	projectScala      projectType = "scala"
 	projectUnknown    projectType = "unknown"
 )
 
 func (r *Runner) detectProjectType() projectType {
-	if r.fileExists("go.mod") {
-		return projectGo
-	}
+	r.detectMu.Lock()
+	defer r.detectMu.Unlock()
 
-	if r.fileExists("pyproject.toml") || r.fileExists("setup.py") || r.fileExists("requirements.txt") {
-		return projectPython
+	if !r.hasDetected {
+		r.detected = r.detectProjectTypeUncached()
+		r.hasDetected = true
 	}
 
-	if r.fileExists("package.json") {
-		pkg := r.readPackageJSON()
-		if strings.Contains(pkg, "typescript") {
-			return projectTypeScript
+	return r.detected
+}
+
+func (r *Runner) ResetDetection() {
+	r.detectMu.Lock()
+	defer r.detectMu.Unlock()
+
+	r.hasDetected = false
+	r.detected = ""
+}
+
+func (r *Runner) detectProjectTypeUncached() projectType {
+	for _, entry := range registeredDetectors() {
+		if pt, ok := entry.detect(r); ok {
+			return pt
 		}
-		return projectJavaScript
 	}
 
 	return projectUnknown
 }
 
+func (r *Runner) isTypeScriptProject() bool {
+	return strings.Contains(r.readPackageJSON(), "typescript")
+}
+
+func (r *Runner) isKotlinProject() bool {
+	if r.fileExists(filepath.Join("src", "main", "kotlin")) {
+		return true
+	}
+
+	for _, name := range []string{"build.gradle.kts", "build.gradle"} {
+		content, err := os.ReadFile(filepath.Join(r.path, name))
+		if err == nil && strings.Contains(string(content), "kotlin") {
+			return true
+		}
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(r.path, "*.kt"))
+	return len(matches) > 0
+}
+
+func (r *Runner) gradleCommand(task string) []string {
+	if r.fileExists("gradlew") {
+		return []string{"./gradlew", task}
+	}
+	return []string{"gradle", task}
+}
+
+type jvmBuildTool int
+
+const (
+	jvmGradle jvmBuildTool = iota
+	jvmMaven
+	jvmSbt
+)
+
+// jvmBuildTool picks the build tool from the marker files, since Java, Kotlin
+// and Scala can each be built with any of them. The language only decides
+// when no marker is present.
+func (r *Runner) jvmBuildTool(pt projectType) jvmBuildTool {
+	switch {
+	case r.fileExists("pom.xml"):
+		return jvmMaven
+	case r.fileExists("build.gradle") || r.fileExists("build.gradle.kts") || r.fileExists("gradlew"):
+		return jvmGradle
+	case r.fileExists("build.sbt"):
+		return jvmSbt
+	case pt == projectScala:
+		return jvmSbt
+	default:
+		return jvmGradle
+	}
+}
+
+func (r *Runner) jvmCommand(pt projectType, gradleTask, mavenGoal, sbtTask string) []string {
+	switch r.jvmBuildTool(pt) {
+	case jvmMaven:
+		return []string{"mvn", "-q", mavenGoal}
+	case jvmSbt:
+		return []string{"sbt", sbtTask}
+	default:
+		return r.gradleCommand(gradleTask)
+	}
+}
+
 func (r *Runner) fileExists(name string) bool {
-	_, err := os.Stat(filepath.Join(r.path, name))
+	_, err := r.stat(filepath.Join(r.path, name))
 	return err == nil
 }
 
@@ -169,7 +466,26 @@ func (r *Runner) readPackageJSON() string {
 	return string(content)
 }
 
+func (r *Runner) overrideCommand(override string) []string {
+	if r.config.Shell {
+		return shellCommand(override)
+	}
+
+	return strings.Fields(override)
+}
+
+func shellCommand(command string) []string {
+	if runtime.GOOS == "windows" {
+		return []string{"cmd", "/c", command}
+	}
+	return []string{"sh", "-c", command}
+}
+
 func (r *Runner) getTestCommand(pt projectType) []string {
+	if r.config.TestCommand != "" {
+		return r.overrideCommand(r.config.TestCommand)
+	}
+
 	switch pt {
 	case projectPython:
 		if r.fileExists("pytest.ini") || r.fileExists("pyproject.toml") {
@@ -179,39 +495,151 @@ func (r *Runner) getTestCommand(pt projectType) []string {
 	case projectJavaScript, projectTypeScript:
 		return []string{"npm", "test"}
 	case projectGo:
-		return []string{"go", "test", "./..."}
+		cmd := []string{"go", "test"}
+		if r.config.PerTestTimeout > 0 {
+			cmd = append(cmd, "-json")
+		}
+		if r.wantsCoverProfile() {
+			cmd = append(cmd, r.coverProfileArg())
+		}
+		return append(cmd, "./...")
+	case projectJava, projectKotlin, projectScala:
+		return r.jvmCommand(pt, "test", "test", "test")
 	default:
+		return r.specCommand(pt, func(spec ProjectSpec) []string { return spec.TestCommand })
+	}
+}
+
+func (r *Runner) specCommand(pt projectType, pick func(ProjectSpec) []string) []string {
+	spec, ok := registeredSpec(pt)
+	if !ok {
 		return nil
 	}
+	return pick(spec)
 }
 
 func (r *Runner) getLintCommand(pt projectType) []string {
+	if r.config.LintCommand != "" {
+		return r.overrideCommand(r.config.LintCommand)
+	}
+
 	switch pt {
 	case projectPython:
-		if _, err := exec.LookPath("ruff"); err == nil {
+		if _, err := r.lookPath("ruff"); err == nil {
 			return []string{"ruff", "check", "."}
 		}
-		if _, err := exec.LookPath("flake8"); err == nil {
+		if _, err := r.lookPath("flake8"); err == nil {
 			return []string{"flake8", "."}
 		}
 		return nil
 	case projectJavaScript, projectTypeScript:
 		return []string{"npm", "run", "lint"}
 	case projectGo:
-		if _, err := exec.LookPath("golangci-lint"); err == nil {
+		if _, err := r.lookPath("golangci-lint"); err == nil {
 			return []string{"golangci-lint", "run"}
 		}
 		return []string{"go", "vet", "./..."}
+	case projectScala:
+		var cmd []string
+		if r.fileExists(".scalafmt.conf") {
+			cmd = append(cmd, "scalafmtCheckAll")
+		}
+		if r.fileExists(".scalafix.conf") {
+			cmd = append(cmd, "scalafixAll --check")
+		}
+		if len(cmd) == 0 {
+			return nil
+		}
+		return append([]string{"sbt"}, cmd...)
 	default:
-		return nil
+		return r.specCommand(pt, func(spec ProjectSpec) []string { return spec.LintCommand })
+	}
+}
+
+func (r *Runner) getLintCommandForFiles(pt projectType, files []string) ([]string, bool) {
+	if r.config.LintCommand != "" {
+		return nil, false
 	}
+
+	switch pt {
+	case projectPython:
+		var cmd []string
+		if _, err := r.lookPath("ruff"); err == nil {
+			cmd = []string{"ruff", "check"}
+		} else if _, err := r.lookPath("flake8"); err == nil {
+			cmd = []string{"flake8"}
+		} else {
+			return nil, false
+		}
+		targets := filterByExtension(files, ".py")
+		if len(targets) == 0 {
+			return nil, true
+		}
+		return append(cmd, targets...), true
+	case projectJavaScript, projectTypeScript:
+		targets := filterByExtension(files, ".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx")
+		if len(targets) == 0 {
+			return nil, true
+		}
+		return append([]string{"npx", "eslint"}, targets...), true
+	case projectGo:
+		dirs := goPackageDirs(files)
+		if len(dirs) == 0 {
+			return nil, true
+		}
+		if _, err := r.lookPath("golangci-lint"); err == nil {
+			return append([]string{"golangci-lint", "run"}, dirs...), true
+		}
+		return append([]string{"go", "vet"}, dirs...), true
+	default:
+		return nil, false
+	}
+}
+
+func filterByExtension(files []string, exts ...string) []string {
+	var matched []string
+	for _, f := range files {
+		ext := strings.ToLower(filepath.Ext(f))
+		for _, e := range exts {
+			if ext == e {
+				matched = append(matched, f)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+func goPackageDirs(files []string) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, f := range filterByExtension(files, ".go") {
+		dir := "./" + filepath.ToSlash(filepath.Dir(f))
+		if dir == "./." {
+			dir = "."
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+func (r *Runner) parseLintOutput(output string, pt projectType) []models.LintIssue {
+	return r.parseLintReader(strings.NewReader(output), pt)
 }
 
-func (r *Runner) parseLintOutput(output string, pt projectType) []LintIssue {
-	var issues []LintIssue
+func (r *Runner) parseLintReader(reader io.Reader, pt projectType) []models.LintIssue {
+	var issues []models.LintIssue
+	currentFile := ""
 
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
+	br := bufio.NewReaderSize(reader, 64*1024)
+	for {
+		line, err := readLintLine(br)
+		if err != nil {
+			break
+		}
 		if line == "" {
 			continue
 		}
@@ -221,15 +649,64 @@ func (r *Runner) parseLintOutput(output string, pt projectType) []LintIssue {
 			issues = append(issues, r.parsePythonLintLine(line)...)
 		case projectGo:
 			issues = append(issues, r.parseGoLintLine(line)...)
+		case projectScala:
+			issues = append(issues, r.parseScalaLintLine(line)...)
 		case projectJavaScript, projectTypeScript:
-			issues = append(issues, r.parseJSLintLine(line)...)
+			if isJSLintFileHeader(line) {
+				currentFile = strings.TrimSpace(line)
+				continue
+			}
+			parsed := r.parseJSLintLine(line)
+			for i := range parsed {
+				if parsed[i].File == "" {
+					parsed[i].File = currentFile
+				}
+			}
+			issues = append(issues, parsed...)
 		}
 	}
 
 	return issues
 }
 
-func (r *Runner) parsePythonLintLine(line string) []LintIssue {
+const maxLintLineBytes = 1024 * 1024
+
+// readLintLine returns the next line without its terminator. Lines longer
+// than maxLintLineBytes (e.g. minified JS echoed by eslint) come back empty so
+// the caller skips them instead of giving up on the rest of the output.
+func readLintLine(br *bufio.Reader) (string, error) {
+	var buf []byte
+	oversized := false
+	for {
+		chunk, isPrefix, err := br.ReadLine()
+		if err != nil {
+			if err == io.EOF && (len(buf) > 0 || oversized) {
+				break
+			}
+			return "", err
+		}
+		if !oversized {
+			if len(buf)+len(chunk) > maxLintLineBytes {
+				oversized = true
+				buf = nil
+			} else {
+				buf = append(buf, chunk...)
+			}
+		}
+		if !isPrefix {
+			break
+		}
+	}
+	return string(buf), nil
+}
+
+var (
+	pythonRuleRegex    = regexp.MustCompile(`^(?:\d+:\s*)?([A-Z]+[0-9]+)\b`)
+	golangciRegex      = regexp.MustCompile(`\(([a-z0-9-]+)\)$`)
+	eslintStylishRegex = regexp.MustCompile(`^\s+(\d+):(\d+)\s+(error|warning)\s+(.+?)\s{2,}(\S+)$`)
+)
+
+func (r *Runner) parsePythonLintLine(line string) []models.LintIssue {
 	parts := strings.Split(line, ":")
 	if len(parts) < 3 {
 		return nil
@@ -246,15 +723,38 @@ func (r *Runner) parsePythonLintLine(line string) []LintIssue {
 		message = strings.TrimSpace(parts[2])
 	}
 
-	return []LintIssue{{
+	issue := models.LintIssue{
 		File:     file,
 		Line:     lineNum,
 		Message:  message,
 		Severity: "warning",
-	}}
+	}
+	if matches := pythonRuleRegex.FindStringSubmatch(message); matches != nil {
+		issue.Rule = matches[1]
+		issue.DocURL = ruleDocURL("ruff", issue.Rule)
+	}
+	if isPythonErrorRule(issue.Rule) || strings.HasPrefix(message, "SyntaxError") {
+		issue.Severity = "error"
+	}
+
+	return []models.LintIssue{issue}
+}
+
+// pythonErrorRulePrefixes are the flake8/ruff codes for code that cannot run:
+// syntax and IO errors, invalid comparisons, misplaced statements and
+// undefined names.
+var pythonErrorRulePrefixes = []string{"E9", "F63", "F7", "F82"}
+
+func isPythonErrorRule(rule string) bool {
+	for _, prefix := range pythonErrorRulePrefixes {
+		if strings.HasPrefix(rule, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
-func (r *Runner) parseGoLintLine(line string) []LintIssue {
+func (r *Runner) parseGoLintLine(line string) []models.LintIssue {
 	parts := strings.Split(line, ":")
 	if len(parts) < 3 {
 		return nil
@@ -271,32 +771,266 @@ func (r *Runner) parseGoLintLine(line string) []LintIssue {
 		message = strings.TrimSpace(parts[2])
 	}
 
-	return []LintIssue{{
+	issue := models.LintIssue{
 		File:     file,
 		Line:     lineNum,
 		Message:  message,
 		Severity: "warning",
-	}}
+	}
+	if matches := golangciRegex.FindStringSubmatch(message); matches != nil {
+		issue.Rule = matches[1]
+		issue.DocURL = ruleDocURL("golangci-lint", issue.Rule)
+	}
+	if issue.Rule == "typecheck" {
+		issue.Severity = "error"
+	}
+
+	return []models.LintIssue{issue}
+}
+
+var (
+	scalafixRegex = regexp.MustCompile(`^(?:\[(?:error|warn)\]\s+)?(.+?\.(?:scala|sbt)):(\d+):(\d+):\s*(error|warning|info):\s*(?:\[(\w+)\]\s*)?(.*)$`)
+	scalafmtRegex = regexp.MustCompile(`^(?:\[(?:error|warn)\]\s+)?(.+?\.(?:scala|sbt)) isn't formatted properly!?$`)
+)
+
+func (r *Runner) parseScalaLintLine(line string) []models.LintIssue {
+	if matches := scalafixRegex.FindStringSubmatch(line); matches != nil {
+		lineNum, column := 0, 0
+		fmt.Sscanf(matches[2], "%d", &lineNum)
+		fmt.Sscanf(matches[3], "%d", &column)
+		return []models.LintIssue{{
+			File:     matches[1],
+			Line:     lineNum,
+			Column:   column,
+			Message:  strings.TrimSpace(matches[6]),
+			Severity: matches[4],
+			Rule:     matches[5],
+		}}
+	}
+
+	if matches := scalafmtRegex.FindStringSubmatch(line); matches != nil {
+		return []models.LintIssue{{
+			File:     matches[1],
+			Message:  "file is not formatted according to .scalafmt.conf",
+			Severity: "warning",
+			Rule:     "scalafmt",
+		}}
+	}
+
+	return nil
 }
 
-func (r *Runner) parseJSLintLine(line string) []LintIssue {
-	return []LintIssue{{
+func (r *Runner) parseJSLintLine(line string) []models.LintIssue {
+	if matches := eslintStylishRegex.FindStringSubmatch(line); matches != nil {
+		issue := models.LintIssue{
+			Message:  strings.TrimSpace(matches[4]),
+			Severity: matches[3],
+			Rule:     matches[5],
+		}
+		fmt.Sscanf(matches[1], "%d", &issue.Line)
+		fmt.Sscanf(matches[2], "%d", &issue.Column)
+		issue.DocURL = ruleDocURL("eslint", issue.Rule)
+		return []models.LintIssue{issue}
+	}
+
+	return []models.LintIssue{{
 		Message:  line,
 		Severity: "warning",
 	}}
 }
 
+func isJSLintFileHeader(line string) bool {
+	if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+		return false
+	}
+	for _, ext := range []string{".js", ".jsx", ".ts", ".tsx", ".mjs", ".cjs"} {
+		if strings.HasSuffix(line, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+func ruleDocURL(linter, rule string) string {
+	if rule == "" {
+		return ""
+	}
+
+	switch linter {
+	case "ruff":
+		return "https://docs.astral.sh/ruff/rules/?q=" + rule
+	case "eslint":
+		if strings.Contains(rule, "/") {
+			return ""
+		}
+		return "https://eslint.org/docs/latest/rules/" + rule
+	case "golangci-lint":
+		return "https://golangci-lint.run/usage/linters/#" + rule
+	default:
+		return ""
+	}
+}
+
 func (r *Runner) Build() (*TestResult, error) {
 	pt := r.detectProjectType()
 
+	if !r.buildCache {
+		return r.build(pt)
+	}
+
+	inputHash, err := r.buildInputHash()
+	if err != nil {
+		return nil, err
+	}
+	if cache := r.loadBuildCache(); cache != nil && cache.InputHash == inputHash {
+		return &TestResult{
+			Success: true,
+			Output:  fmt.Sprintf("Build inputs unchanged since %s; skipping build", cache.BuiltAt.Format(time.RFC3339)),
+			Cached:  true,
+		}, nil
+	}
+
+	result, err := r.build(pt)
+	if err != nil {
+		return nil, err
+	}
+	if result.Success {
+		if err := r.saveBuildCache(inputHash); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (r *Runner) getBuildCommand(pt projectType) []string {
+	if r.config.BuildCommand != "" {
+		return r.overrideCommand(r.config.BuildCommand)
+	}
+
 	switch pt {
 	case projectGo:
-		return r.execute([]string{"go", "build", "./..."})
+		return []string{"go", "build", "./..."}
 	case projectJavaScript, projectTypeScript:
-		return r.execute([]string{"npm", "run", "build"})
+		return []string{"npm", "run", "build"}
+	case projectJava, projectKotlin, projectScala:
+		return r.jvmCommand(pt, "build", "compile", "compile")
 	case projectPython:
-		return &TestResult{Success: true, Output: "Python does not require build step"}, nil
+		return nil
 	default:
+		return r.specCommand(pt, func(spec ProjectSpec) []string { return spec.BuildCommand })
+	}
+}
+
+func (r *Runner) build(pt projectType) (*TestResult, error) {
+	cmd := r.getBuildCommand(pt)
+	if cmd == nil {
+		if pt == projectPython {
+			return &TestResult{Success: true, Output: "Python does not require build step"}, nil
+		}
 		return &TestResult{Success: true, Output: "No build step required"}, nil
 	}
+
+	result, err := r.execute(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if pt == projectTypeScript && r.config.BuildCommand == "" {
+		result.BuildErrors = r.parseTSCOutput(result.Output)
+	}
+	return result, nil
+}
+
+var (
+	tscDiagnosticRegex = regexp.MustCompile(`^(.+?)\((\d+),(\d+)\):\s+(error|warning)\s+(TS\d+):\s*(.*)$`)
+	tscPrettyRegex     = regexp.MustCompile(`^(.+?):(\d+):(\d+)\s+-\s+(error|warning)\s+(TS\d+):\s*(.*)$`)
+	tscGlobalRegex     = regexp.MustCompile(`^(error|warning)\s+(TS\d+):\s*(.*)$`)
+)
+
+func (r *Runner) parseTSCOutput(output string) []BuildError {
+	var diagnostics []BuildError
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if matches := tscDiagnosticRegex.FindStringSubmatch(line); matches != nil {
+			diagnostics = append(diagnostics, newTSCBuildError(matches[1], matches[2], matches[3], matches[4], matches[5], matches[6]))
+			continue
+		}
+
+		if matches := tscPrettyRegex.FindStringSubmatch(line); matches != nil {
+			diagnostics = append(diagnostics, newTSCBuildError(matches[1], matches[2], matches[3], matches[4], matches[5], matches[6]))
+			continue
+		}
+
+		if matches := tscGlobalRegex.FindStringSubmatch(line); matches != nil {
+			diagnostics = append(diagnostics, BuildError{
+				Code:     matches[2],
+				Message:  strings.TrimSpace(matches[3]),
+				Severity: matches[1],
+			})
+		}
+	}
+
+	return diagnostics
+}
+
+func newTSCBuildError(file, line, column, severity, code, message string) BuildError {
+	be := BuildError{
+		File:     strings.TrimSpace(file),
+		Code:     code,
+		Message:  strings.TrimSpace(message),
+		Severity: severity,
+	}
+	fmt.Sscanf(line, "%d", &be.Line)
+	fmt.Sscanf(column, "%d", &be.Column)
+	return be
+}
+
+type ContainerConfig struct {
+	Image  string
+	Mounts []string
+}
+
+func (r *Runner) RunTestsInContainer(cfg ContainerConfig) (*TestResult, error) {
+	if cfg.Image == "" {
+		return nil, fmt.Errorf("container image is required")
+	}
+
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil, fmt.Errorf("docker is not available: %w", err)
+	}
+
+	testCmd := r.getTestCommand(r.detectProjectType())
+	if testCmd == nil {
+		return &TestResult{
+			Success: true,
+			Output:  "No test command detected for this project type",
+		}, nil
+	}
+
+	cmd, err := r.containerCommand(cfg, testCmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.execute(cmd)
+}
+
+func (r *Runner) containerCommand(cfg ContainerConfig, command []string) ([]string, error) {
+	absPath, err := filepath.Abs(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	cmd := []string{"docker", "run", "--rm", "-v", absPath + ":/work", "-w", "/work"}
+	for _, mount := range cfg.Mounts {
+		cmd = append(cmd, "-v", mount)
+	}
+	cmd = append(cmd, cfg.Image)
+	cmd = append(cmd, command...)
+
+	return cmd, nil
 }
@@ -1,10 +1,18 @@
 package runner
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
 )
 
 func TestNew(t *testing.T) {
@@ -64,6 +72,31 @@ func TestDetectProjectType(t *testing.T) {
 			files:    map[string]string{"package.json": `{"devDependencies": {"typescript": "^4.0.0"}}`},
 			expected: projectTypeScript,
 		},
+		{
+			name:     "scala project",
+			files:    map[string]string{"build.sbt": `scalaVersion := "3.3.1"`},
+			expected: projectScala,
+		},
+		{
+			name:     "kotlin gradle project",
+			files:    map[string]string{"build.gradle.kts": `plugins { kotlin("jvm") version "1.9.0" }`},
+			expected: projectKotlin,
+		},
+		{
+			name:     "kotlin sources",
+			files:    map[string]string{"Main.kt": "fun main() {}"},
+			expected: projectKotlin,
+		},
+		{
+			name:     "java maven project",
+			files:    map[string]string{"pom.xml": "<project/>"},
+			expected: projectJava,
+		},
+		{
+			name:     "java gradle project",
+			files:    map[string]string{"build.gradle": "plugins { id 'java' }"},
+			expected: projectJava,
+		},
 		{
 			name:     "unknown project",
 			files:    map[string]string{},
@@ -284,12 +317,12 @@ func TestParsePythonLintLine(t *testing.T) {
 	tests := []struct {
 		name     string
 		line     string
-		expected LintIssue
+		expected models.LintIssue
 	}{
 		{
 			name: "standard format",
 			line: "file.py:10: E501 line too long",
-			expected: LintIssue{
+			expected: models.LintIssue{
 				File:     "file.py",
 				Line:     10,
 				Message:  " E501 line too long",
@@ -299,13 +332,31 @@ func TestParsePythonLintLine(t *testing.T) {
 		{
 			name: "with column",
 			line: "test.py:5:3: invalid syntax",
-			expected: LintIssue{
+			expected: models.LintIssue{
 				File:     "test.py",
 				Line:     5,
 				Message:  "3: invalid syntax",
 				Severity: "warning",
 			},
 		},
+		{
+			name: "undefined name is an error",
+			line: "app.py:3:5: F821 Undefined name `helper`",
+			expected: models.LintIssue{
+				File:     "app.py",
+				Line:     3,
+				Severity: "error",
+			},
+		},
+		{
+			name: "syntax error is an error",
+			line: "app.py:9:1: E999 SyntaxError: invalid syntax",
+			expected: models.LintIssue{
+				File:     "app.py",
+				Line:     9,
+				Severity: "error",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -323,6 +374,9 @@ func TestParsePythonLintLine(t *testing.T) {
 			if result[0].Line != tt.expected.Line {
 				t.Errorf("expected line %d, got %d", tt.expected.Line, result[0].Line)
 			}
+			if result[0].Severity != tt.expected.Severity {
+				t.Errorf("expected severity %s, got %s", tt.expected.Severity, result[0].Severity)
+			}
 		})
 	}
 }
@@ -331,12 +385,12 @@ func TestParseGoLintLine(t *testing.T) {
 	tests := []struct {
 		name     string
 		line     string
-		expected LintIssue
+		expected models.LintIssue
 	}{
 		{
 			name: "standard format",
 			line: "file.go:10: unused variable",
-			expected: LintIssue{
+			expected: models.LintIssue{
 				File:     "file.go",
 				Line:     10,
 				Message:  " unused variable",
@@ -346,13 +400,22 @@ func TestParseGoLintLine(t *testing.T) {
 		{
 			name: "with column",
 			line: "test.go:5:3: syntax error",
-			expected: LintIssue{
+			expected: models.LintIssue{
 				File:     "test.go",
 				Line:     5,
 				Message:  "3: syntax error",
 				Severity: "warning",
 			},
 		},
+		{
+			name: "typecheck is an error",
+			line: "main.go:3:2: undefined: helper (typecheck)",
+			expected: models.LintIssue{
+				File:     "main.go",
+				Line:     3,
+				Severity: "error",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -370,6 +433,9 @@ func TestParseGoLintLine(t *testing.T) {
 			if result[0].Line != tt.expected.Line {
 				t.Errorf("expected line %d, got %d", tt.expected.Line, result[0].Line)
 			}
+			if result[0].Severity != tt.expected.Severity {
+				t.Errorf("expected severity %s, got %s", tt.expected.Severity, result[0].Severity)
+			}
 		})
 	}
 }
@@ -447,3 +513,602 @@ func TestBuild(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTSCOutput(t *testing.T) {
+	output := `> tsc -p .
+
+src/index.ts(10,5): error TS2345: Argument of type 'string' is not assignable to parameter of type 'number'.
+src/util.ts:3:12 - error TS2304: Cannot find name 'foo'.
+lib/legacy.ts(1,1): warning TS6133: 'x' is declared but its value is never read.
+error TS5023: Unknown compiler option 'strictest'.
+
+Found 3 errors.`
+
+	r := New("/tmp")
+	diagnostics := r.parseTSCOutput(output)
+
+	expected := []BuildError{
+		{File: "src/index.ts", Line: 10, Column: 5, Code: "TS2345", Message: "Argument of type 'string' is not assignable to parameter of type 'number'.", Severity: "error"},
+		{File: "src/util.ts", Line: 3, Column: 12, Code: "TS2304", Message: "Cannot find name 'foo'.", Severity: "error"},
+		{File: "lib/legacy.ts", Line: 1, Column: 1, Code: "TS6133", Message: "'x' is declared but its value is never read.", Severity: "warning"},
+		{Code: "TS5023", Message: "Unknown compiler option 'strictest'.", Severity: "error"},
+	}
+
+	if len(diagnostics) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %d: %+v", len(expected), len(diagnostics), diagnostics)
+	}
+
+	for i, want := range expected {
+		if diagnostics[i] != want {
+			t.Errorf("diagnostic %d: expected %+v, got %+v", i, want, diagnostics[i])
+		}
+	}
+}
+
+func TestShellMode(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	t.Run("shell mode runs pipes", func(t *testing.T) {
+		r := New(t.TempDir())
+		r.SetConfig(RunnerConfig{
+			TestCommand: "echo hello | tr a-z A-Z",
+			Shell:       true,
+		})
+
+		result, err := r.RunTests()
+		if err != nil {
+			t.Fatalf("RunTests returned error: %v", err)
+		}
+		if !result.Success {
+			t.Fatalf("expected success, got output %q", result.Output)
+		}
+		if strings.TrimSpace(result.Output) != "HELLO" {
+			t.Errorf("expected piped output HELLO, got %q", result.Output)
+		}
+	})
+
+	t.Run("direct mode splits args literally", func(t *testing.T) {
+		r := New(t.TempDir())
+		r.SetConfig(RunnerConfig{
+			TestCommand: "echo hello | tr a-z A-Z",
+		})
+
+		cmd := r.getTestCommand(projectUnknown)
+		expected := []string{"echo", "hello", "|", "tr", "a-z", "A-Z"}
+		if strings.Join(cmd, "\x00") != strings.Join(expected, "\x00") {
+			t.Errorf("expected %v, got %v", expected, cmd)
+		}
+
+		result, err := r.RunTests()
+		if err != nil {
+			t.Fatalf("RunTests returned error: %v", err)
+		}
+		if strings.TrimSpace(result.Output) != "hello | tr a-z A-Z" {
+			t.Errorf("expected literal args, got %q", result.Output)
+		}
+	})
+
+	t.Run("shell mode wraps command", func(t *testing.T) {
+		r := New(t.TempDir())
+		r.SetConfig(RunnerConfig{LintCommand: "FOO=bar go vet ./..."})
+		r.SetShell(true)
+
+		cmd := r.getLintCommand(projectGo)
+		if len(cmd) != 3 || cmd[2] != "FOO=bar go vet ./..." {
+			t.Errorf("expected shell-wrapped command, got %v", cmd)
+		}
+	})
+}
+
+func TestLintRuleTagging(t *testing.T) {
+	r := New("/tmp")
+
+	t.Run("ruff rule", func(t *testing.T) {
+		issues := r.parseLintOutput("app.py:10:80: E501 Line too long (120 > 79)", projectPython)
+		if len(issues) != 1 {
+			t.Fatalf("expected 1 issue, got %d", len(issues))
+		}
+		if issues[0].Rule != "E501" {
+			t.Errorf("expected rule E501, got %q", issues[0].Rule)
+		}
+		if issues[0].DocURL != "https://docs.astral.sh/ruff/rules/?q=E501" {
+			t.Errorf("unexpected doc URL %q", issues[0].DocURL)
+		}
+	})
+
+	t.Run("eslint rule", func(t *testing.T) {
+		output := "/repo/src/index.js\n  3:7  error  'unused' is assigned a value but never used  no-unused-vars\n\n✖ 1 problem (1 error, 0 warnings)"
+		issues := r.parseLintOutput(output, projectJavaScript)
+		if len(issues) == 0 {
+			t.Fatal("expected at least one issue")
+		}
+		issue := issues[0]
+		if issue.Rule != "no-unused-vars" {
+			t.Errorf("expected rule no-unused-vars, got %q", issue.Rule)
+		}
+		if issue.DocURL != "https://eslint.org/docs/latest/rules/no-unused-vars" {
+			t.Errorf("unexpected doc URL %q", issue.DocURL)
+		}
+		if issue.File != "/repo/src/index.js" || issue.Line != 3 || issue.Column != 7 {
+			t.Errorf("unexpected location %s:%d:%d", issue.File, issue.Line, issue.Column)
+		}
+		if issue.Severity != "error" {
+			t.Errorf("expected severity error, got %s", issue.Severity)
+		}
+	})
+
+	t.Run("golangci linter", func(t *testing.T) {
+		issues := r.parseLintOutput("main.go:12:2: ineffectual assignment to err (ineffassign)", projectGo)
+		if len(issues) != 1 || issues[0].Rule != "ineffassign" {
+			t.Fatalf("expected ineffassign rule, got %+v", issues)
+		}
+		if issues[0].DocURL != "https://golangci-lint.run/usage/linters/#ineffassign" {
+			t.Errorf("unexpected doc URL %q", issues[0].DocURL)
+		}
+	})
+
+	t.Run("go vet has no rule", func(t *testing.T) {
+		issues := r.parseLintOutput("main.go:12:2: unreachable code", projectGo)
+		if len(issues) != 1 || issues[0].Rule != "" || issues[0].DocURL != "" {
+			t.Errorf("expected no rule for go vet output, got %+v", issues)
+		}
+	})
+}
+
+func TestContainerCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test"), 0644); err != nil {
+		t.Fatalf("failed to create go.mod: %v", err)
+	}
+
+	r := New(tmpDir)
+	cfg := ContainerConfig{
+		Image:  "golang:1.22",
+		Mounts: []string{"/tmp/cache:/root/.cache"},
+	}
+
+	cmd, err := r.containerCommand(cfg, r.getTestCommand(r.detectProjectType()))
+	if err != nil {
+		t.Fatalf("containerCommand returned error: %v", err)
+	}
+
+	expected := []string{
+		"docker", "run", "--rm",
+		"-v", tmpDir + ":/work",
+		"-w", "/work",
+		"-v", "/tmp/cache:/root/.cache",
+		"golang:1.22",
+		"go", "test", "./...",
+	}
+	if strings.Join(cmd, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected %v, got %v", expected, cmd)
+	}
+}
+
+func TestRunTestsInContainer(t *testing.T) {
+	t.Run("missing image", func(t *testing.T) {
+		r := New(t.TempDir())
+		if _, err := r.RunTestsInContainer(ContainerConfig{}); err == nil {
+			t.Error("expected error without image")
+		}
+	})
+
+	t.Run("docker unavailable", func(t *testing.T) {
+		t.Setenv("PATH", "")
+
+		r := New(t.TempDir())
+		_, err := r.RunTestsInContainer(ContainerConfig{Image: "alpine"})
+		if err == nil || !strings.Contains(err.Error(), "docker is not available") {
+			t.Errorf("expected docker unavailable error, got %v", err)
+		}
+	})
+
+	t.Run("with docker", func(t *testing.T) {
+		if _, err := exec.LookPath("docker"); err != nil {
+			t.Skip("docker not available")
+		}
+
+		r := New(t.TempDir())
+		result, err := r.RunTestsInContainer(ContainerConfig{Image: "alpine"})
+		if err != nil {
+			t.Fatalf("RunTestsInContainer returned error: %v", err)
+		}
+		if !result.Success {
+			t.Error("expected success when no test command is detected")
+		}
+	})
+}
+
+func TestDetectProjectTypeCaching(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	r := New(tmpDir)
+	var mu sync.Mutex
+	statCalls := 0
+	r.stat = func(name string) (os.FileInfo, error) {
+		mu.Lock()
+		statCalls++
+		mu.Unlock()
+		return os.Stat(name)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if pt := r.detectProjectType(); pt != projectUnknown {
+				t.Errorf("expected unknown project, got %s", pt)
+			}
+		}()
+	}
+	wg.Wait()
+
+	first := statCalls
+	if first == 0 {
+		t.Fatal("expected detection to stat the filesystem")
+	}
+
+	r.RunTests()
+	r.RunLint()
+	r.Build()
+	if statCalls != first {
+		t.Errorf("expected cached detection, stat calls went from %d to %d", first, statCalls)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module test"), 0644); err != nil {
+		t.Fatalf("failed to create go.mod: %v", err)
+	}
+	if pt := r.detectProjectType(); pt != projectUnknown {
+		t.Errorf("expected stale cached result before reset, got %s", pt)
+	}
+
+	r.ResetDetection()
+	if pt := r.detectProjectType(); pt != projectGo {
+		t.Errorf("expected go project after reset, got %s", pt)
+	}
+	if statCalls == first {
+		t.Error("expected reset to trigger re-detection")
+	}
+}
+
+func TestParseLintReader(t *testing.T) {
+	const count = 50000
+
+	pr, pw := io.Pipe()
+	go func() {
+		for i := 0; i < count; i++ {
+			fmt.Fprintf(pw, "pkg/file%d.py:%d:1: E501 Line too long\n", i%100, i+1)
+		}
+		pw.Close()
+	}()
+
+	r := New("/tmp")
+	issues := r.parseLintReader(pr, projectPython)
+
+	if len(issues) != count {
+		t.Fatalf("expected %d issues, got %d", count, len(issues))
+	}
+	last := issues[count-1]
+	if last.File != "pkg/file99.py" || last.Line != count || last.Rule != "E501" {
+		t.Errorf("unexpected last issue: %+v", last)
+	}
+
+	if got := r.parseLintOutput("a.py:1:1: F401 unused\n\nb.py:2:1: E302 blank lines\n", projectPython); len(got) != 2 {
+		t.Errorf("expected string wrapper to parse 2 issues, got %d", len(got))
+	}
+
+	t.Run("oversized line is skipped", func(t *testing.T) {
+		output := "src/a.js\n" +
+			"  1:1  error  Unexpected var  no-var\n" +
+			"  2:1  error  " + strings.Repeat("x", 2*maxLintLineBytes) + "  max-len\n" +
+			"src/b.js\n" +
+			"  3:5  warning  Missing semicolon  semi"
+
+		issues := r.parseLintOutput(output, projectJavaScript)
+		if len(issues) != 2 {
+			t.Fatalf("expected 2 issues around the oversized line, got %d: %+v", len(issues), issues)
+		}
+		if issues[1].File != "src/b.js" || issues[1].Line != 3 || issues[1].Rule != "semi" {
+			t.Errorf("unexpected issue after oversized line: %+v", issues[1])
+		}
+	})
+}
+
+func TestGetLintCommandForFiles(t *testing.T) {
+	found := func(tools ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, tool := range tools {
+				if tool == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", exec.ErrNotFound
+		}
+	}
+
+	files := []string{"app/main.py", "README.md", "web/index.ts", "web/util.js", "cmd/tool/main.go", "cmd/tool/flags.go", "root.go"}
+
+	tests := []struct {
+		name     string
+		pt       projectType
+		tools    []string
+		config   RunnerConfig
+		expected []string
+		ok       bool
+	}{
+		{
+			name:     "ruff",
+			pt:       projectPython,
+			tools:    []string{"ruff", "flake8"},
+			expected: []string{"ruff", "check", "app/main.py"},
+			ok:       true,
+		},
+		{
+			name:     "flake8 fallback",
+			pt:       projectPython,
+			tools:    []string{"flake8"},
+			expected: []string{"flake8", "app/main.py"},
+			ok:       true,
+		},
+		{
+			name: "no python linter",
+			pt:   projectPython,
+			ok:   false,
+		},
+		{
+			name:     "eslint",
+			pt:       projectTypeScript,
+			expected: []string{"npx", "eslint", "web/index.ts", "web/util.js"},
+			ok:       true,
+		},
+		{
+			name:     "golangci-lint package dirs",
+			pt:       projectGo,
+			tools:    []string{"golangci-lint"},
+			expected: []string{"golangci-lint", "run", "./cmd/tool", "."},
+			ok:       true,
+		},
+		{
+			name:     "go vet package dirs",
+			pt:       projectGo,
+			expected: []string{"go", "vet", "./cmd/tool", "."},
+			ok:       true,
+		},
+		{
+			name:   "override falls back to whole repo",
+			pt:     projectPython,
+			tools:  []string{"ruff"},
+			config: RunnerConfig{LintCommand: "make lint"},
+			ok:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(t.TempDir())
+			r.lookPath = found(tt.tools...)
+			r.SetConfig(tt.config)
+
+			cmd, ok := r.getLintCommandForFiles(tt.pt, files)
+			if ok != tt.ok {
+				t.Fatalf("expected ok %v, got %v", tt.ok, ok)
+			}
+			if strings.Join(cmd, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("expected %v, got %v", tt.expected, cmd)
+			}
+		})
+	}
+
+	t.Run("no matching files", func(t *testing.T) {
+		r := New(t.TempDir())
+		r.lookPath = found("ruff")
+
+		cmd, ok := r.getLintCommandForFiles(projectPython, []string{"README.md"})
+		if !ok || cmd != nil {
+			t.Errorf("expected nothing to lint, got %v (ok=%v)", cmd, ok)
+		}
+	})
+}
+
+func TestJVMCommands(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectedTest  []string
+		expectedBuild []string
+	}{
+		{
+			name:          "scala sbt",
+			files:         map[string]string{"build.sbt": ""},
+			expectedTest:  []string{"sbt", "test"},
+			expectedBuild: []string{"sbt", "compile"},
+		},
+		{
+			name:          "kotlin gradle wrapper",
+			files:         map[string]string{"build.gradle.kts": `kotlin("jvm")`, "gradlew": "#!/bin/sh"},
+			expectedTest:  []string{"./gradlew", "test"},
+			expectedBuild: []string{"./gradlew", "build"},
+		},
+		{
+			name:          "java maven",
+			files:         map[string]string{"pom.xml": "<project/>"},
+			expectedTest:  []string{"mvn", "-q", "test"},
+			expectedBuild: []string{"mvn", "-q", "compile"},
+		},
+		{
+			name:          "kotlin maven",
+			files:         map[string]string{"pom.xml": "<project/>", "src/main/kotlin/App.kt": "fun main() {}"},
+			expectedTest:  []string{"mvn", "-q", "test"},
+			expectedBuild: []string{"mvn", "-q", "compile"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(tmpDir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("failed to create dir for %s: %v", name, err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("failed to create %s: %v", name, err)
+				}
+			}
+
+			r := New(tmpDir)
+			pt := r.detectProjectType()
+
+			if got := r.getTestCommand(pt); strings.Join(got, " ") != strings.Join(tt.expectedTest, " ") {
+				t.Errorf("expected test command %v, got %v", tt.expectedTest, got)
+			}
+			if got := r.jvmCommand(pt, "build", "compile", "compile"); strings.Join(got, " ") != strings.Join(tt.expectedBuild, " ") {
+				t.Errorf("expected build command %v, got %v", tt.expectedBuild, got)
+			}
+		})
+	}
+}
+
+func TestParseScalaLintLine(t *testing.T) {
+	r := New(t.TempDir())
+
+	tests := []struct {
+		name     string
+		line     string
+		expected []models.LintIssue
+	}{
+		{
+			name: "scalafix",
+			line: "[error] src/main/scala/App.scala:12:5: error: [RemoveUnused] Unused import",
+			expected: []models.LintIssue{{
+				File: "src/main/scala/App.scala", Line: 12, Column: 5,
+				Message: "Unused import", Severity: "error", Rule: "RemoveUnused",
+			}},
+		},
+		{
+			name: "scalafmt",
+			line: "[warn] src/main/scala/App.scala isn't formatted properly!",
+			expected: []models.LintIssue{{
+				File: "src/main/scala/App.scala", Message: "file is not formatted according to .scalafmt.conf",
+				Severity: "warning", Rule: "scalafmt",
+			}},
+		},
+		{
+			name: "unrelated",
+			line: "[info] compiling 3 Scala sources",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := r.parseScalaLintLine(tt.line)
+			if len(issues) != len(tt.expected) {
+				t.Fatalf("expected %d issues, got %v", len(tt.expected), issues)
+			}
+			for i := range issues {
+				if issues[i] != tt.expected[i] {
+					t.Errorf("expected %+v, got %+v", tt.expected[i], issues[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPTYMode(t *testing.T) {
+	if _, err := os.Stat("/dev/ptmx"); err != nil {
+		t.Skip("pseudo-terminals not available")
+	}
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	check := "if [ -t 1 ]; then echo attached; else echo detached; fi"
+
+	t.Run("pipes by default", func(t *testing.T) {
+		r := New(t.TempDir())
+		r.SetConfig(RunnerConfig{TestCommand: check, Shell: true})
+
+		result, err := r.RunTests()
+		if err != nil {
+			t.Fatalf("RunTests returned error: %v", err)
+		}
+		if !strings.Contains(result.Output, "detached") {
+			t.Errorf("expected command to see pipes, got %q", result.Output)
+		}
+	})
+
+	t.Run("pty enabled", func(t *testing.T) {
+		r := New(t.TempDir())
+		r.SetConfig(RunnerConfig{TestCommand: check, Shell: true})
+		r.SetPTY(true)
+
+		result, err := r.RunTests()
+		if errors.Is(err, errPTYUnsupported) {
+			t.Skip("pty unsupported on this platform")
+		}
+		if err != nil {
+			t.Fatalf("RunTests returned error: %v", err)
+		}
+		if !strings.Contains(result.Output, "attached") || strings.Contains(result.Output, "detached") {
+			t.Errorf("expected command to see a terminal, got %q", result.Output)
+		}
+		if !result.Success {
+			t.Errorf("expected success, got exit code %d", result.ExitCode)
+		}
+	})
+}
+
+func TestExecuteTimeout(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("sleep not available")
+	}
+
+	tests := []struct {
+		name   string
+		config RunnerConfig
+	}{
+		{"direct", RunnerConfig{TestCommand: "sleep 10"}},
+		{"shell child", RunnerConfig{TestCommand: "sleep 10; echo done", Shell: true}},
+		{"pty", RunnerConfig{TestCommand: "sleep 10; echo done", Shell: true, PTY: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.config.PTY {
+				if _, err := os.Stat("/dev/ptmx"); err != nil {
+					t.Skip("pseudo-terminals not available")
+				}
+			}
+
+			r := New(t.TempDir())
+			r.SetConfig(tt.config)
+			r.SetTimeout(100 * time.Millisecond)
+
+			start := time.Now()
+			result, err := r.RunTests()
+			elapsed := time.Since(start)
+			if err != nil {
+				t.Fatalf("RunTests returned error: %v", err)
+			}
+
+			if elapsed > time.Second {
+				t.Errorf("expected command to be killed promptly, took %v", elapsed)
+			}
+			if result.Success {
+				t.Error("expected timed out run to fail")
+			}
+			if result.ExitCode == 0 {
+				t.Error("expected non-zero exit code")
+			}
+			if !strings.Contains(result.Output, "timed out after 100ms") {
+				t.Errorf("expected timeout note, got %q", result.Output)
+			}
+			if strings.Contains(result.Output, "done") {
+				t.Errorf("expected command to be stopped before finishing, got %q", result.Output)
+			}
+		})
+	}
+}
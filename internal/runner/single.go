@@ -0,0 +1,178 @@
+package runner
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var ErrSingleTestUnsupported = errors.New("running a single test is not supported")
+
+func (r *Runner) RunSingleTest(name string) (*TestResult, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("test name required")
+	}
+
+	cmd, err := r.getSingleTestCommand(r.detectProjectType(), name)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.execute(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Passed, result.Total, result.CountsMeasured = parseTestCounts(result.Output)
+	return result, nil
+}
+
+func (r *Runner) getSingleTestCommand(pt projectType, name string) ([]string, error) {
+	if r.config.TestCommand != "" {
+		return nil, fmt.Errorf("%w with a custom test command", ErrSingleTestUnsupported)
+	}
+
+	switch pt {
+	case projectGo:
+		return []string{"go", "test", "-run", "^" + regexp.QuoteMeta(name) + "$", "./..."}, nil
+	case projectPython:
+		if r.fileExists("pytest.ini") || r.fileExists("pyproject.toml") {
+			nodeIDs, err := r.pytestNodeIDs(name)
+			if err != nil {
+				return nil, err
+			}
+			return append([]string{"python", "-m", "pytest", "-q"}, nodeIDs...), nil
+		}
+		return []string{"python", "-m", "unittest", "discover", "-v", "-k", escapeFnmatch(name)}, nil
+	case projectJavaScript, projectTypeScript:
+		return []string{"npm", "test", "--", "-t", regexp.QuoteMeta(name)}, nil
+	case projectJava, projectKotlin:
+		if r.jvmBuildTool(pt) == jvmMaven {
+			return []string{"mvn", "-q", "test", "-Dtest=" + name}, nil
+		}
+		return append(r.gradleCommand("test"), "--tests", name), nil
+	default:
+		return nil, fmt.Errorf("%w for project type %s", ErrSingleTestUnsupported, pt)
+	}
+}
+
+var (
+	pytestClassRegex = regexp.MustCompile(`^class\s+(\w+)`)
+	pytestFuncRegex  = regexp.MustCompile(`^(\s*)(?:async\s+)?def\s+(\w+)\s*\(`)
+)
+
+// pytestNodeIDs turns a test name into pytest node IDs (path::name), which
+// select exactly that test; -k would treat the name as an expression. A name
+// that already contains "::" is used as is. Parametrized names such as
+// test_load[json] are matched by their function name.
+func (r *Runner) pytestNodeIDs(name string) ([]string, error) {
+	if strings.Contains(name, "::") {
+		return []string{name}, nil
+	}
+
+	funcName, params, _ := strings.Cut(name, "[")
+	if params != "" {
+		params = "[" + params
+	}
+
+	var nodeIDs []string
+	err := filepath.WalkDir(r.path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != r.path && pytestSkipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isPytestFile(d.Name()) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(r.path, path)
+		if err != nil {
+			return nil
+		}
+		for _, scope := range pytestTestScopes(path, funcName) {
+			nodeIDs = append(nodeIDs, filepath.ToSlash(rel)+"::"+scope+params)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for test %s: %w", name, err)
+	}
+	if len(nodeIDs) == 0 {
+		return nil, fmt.Errorf("test %s not found in any pytest file", name)
+	}
+
+	return nodeIDs, nil
+}
+
+var pytestSkipDirs = map[string]bool{
+	".git":          true,
+	".venv":         true,
+	"venv":          true,
+	"node_modules":  true,
+	"__pycache__":   true,
+	".pytest_cache": true,
+}
+
+func isPytestFile(name string) bool {
+	return strings.HasSuffix(name, ".py") && (strings.HasPrefix(name, "test_") || strings.HasSuffix(name, "_test.py"))
+}
+
+// pytestTestScopes returns "name" for a module-level test function and
+// "Class::name" for each test class method with that name.
+func pytestTestScopes(path, funcName string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var scopes []string
+	class := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := pytestClassRegex.FindStringSubmatch(line); m != nil {
+			class = m[1]
+			continue
+		}
+		if line != "" && line[0] != ' ' && line[0] != '\t' && line[0] != '#' && line[0] != '@' {
+			class = ""
+		}
+
+		m := pytestFuncRegex.FindStringSubmatch(line)
+		if m == nil || m[2] != funcName {
+			continue
+		}
+		switch {
+		case m[1] == "":
+			scopes = append(scopes, funcName)
+		case class != "":
+			scopes = append(scopes, class+"::"+funcName)
+		}
+	}
+
+	return scopes
+}
+
+func escapeFnmatch(name string) string {
+	var sb strings.Builder
+	for _, c := range name {
+		switch c {
+		case '*', '?', '[':
+			sb.WriteString("[" + string(c) + "]")
+		default:
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
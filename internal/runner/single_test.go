@@ -0,0 +1,107 @@
+package runner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const pytestSource = `import pytest
+
+
+def test_load():
+    assert True
+
+
+@pytest.mark.parametrize("fmt", ["json"])
+def test_save(fmt):
+    assert fmt
+
+
+class TestReader:
+    def test_load(self):
+        assert True
+
+
+def helper_test_load():
+    pass
+`
+
+func TestGetSingleTestCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		pt       projectType
+		test     string
+		expected string
+	}{
+		{"go", nil, projectGo, "TestParse", "go test -run ^TestParse$ ./..."},
+		{"go subtest with special characters", nil, projectGo, "TestParse/case(1)+x", `go test -run ^TestParse/case\(1\)\+x$ ./...`},
+		{"pytest", map[string]string{"pytest.ini": "", "tests/test_io.py": pytestSource}, projectPython, "test_load", "python -m pytest -q tests/test_io.py::test_load tests/test_io.py::TestReader::test_load"},
+		{"pytest parametrized", map[string]string{"pytest.ini": "", "tests/test_io.py": pytestSource}, projectPython, "test_save[json-1]", "python -m pytest -q tests/test_io.py::test_save[json-1]"},
+		{"pytest node id", map[string]string{"pyproject.toml": ""}, projectPython, "tests/test_io.py::test_load", "python -m pytest -q tests/test_io.py::test_load"},
+		{"unittest", nil, projectPython, "test_load*", "python -m unittest discover -v -k test_load[*]"},
+		{"jest", nil, projectTypeScript, "renders (empty) list?", `npm test -- -t renders \(empty\) list\?`},
+		{"maven", map[string]string{"pom.xml": ""}, projectJava, "UserTest#testSave", "mvn -q test -Dtest=UserTest#testSave"},
+		{"gradle", map[string]string{"build.gradle.kts": ""}, projectKotlin, "UserTest", "gradle test --tests UserTest"},
+		{"kotlin maven", map[string]string{"pom.xml": ""}, projectKotlin, "UserTest", "mvn -q test -Dtest=UserTest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(tmpDir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("failed to create directory for %s: %v", name, err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+
+			r := New(tmpDir)
+			cmd, err := r.getSingleTestCommand(tt.pt, tt.test)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := strings.Join(cmd, " "); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetSingleTestCommandPytestNotFound(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "pytest.ini"), nil, 0644); err != nil {
+		t.Fatalf("failed to write pytest.ini: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "test_io.py"), []byte(pytestSource), 0644); err != nil {
+		t.Fatalf("failed to write test_io.py: %v", err)
+	}
+
+	// With -k this expression would select every test not named test_load.
+	if _, err := New(tmpDir).getSingleTestCommand(projectPython, "not test_load"); err == nil {
+		t.Error("expected an error for a name matching no test")
+	}
+}
+
+func TestGetSingleTestCommandUnsupported(t *testing.T) {
+	r := New(t.TempDir())
+
+	if _, err := r.getSingleTestCommand(projectScala, "MySpec"); !errors.Is(err, ErrSingleTestUnsupported) {
+		t.Errorf("expected ErrSingleTestUnsupported for scala, got %v", err)
+	}
+
+	r.SetConfig(RunnerConfig{TestCommand: "make test"})
+	if _, err := r.getSingleTestCommand(projectGo, "TestParse"); !errors.Is(err, ErrSingleTestUnsupported) {
+		t.Errorf("expected ErrSingleTestUnsupported with a custom command, got %v", err)
+	}
+
+	if _, err := r.RunSingleTest(" "); err == nil {
+		t.Error("expected error for empty test name")
+	}
+}
@@ -0,0 +1,55 @@
+package runner
+
+import (
+	"path"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func (r *Runner) SetSuppressRules(rules []string) {
+	r.config.SuppressRules = rules
+}
+
+func (r *Runner) suppressIssues(issues []models.LintIssue) []models.LintIssue {
+	if len(r.config.SuppressRules) == 0 {
+		return issues
+	}
+
+	kept := issues[:0]
+	for _, issue := range issues {
+		if !r.ruleSuppressed(issue.Rule) {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+func (r *Runner) ruleSuppressed(rule string) bool {
+	if rule == "" {
+		return false
+	}
+	for _, pattern := range r.config.SuppressRules {
+		if matched, err := path.Match(pattern, rule); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Runner) lintResult(result *TestResult, pt projectType) *LintResult {
+	parsed := r.parseLintOutput(result.Output, pt)
+	total := len(parsed)
+	issues := r.suppressIssues(parsed)
+
+	success := result.Success
+	if !success && total > 0 && len(issues) == 0 {
+		success = true
+	}
+
+	return &LintResult{
+		Success:  success,
+		Output:   result.Output,
+		Duration: result.Duration,
+		Issues:   issues,
+	}
+}
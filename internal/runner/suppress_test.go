@@ -0,0 +1,56 @@
+package runner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSuppressRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "requirements.txt"), []byte("ruff\n"), 0644); err != nil {
+		t.Fatalf("failed to create requirements.txt: %v", err)
+	}
+
+	output := "app.py:10:89: E501 Line too long (120 > 88)\napp.py:1:1: F401 `os` imported but unused\n"
+
+	tests := []struct {
+		name     string
+		suppress []string
+		expected []string
+		success  bool
+	}{
+		{name: "no suppression", expected: []string{"E501", "F401"}, success: false},
+		{name: "glob suppresses E5 rules", suppress: []string{"E5*"}, expected: []string{"F401"}, success: false},
+		{name: "all suppressed", suppress: []string{"E501", "F*"}, expected: nil, success: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tmpDir)
+			r.SetConfig(RunnerConfig{LintCommand: "printf '" + output + "'; exit 1", Shell: true})
+			r.SetSuppressRules(tt.suppress)
+
+			result, err := r.RunLint()
+			if err != nil {
+				t.Fatalf("RunLint returned error: %v", err)
+			}
+
+			var rules []string
+			for _, issue := range result.Issues {
+				rules = append(rules, issue.Rule)
+			}
+			if len(rules) != len(tt.expected) {
+				t.Fatalf("expected rules %v, got %v", tt.expected, rules)
+			}
+			for i := range rules {
+				if rules[i] != tt.expected[i] {
+					t.Errorf("expected rules %v, got %v", tt.expected, rules)
+				}
+			}
+			if result.Success != tt.success {
+				t.Errorf("expected success=%v, got %v", tt.success, result.Success)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package runner
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const tracebackHeader = "Traceback (most recent call last):"
+
+var (
+	tracebackFrameRegex     = regexp.MustCompile(`^\s*File "([^"]+)", line (\d+), in (.+)$`)
+	tracebackExceptionRegex = regexp.MustCompile(`^([A-Za-z_][\w.]*)(?::\s?(.*))?$`)
+	pytestSectionRegex      = regexp.MustCompile(`^_{3,} (.+?) _{3,}$`)
+	unittestSectionRegex    = regexp.MustCompile(`^(?:ERROR|FAIL): (\S+)`)
+
+	// pytest's long and short --tb styles report frames as "path:line: in func"
+	// (short), "path:line: ExcType" (last frame, long) or "path:line: "
+	// (earlier frames, long), with the exception on "E   " lines.
+	pytestLocationRegex = regexp.MustCompile(`^(\S+\.py):(\d+):(?: in (\S+)| ([A-Za-z_][\w.]*))?\s*$`)
+	pytestDefRegex      = regexp.MustCompile(`^\s*(?:>\s*)?(?:async\s+)?def (\w+)\(`)
+	pytestErrorRegex    = regexp.MustCompile(`^E\s+(.*)$`)
+	pytestExcRegex      = regexp.MustCompile(`^([A-Za-z_][\w.]*(?:Error|Exception|Exit|Interrupt|Warning|Failed)): ?(.*)$`)
+)
+
+type PythonTraceback struct {
+	ExceptionType string
+	Message       string
+	File          string
+	Line          int
+	Function      string
+}
+
+type TestCaseResult struct {
+	Name      string
+	Passed    bool
+	Traceback *PythonTraceback
+}
+
+func (t TestCaseResult) Crashed() bool {
+	return t.Traceback != nil && t.Traceback.ExceptionType != "AssertionError"
+}
+
+func parsePythonTraceback(output string) *PythonTraceback {
+	idx := strings.LastIndex(output, tracebackHeader)
+	if idx < 0 {
+		return parsePytestTraceback(output)
+	}
+
+	var tb PythonTraceback
+	for _, line := range strings.Split(output[idx+len(tracebackHeader):], "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if m := tracebackFrameRegex.FindStringSubmatch(line); m != nil {
+			tb.File = m[1]
+			tb.Line, _ = strconv.Atoi(m[2])
+			tb.Function = m[3]
+			continue
+		}
+
+		if line == "" || line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+
+		if m := tracebackExceptionRegex.FindStringSubmatch(line); m != nil {
+			tb.ExceptionType = m[1]
+			tb.Message = strings.TrimSpace(m[2])
+			return &tb
+		}
+		break
+	}
+
+	if tb.File == "" {
+		return nil
+	}
+	return &tb
+}
+
+func parsePytestTraceback(output string) *PythonTraceback {
+	var tb PythonTraceback
+	var function, errLine string
+	found := false
+	inError := false
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		if m := pytestErrorRegex.FindStringSubmatch(line); m != nil {
+			if !inError {
+				errLine = strings.TrimSpace(m[1])
+			}
+			inError = true
+			continue
+		}
+		inError = false
+
+		if m := pytestDefRegex.FindStringSubmatch(line); m != nil {
+			function = m[1]
+			continue
+		}
+
+		if m := pytestLocationRegex.FindStringSubmatch(line); m != nil {
+			found = true
+			tb.File = m[1]
+			tb.Line, _ = strconv.Atoi(m[2])
+			tb.Function = function
+			if m[3] != "" {
+				tb.Function = m[3]
+			}
+			tb.ExceptionType = m[4]
+			function = ""
+		}
+	}
+
+	if !found {
+		return nil
+	}
+
+	if m := pytestExcRegex.FindStringSubmatch(errLine); m != nil {
+		if tb.ExceptionType == "" {
+			tb.ExceptionType = m[1]
+		}
+		tb.Message = strings.TrimSpace(m[2])
+	} else {
+		tb.Message = errLine
+		if tb.ExceptionType == "" && strings.HasPrefix(errLine, "assert") {
+			tb.ExceptionType = "AssertionError"
+		}
+	}
+
+	return &tb
+}
+
+func parsePythonTestCases(output string) []TestCaseResult {
+	var cases []TestCaseResult
+	var name string
+	var section strings.Builder
+
+	flush := func() {
+		if name == "" {
+			return
+		}
+		cases = append(cases, TestCaseResult{
+			Name:      name,
+			Traceback: parsePythonTraceback(section.String()),
+		})
+		section.Reset()
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimRight(line, "\r")
+
+		m := pytestSectionRegex.FindStringSubmatch(trimmed)
+		if m == nil {
+			m = unittestSectionRegex.FindStringSubmatch(trimmed)
+		}
+		if m != nil {
+			flush()
+			name = m[1]
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "=====") && !strings.Contains(trimmed, "FAILURES") && !strings.Contains(trimmed, "ERRORS") {
+			flush()
+			name = ""
+			continue
+		}
+
+		if name != "" {
+			section.WriteString(line)
+			section.WriteString("\n")
+		}
+	}
+	flush()
+
+	return cases
+}
@@ -0,0 +1,180 @@
+package runner
+
+import "testing"
+
+const sampleTraceback = `Traceback (most recent call last):
+  File "/usr/lib/python3.11/unittest/case.py", line 57, in testPartExecutor
+    yield
+  File "tests/test_loader.py", line 14, in test_load
+    data = load_config("missing.yaml")
+  File "app/loader.py", line 42, in load_config
+    with open(path) as f:
+FileNotFoundError: [Errno 2] No such file or directory: 'missing.yaml'
+`
+
+func TestParsePythonTraceback(t *testing.T) {
+	tb := parsePythonTraceback(sampleTraceback)
+	if tb == nil {
+		t.Fatal("expected traceback to be parsed")
+	}
+
+	if tb.ExceptionType != "FileNotFoundError" {
+		t.Errorf("expected exception type FileNotFoundError, got %q", tb.ExceptionType)
+	}
+	if tb.Message != "[Errno 2] No such file or directory: 'missing.yaml'" {
+		t.Errorf("unexpected message %q", tb.Message)
+	}
+	if tb.File != "app/loader.py" || tb.Line != 42 || tb.Function != "load_config" {
+		t.Errorf("expected deepest frame app/loader.py:42 in load_config, got %s:%d in %s", tb.File, tb.Line, tb.Function)
+	}
+
+	if parsePythonTraceback("FAILED tests/test_x.py::test_a - assert 1 == 2") != nil {
+		t.Error("expected nil for output without a traceback")
+	}
+}
+
+func TestParsePythonTestCases(t *testing.T) {
+	output := "E\n" +
+		"======================================================================\n" +
+		"ERROR: test_load (tests.test_loader.LoaderTest)\n" +
+		"----------------------------------------------------------------------\n" +
+		sampleTraceback +
+		"\n======================================================================\n" +
+		"FAIL: test_sum (tests.test_math.MathTest)\n" +
+		"----------------------------------------------------------------------\n" +
+		"Traceback (most recent call last):\n" +
+		"  File \"tests/test_math.py\", line 8, in test_sum\n" +
+		"    self.assertEqual(add(1, 1), 3)\n" +
+		"AssertionError: 2 != 3\n\n" +
+		"----------------------------------------------------------------------\n" +
+		"Ran 2 tests in 0.001s\n\nFAILED (failures=1, errors=1)\n"
+
+	cases := parsePythonTestCases(output)
+	if len(cases) != 2 {
+		t.Fatalf("expected 2 test cases, got %d", len(cases))
+	}
+
+	if cases[0].Name != "test_load" || !cases[0].Crashed() {
+		t.Errorf("expected test_load to be reported as a crash, got %+v", cases[0])
+	}
+	if cases[1].Name != "test_sum" || cases[1].Crashed() {
+		t.Errorf("expected test_sum to be an assertion failure, got %+v", cases[1])
+	}
+	if cases[1].Traceback == nil || cases[1].Traceback.Line != 8 {
+		t.Errorf("expected test_sum traceback at line 8, got %+v", cases[1].Traceback)
+	}
+}
+
+// Captured from `python -m pytest -x -q` (pytest 8, default --tb=auto).
+const samplePytestOutput = `F
+=================================== FAILURES ===================================
+__________________________________ test_load ___________________________________
+
+    def test_load():
+>       data = load_config("missing.yaml")
+
+tests/test_loader.py:14: 
+_ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ _ 
+
+path = 'missing.yaml'
+
+    def load_config(path):
+>       with open(path) as f:
+E       FileNotFoundError: [Errno 2] No such file or directory: 'missing.yaml'
+
+app/loader.py:42: FileNotFoundError
+=========================== short test summary info ============================
+FAILED tests/test_loader.py::test_load - FileNotFoundError: [Errno 2] No such file or directory: 'missing.yaml'
+!!!!!!!!!!!!!!!!!!!!!!!!!! stopping after 1 failures !!!!!!!!!!!!!!!!!!!!!!!!!!!
+1 failed in 0.03s
+`
+
+func TestParsePytestTraceback(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		excType  string
+		message  string
+		file     string
+		line     int
+		function string
+		crashed  bool
+	}{
+		{
+			name:     "long format crash",
+			output:   samplePytestOutput,
+			excType:  "FileNotFoundError",
+			message:  "[Errno 2] No such file or directory: 'missing.yaml'",
+			file:     "app/loader.py",
+			line:     42,
+			function: "load_config",
+			crashed:  true,
+		},
+		{
+			name: "long format assertion",
+			output: `F
+=================================== FAILURES ===================================
+___________________________________ test_sum ___________________________________
+
+    def test_sum():
+>       assert add(1, 1) == 3
+E       assert 2 == 3
+E        +  where 2 = add(1, 1)
+
+tests/test_math.py:8: AssertionError
+=========================== short test summary info ============================
+FAILED tests/test_math.py::test_sum - assert 2 == 3
+1 failed in 0.01s
+`,
+			excType:  "AssertionError",
+			message:  "assert 2 == 3",
+			file:     "tests/test_math.py",
+			line:     8,
+			function: "test_sum",
+		},
+		{
+			name: "short format crash",
+			output: `F
+=================================== FAILURES ===================================
+__________________________________ test_load ___________________________________
+tests/test_loader.py:14: in test_load
+    data = load_config("missing.yaml")
+app/loader.py:42: in load_config
+    with open(path) as f:
+E   FileNotFoundError: [Errno 2] No such file or directory: 'missing.yaml'
+=========================== short test summary info ============================
+FAILED tests/test_loader.py::test_load - FileNotFoundError: [Errno 2] No such file or directory: 'missing.yaml'
+1 failed in 0.02s
+`,
+			excType:  "FileNotFoundError",
+			message:  "[Errno 2] No such file or directory: 'missing.yaml'",
+			file:     "app/loader.py",
+			line:     42,
+			function: "load_config",
+			crashed:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cases := parsePythonTestCases(tt.output)
+			if len(cases) != 1 {
+				t.Fatalf("expected 1 test case, got %d", len(cases))
+			}
+
+			tb := cases[0].Traceback
+			if tb == nil {
+				t.Fatal("expected traceback to be parsed")
+			}
+			if tb.ExceptionType != tt.excType || tb.Message != tt.message {
+				t.Errorf("expected %s: %q, got %s: %q", tt.excType, tt.message, tb.ExceptionType, tb.Message)
+			}
+			if tb.File != tt.file || tb.Line != tt.line || tb.Function != tt.function {
+				t.Errorf("expected %s:%d in %s, got %s:%d in %s", tt.file, tt.line, tt.function, tb.File, tb.Line, tb.Function)
+			}
+			if cases[0].Crashed() != tt.crashed {
+				t.Errorf("expected Crashed() = %v", tt.crashed)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package sidecar
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+type SidecarAPI interface {
+	Health(ctx context.Context) error
+	Ping(ctx context.Context) (time.Duration, error)
+	Diagnostics(ctx context.Context) (*Diagnostics, error)
+	Analyze(ctx context.Context, path string, files []models.FileInfo) (*AnalyzeResult, error)
+	AnalyzeFiles(ctx context.Context, files []models.FileInfo) (*AnalyzeResult, error)
+	AnalyzeTar(ctx context.Context, r io.Reader) (*AnalyzeResult, error)
+	AnalyzeResumable(ctx context.Context, path string) (*AnalyzeResult, error)
+	AnalyzeByDirectory(ctx context.Context, root string) (map[string]*DirSummary, error)
+	AnalyzeGraph(ctx context.Context, path string) (*DependencyGraph, error)
+	LanguageStats(ctx context.Context, path string) ([]models.LanguageStat, error)
+	SuggestFix(ctx context.Context, group models.DuplicateGroup) (string, error)
+	Deduplicate(ctx context.Context, path string, files []models.FileInfo, threshold float64) (*models.RefactorPlan, error)
+	Idiomatize(ctx context.Context, path string, files []models.FileInfo, language models.Language) (*models.RefactorPlan, error)
+	IdiomatizeLang(ctx context.Context, path string, lang models.Language) (*models.RefactorPlan, error)
+	CanApplyPattern(ctx context.Context, pattern, path string) (bool, []string, error)
+	ApplyPattern(ctx context.Context, pattern, path string, files []models.FileInfo) (*models.RefactorPlan, error)
+	ApplyPlan(ctx context.Context, sessionID string) (*models.RefactorResult, error)
+	ApplyBatch(ctx context.Context, plans []*models.RefactorPlan) ([]*models.RefactorResult, error)
+	ResumeBatch(ctx context.Context, id string, plans []*models.RefactorPlan) ([]*models.RefactorResult, error)
+	Embed(ctx context.Context, files []models.FileInfo) (map[string][]float32, error)
+	EmbedProgress(ctx context.Context, files []models.FileInfo, onProgress func(done, total int)) (map[string][]float32, error)
+	EmbedDelta(ctx context.Context, files []models.FileInfo, knownHashes map[string]string) (map[string][]float32, error)
+}
+
+var _ SidecarAPI = (*Client)(nil)
@@ -0,0 +1,110 @@
+package sidecar
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+const maxArchiveFileSize = 1 << 20
+
+func (c *Client) AnalyzeFiles(ctx context.Context, files []models.FileInfo) (*AnalyzeResult, error) {
+	if len(files) == 0 {
+		return nil, NewValidationError("analyze", "no files to analyze")
+	}
+	return c.Analyze(ctx, ".", files)
+}
+
+func (c *Client) AnalyzeTar(ctx context.Context, r io.Reader) (*AnalyzeResult, error) {
+	files, err := readTarFiles(r, c.forceLanguage != "")
+	if err != nil {
+		return nil, err
+	}
+	if c.forceLanguage != "" {
+		for i := range files {
+			files[i].Language = c.forceLanguage
+		}
+	}
+	return c.AnalyzeFiles(ctx, files)
+}
+
+func ReadTarFiles(r io.Reader) ([]models.FileInfo, error) {
+	return readTarFiles(r, false)
+}
+
+func readTarFiles(r io.Reader, keepUnknown bool) ([]models.FileInfo, error) {
+	br := bufio.NewReader(r)
+
+	var src io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		defer gz.Close()
+		src = gz
+	}
+
+	tr := tar.NewReader(src)
+
+	var files []models.FileInfo
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name, ok := cleanArchivePath(hdr.Name)
+		if !ok {
+			continue
+		}
+
+		if hdr.Size > maxArchiveFileSize {
+			continue
+		}
+
+		content, err := io.ReadAll(io.LimitReader(tr, maxArchiveFileSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", name, err)
+		}
+
+		lang := models.DetectLanguage(name, string(content))
+		if lang == models.LanguageUnknown && !keepUnknown {
+			continue
+		}
+
+		hash := sha256.Sum256(content)
+		files = append(files, models.FileInfo{
+			Path:     name,
+			Content:  string(content),
+			Hash:     hex.EncodeToString(hash[:]),
+			Language: lang,
+		})
+	}
+
+	return files, nil
+}
+
+func cleanArchivePath(name string) (string, bool) {
+	name = path.Clean(strings.TrimPrefix(name, "./"))
+	if name == "." || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return "", false
+	}
+	return name, true
+}
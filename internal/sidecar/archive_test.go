@@ -0,0 +1,135 @@
+package sidecar
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func buildTar(t *testing.T, entries []*tar.Header, contents map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, hdr := range entries {
+		body := contents[hdr.Name]
+		if hdr.Typeflag == tar.TypeReg {
+			hdr.Size = int64(len(body))
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("failed to write header: %v", err)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			if _, err := io.WriteString(tw, body); err != nil {
+				t.Fatalf("failed to write body: %v", err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("failed to close tar: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestAnalyzeTar(t *testing.T) {
+	contents := map[string]string{
+		"./cmd/main.go": "package main\n\nfunc main() {}\n",
+		"pkg/util.go":   "package pkg\n\nfunc Util() {}\n",
+		"README.md":     "# readme\n",
+		"../escape.go":  "package evil\n",
+		"/abs/path.go":  "package abs\n",
+	}
+	archive := buildTar(t, []*tar.Header{
+		{Name: "cmd/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "./cmd/main.go", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "pkg/util.go", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "README.md", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "link.go", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"},
+		{Name: "../escape.go", Typeflag: tar.TypeReg, Mode: 0644},
+		{Name: "/abs/path.go", Typeflag: tar.TypeReg, Mode: 0644},
+	}, contents)
+
+	var received []models.FileInfo
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Files []models.FileInfo `json:"files"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		received = body.Files
+		writeAPIResponse(w, map[string]interface{}{"total_files": len(body.Files)})
+	})
+
+	client := NewClient(server.URL)
+
+	t.Run("plain tar", func(t *testing.T) {
+		result, err := client.AnalyzeTar(context.Background(), bytes.NewReader(archive))
+		if err != nil {
+			t.Fatalf("AnalyzeTar returned error: %v", err)
+		}
+		if result.TotalFiles != 2 {
+			t.Errorf("expected 2 analyzed files, got %d", result.TotalFiles)
+		}
+		if len(received) != 2 || received[0].Path != "cmd/main.go" || received[1].Path != "pkg/util.go" {
+			t.Fatalf("unexpected files sent to sidecar: %+v", received)
+		}
+		if received[0].Content != contents["./cmd/main.go"] || received[0].Hash == "" {
+			t.Errorf("unexpected file info: %+v", received[0])
+		}
+	})
+
+	t.Run("gzip tar", func(t *testing.T) {
+		var gzBuf bytes.Buffer
+		gz := gzip.NewWriter(&gzBuf)
+		gz.Write(archive)
+		gz.Close()
+
+		result, err := client.AnalyzeTar(context.Background(), &gzBuf)
+		if err != nil {
+			t.Fatalf("AnalyzeTar returned error: %v", err)
+		}
+		if result.TotalFiles != 2 {
+			t.Errorf("expected 2 analyzed files, got %d", result.TotalFiles)
+		}
+	})
+
+	t.Run("shebang script and forced language", func(t *testing.T) {
+		scripts := map[string]string{
+			"bin/deploy":  "#!/usr/bin/env python3\nimport sys\n",
+			"bin/cleanup": "import shutil\n",
+		}
+		scriptArchive := buildTar(t, []*tar.Header{
+			{Name: "bin/deploy", Typeflag: tar.TypeReg, Mode: 0755},
+			{Name: "bin/cleanup", Typeflag: tar.TypeReg, Mode: 0755},
+		}, scripts)
+
+		if _, err := client.AnalyzeTar(context.Background(), bytes.NewReader(scriptArchive)); err != nil {
+			t.Fatalf("AnalyzeTar returned error: %v", err)
+		}
+		if len(received) != 1 || received[0].Path != "bin/deploy" || received[0].Language != models.LanguagePython {
+			t.Fatalf("expected only the shebang script to be analyzed as python, got %+v", received)
+		}
+
+		forced := NewClient(server.URL)
+		forced.SetForceLanguage(models.LanguagePython)
+		if _, err := forced.AnalyzeTar(context.Background(), bytes.NewReader(scriptArchive)); err != nil {
+			t.Fatalf("AnalyzeTar returned error: %v", err)
+		}
+		if len(received) != 2 || received[1].Language != models.LanguagePython {
+			t.Fatalf("expected both scripts to be analyzed as python, got %+v", received)
+		}
+	})
+
+	t.Run("no source files", func(t *testing.T) {
+		empty := buildTar(t, []*tar.Header{{Name: "notes.txt", Typeflag: tar.TypeReg}}, map[string]string{"notes.txt": "hi"})
+		if _, err := client.AnalyzeTar(context.Background(), bytes.NewReader(empty)); err == nil {
+			t.Error("expected error when archive has no source files")
+		}
+	})
+}
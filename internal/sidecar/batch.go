@@ -0,0 +1,144 @@
+package sidecar
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+type BatchProgress struct {
+	ID        string    `json:"id"`
+	Applied   []string  `json:"applied"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BatchID derives a stable batch ID from the plans' session IDs, so running
+// ApplyBatch again with the same plans picks up where the last run stopped.
+func BatchID(plans []*models.RefactorPlan) string {
+	h := sha256.New()
+	for _, plan := range plans {
+		if plan != nil {
+			h.Write([]byte(plan.SessionID))
+		}
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func (c *Client) ApplyBatch(ctx context.Context, plans []*models.RefactorPlan) ([]*models.RefactorResult, error) {
+	return c.ResumeBatch(ctx, BatchID(plans), plans)
+}
+
+func (c *Client) ResumeBatch(ctx context.Context, id string, plans []*models.RefactorPlan) ([]*models.RefactorResult, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return nil, NewValidationError("batch", fmt.Sprintf("invalid batch ID %q", id))
+	}
+
+	progress, err := c.loadBatchProgress(id)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]bool, len(progress.Applied))
+	for _, sessionID := range progress.Applied {
+		applied[sessionID] = true
+	}
+
+	results, err := c.applyPlans(ctx, plans, applied, func(sessionID string) error {
+		progress.Applied = append(progress.Applied, sessionID)
+		return c.saveBatchProgress(progress)
+	})
+	if err != nil {
+		return results, err
+	}
+
+	// A finished batch has nothing to resume; drop its progress so running
+	// the same plans again applies them again.
+	if err := os.Remove(c.batchProgressPath(id)); err != nil && !os.IsNotExist(err) {
+		return results, fmt.Errorf("failed to remove batch progress: %w", err)
+	}
+
+	return results, nil
+}
+
+func (c *Client) applyPlans(ctx context.Context, plans []*models.RefactorPlan, applied map[string]bool, onApplied func(sessionID string) error) ([]*models.RefactorResult, error) {
+	var results []*models.RefactorResult
+
+	for _, plan := range plans {
+		if plan == nil || applied[plan.SessionID] {
+			continue
+		}
+
+		result, err := c.ApplyPlan(ctx, plan.SessionID)
+		if err != nil {
+			return results, fmt.Errorf("failed to apply plan %s: %w", plan.SessionID, err)
+		}
+		if !result.Success {
+			return results, fmt.Errorf("plan %s was not applied: %s", plan.SessionID, result.Error)
+		}
+
+		applied[plan.SessionID] = true
+		results = append(results, result)
+
+		if onApplied != nil {
+			if err := onApplied(plan.SessionID); err != nil {
+				return results, err
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func (c *Client) batchProgressPath(id string) string {
+	return filepath.Join(c.stateDir, fmt.Sprintf("batch-%s.json", id))
+}
+
+func (c *Client) loadBatchProgress(id string) (*BatchProgress, error) {
+	data, err := os.ReadFile(c.batchProgressPath(id))
+	if os.IsNotExist(err) {
+		return &BatchProgress{ID: id}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch progress: %w", err)
+	}
+
+	var progress BatchProgress
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return nil, fmt.Errorf("failed to parse batch progress: %w", err)
+	}
+	progress.ID = id
+
+	return &progress, nil
+}
+
+func (c *Client) saveBatchProgress(progress *BatchProgress) error {
+	if err := os.MkdirAll(c.stateDir, 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	progress.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch progress: %w", err)
+	}
+
+	path := c.batchProgressPath(progress.ID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write batch progress: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write batch progress: %w", err)
+	}
+
+	return nil
+}
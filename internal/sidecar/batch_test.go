@@ -0,0 +1,219 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestResumeBatch(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	failSession := "session-2"
+
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			SessionID string `json:"session_id"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		requested = append(requested, req.SessionID)
+		fail := req.SessionID == failSession
+		mu.Unlock()
+
+		if fail {
+			http.Error(w, "interrupted", http.StatusServiceUnavailable)
+			return
+		}
+		writeAPIResponse(w, map[string]interface{}{
+			"session_id": req.SessionID,
+			"success":    true,
+		})
+	})
+
+	stateDir := t.TempDir()
+	client := NewClient(server.URL)
+	client.SetStateDir(stateDir)
+
+	plans := []*models.RefactorPlan{
+		{SessionID: "session-1"},
+		{SessionID: "session-2"},
+		{SessionID: "session-3"},
+	}
+
+	results, err := client.ResumeBatch(context.Background(), "b1", plans)
+	if err == nil {
+		t.Fatal("expected interruption error")
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 applied plan before interruption, got %d", len(results))
+	}
+
+	if _, err := os.Stat(filepath.Join(stateDir, "batch-b1.json")); err != nil {
+		t.Fatalf("expected progress file: %v", err)
+	}
+
+	mu.Lock()
+	requested = nil
+	failSession = ""
+	mu.Unlock()
+
+	results, err = client.ResumeBatch(context.Background(), "b1", plans)
+	if err != nil {
+		t.Fatalf("ResumeBatch returned error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("expected 2 applied plans on resume, got %d", len(results))
+	}
+
+	expected := []string{"session-2", "session-3"}
+	if len(requested) != len(expected) {
+		t.Fatalf("expected requests %v, got %v", expected, requested)
+	}
+	for i := range expected {
+		if requested[i] != expected[i] {
+			t.Errorf("expected requests %v, got %v", expected, requested)
+			break
+		}
+	}
+}
+
+func TestApplyBatchSkipsDuplicateSessions(t *testing.T) {
+	var count int
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		count++
+		writeAPIResponse(w, map[string]interface{}{"success": true})
+	})
+
+	client := NewClient(server.URL)
+	client.SetStateDir(t.TempDir())
+	plans := []*models.RefactorPlan{
+		{SessionID: "a"},
+		{SessionID: "a"},
+		{SessionID: "b"},
+	}
+
+	results, err := client.ApplyBatch(context.Background(), plans)
+	if err != nil {
+		t.Fatalf("ApplyBatch returned error: %v", err)
+	}
+	if len(results) != 2 || count != 2 {
+		t.Errorf("expected 2 applied plans, got %d results and %d requests", len(results), count)
+	}
+}
+
+func TestApplyBatchResumesAfterInterruption(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	failSession := "session-2"
+
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			SessionID string `json:"session_id"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		requested = append(requested, req.SessionID)
+		fail := req.SessionID == failSession
+		mu.Unlock()
+
+		if fail {
+			http.Error(w, "interrupted", http.StatusServiceUnavailable)
+			return
+		}
+		writeAPIResponse(w, map[string]interface{}{
+			"session_id": req.SessionID,
+			"success":    true,
+		})
+	})
+
+	stateDir := t.TempDir()
+	client := NewClient(server.URL)
+	client.SetStateDir(stateDir)
+
+	plans := []*models.RefactorPlan{
+		{SessionID: "session-1"},
+		{SessionID: "session-2"},
+		{SessionID: "session-3"},
+	}
+
+	results, err := client.ApplyBatch(context.Background(), plans)
+	if err == nil {
+		t.Fatal("expected interruption error")
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 applied plan before interruption, got %d", len(results))
+	}
+
+	if _, err := os.Stat(filepath.Join(stateDir, "batch-"+BatchID(plans)+".json")); err != nil {
+		t.Fatalf("expected progress file: %v", err)
+	}
+
+	mu.Lock()
+	requested = nil
+	failSession = ""
+	mu.Unlock()
+
+	results, err = client.ApplyBatch(context.Background(), plans)
+	if err != nil {
+		t.Fatalf("ApplyBatch returned error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("expected 2 applied plans on restart, got %d", len(results))
+	}
+	if _, err := os.Stat(filepath.Join(stateDir, "batch-"+BatchID(plans)+".json")); !os.IsNotExist(err) {
+		t.Errorf("expected progress file to be removed after completion, got %v", err)
+	}
+
+	expected := []string{"session-2", "session-3"}
+	if len(requested) != len(expected) {
+		t.Fatalf("expected requests %v, got %v", expected, requested)
+	}
+	for i := range expected {
+		if requested[i] != expected[i] {
+			t.Errorf("expected requests %v, got %v", expected, requested)
+			break
+		}
+	}
+}
+
+func TestApplyBatchRerunAfterCompletion(t *testing.T) {
+	var count int
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		count++
+		writeAPIResponse(w, map[string]interface{}{"success": true})
+	})
+
+	stateDir := t.TempDir()
+	client := NewClient(server.URL)
+	client.SetStateDir(stateDir)
+	plans := []*models.RefactorPlan{
+		{SessionID: "a"},
+		{SessionID: "b"},
+	}
+
+	for run := 1; run <= 2; run++ {
+		results, err := client.ApplyBatch(context.Background(), plans)
+		if err != nil {
+			t.Fatalf("run %d: ApplyBatch returned error: %v", run, err)
+		}
+		if len(results) != 2 {
+			t.Errorf("run %d: expected 2 applied plans, got %d", run, len(results))
+		}
+	}
+
+	if count != 4 {
+		t.Errorf("expected every plan to be applied on both runs, got %d requests", count)
+	}
+	if entries, _ := os.ReadDir(stateDir); len(entries) != 0 {
+		t.Errorf("expected no leftover progress files, got %d", len(entries))
+	}
+}
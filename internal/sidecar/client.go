@@ -0,0 +1,399 @@
+package sidecar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultClientTimeout    = 10 * time.Minute
+	defaultEmbedBatchSize   = 25
+	defaultAnalyzeBatchSize = 10
+	defaultStateDir         = ".reducto"
+)
+
+type Client struct {
+	baseURL          string
+	httpClient       *http.Client
+	sortResult       bool
+	embedBatchSize   int
+	analyzeBatchSize int
+	stateDir         string
+	limiter          *rate.Limiter
+	sharedSecret     []byte
+	forceLanguage    models.Language
+	strictParse      bool
+	publicOnly       bool
+}
+
+type StatusError struct {
+	StatusCode int
+	Body       string
+	RequestID  string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("sidecar returned status %d (request %s): %s", e.StatusCode, e.RequestID, e.Body)
+}
+
+type apiResponse struct {
+	Status string          `json:"status"`
+	Data   json.RawMessage `json:"data"`
+	Error  string          `json:"error,omitempty"`
+}
+
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL:          strings.TrimSuffix(baseURL, "/"),
+		httpClient:       &http.Client{Timeout: defaultClientTimeout},
+		embedBatchSize:   defaultEmbedBatchSize,
+		analyzeBatchSize: defaultAnalyzeBatchSize,
+		stateDir:         defaultStateDir,
+	}
+}
+
+func NewClientWithTransport(baseURL string, rt http.RoundTripper) *Client {
+	c := NewClient(baseURL)
+	c.httpClient.Transport = rt
+	return c
+}
+
+func (c *Client) SetHTTPClient(hc *http.Client) {
+	if hc == nil {
+		return
+	}
+	client := *hc
+	if client.Timeout == 0 {
+		client.Timeout = c.httpClient.Timeout
+	}
+	c.httpClient = &client
+}
+
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.httpClient.Timeout = timeout
+}
+
+func (c *Client) SetEmbedBatchSize(size int) {
+	if size > 0 {
+		c.embedBatchSize = size
+	}
+}
+
+func (c *Client) SetAnalyzeBatchSize(size int) {
+	if size > 0 {
+		c.analyzeBatchSize = size
+	}
+}
+
+func (c *Client) SetStateDir(dir string) {
+	c.stateDir = dir
+}
+
+func (c *Client) SetRateLimit(requestsPerSecond float64) {
+	c.limiter = newRateLimiter(requestsPerSecond)
+}
+
+func (c *Client) SetForceLanguage(lang models.Language) {
+	c.forceLanguage = lang
+}
+
+func (c *Client) SetPublicOnly(enabled bool) {
+	c.publicOnly = enabled
+}
+
+func (c *Client) SetDeterministicOrder(enabled bool) {
+	c.sortResult = enabled
+}
+
+func (c *Client) Health(ctx context.Context) error {
+	_, _, err := c.health(ctx)
+	return err
+}
+
+func (c *Client) Analyze(ctx context.Context, path string, files []models.FileInfo) (*AnalyzeResult, error) {
+	if err := validateTarget("analyze", path, files); err != nil {
+		return nil, err
+	}
+
+	body := models.AnalyzeRequest{
+		Path:  path,
+		Files: files,
+	}
+
+	var result AnalyzeResult
+	if err := c.post(ctx, "/analyze", body, &result); err != nil {
+		return nil, fmt.Errorf("analyze failed: %w", err)
+	}
+
+	if c.strictParse && len(result.ParseErrors) > 0 {
+		return nil, fmt.Errorf("analyze failed: %w", &ParseFailedError{Errors: result.ParseErrors})
+	}
+
+	if c.publicOnly {
+		result.Symbols = exportedSymbols(result.Symbols)
+	}
+
+	if c.sortResult {
+		result.Sort()
+	}
+
+	return &result, nil
+}
+
+func exportedSymbols(symbols []models.Symbol) []models.Symbol {
+	var exported []models.Symbol
+	for _, sym := range symbols {
+		if sym.IsExported() {
+			exported = append(exported, sym)
+		}
+	}
+	return exported
+}
+
+func (c *Client) Deduplicate(ctx context.Context, path string, files []models.FileInfo, threshold float64) (*models.RefactorPlan, error) {
+	if err := validateTarget("deduplicate", path, files); err != nil {
+		return nil, err
+	}
+	if threshold < 0 || threshold > 1 {
+		return nil, NewValidationError("deduplicate", fmt.Sprintf("threshold must be between 0 and 1, got %v", threshold))
+	}
+
+	body := map[string]interface{}{
+		"path":                 path,
+		"files":                files,
+		"similarity_threshold": threshold,
+	}
+
+	var plan models.RefactorPlan
+	if err := c.post(ctx, "/deduplicate", body, &plan); err != nil {
+		return nil, fmt.Errorf("deduplicate failed: %w", err)
+	}
+
+	return &plan, nil
+}
+
+func (c *Client) Idiomatize(ctx context.Context, path string, files []models.FileInfo, language models.Language) (*models.RefactorPlan, error) {
+	if err := validateTarget("idiomatize", path, files); err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"path":     path,
+		"files":    files,
+		"language": language,
+	}
+
+	var plan models.RefactorPlan
+	if err := c.post(ctx, "/idiomatize", body, &plan); err != nil {
+		return nil, fmt.Errorf("idiomatize failed: %w", err)
+	}
+
+	return &plan, nil
+}
+
+func (c *Client) IdiomatizeLang(ctx context.Context, path string, lang models.Language) (*models.RefactorPlan, error) {
+	if !knownLanguage(lang) {
+		return nil, NewValidationError("idiomatize", fmt.Sprintf("unknown language %q", lang))
+	}
+	return c.Idiomatize(ctx, path, nil, lang)
+}
+
+func (c *Client) ApplyPattern(ctx context.Context, pattern, path string, files []models.FileInfo) (*models.RefactorPlan, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return nil, NewValidationError("pattern", "pattern required")
+	}
+
+	body := map[string]interface{}{
+		"pattern": pattern,
+		"path":    path,
+		"files":   files,
+	}
+
+	var plan models.RefactorPlan
+	if err := c.post(ctx, "/pattern", body, &plan); err != nil {
+		return nil, fmt.Errorf("pattern failed: %w", err)
+	}
+
+	return &plan, nil
+}
+
+func (c *Client) ApplyPlan(ctx context.Context, sessionID string) (*models.RefactorResult, error) {
+	if sessionID == "" {
+		return nil, NewValidationError("apply", "session ID required")
+	}
+
+	body := map[string]interface{}{
+		"session_id": sessionID,
+	}
+
+	var result models.RefactorResult
+	if err := c.post(ctx, "/apply", body, &result); err != nil {
+		return nil, fmt.Errorf("apply failed: %w", err)
+	}
+
+	return &result, nil
+}
+
+func (c *Client) Embed(ctx context.Context, files []models.FileInfo) (map[string][]float32, error) {
+	if len(files) == 0 {
+		return nil, NewValidationError("embed", "at least one file required")
+	}
+
+	body := map[string]interface{}{
+		"files": files,
+	}
+
+	embeddings := make(map[string][]float32)
+	if err := c.post(ctx, "/embed", body, &embeddings); err != nil {
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusRequestEntityTooLarge && len(files) > 1 {
+			return c.embedSplit(ctx, files)
+		}
+		return nil, fmt.Errorf("embed failed: %w", err)
+	}
+
+	return embeddings, nil
+}
+
+func (c *Client) embedSplit(ctx context.Context, files []models.FileInfo) (map[string][]float32, error) {
+	mid := len(files) / 2
+
+	embeddings, err := c.Embed(ctx, files[:mid])
+	if err != nil {
+		return nil, err
+	}
+
+	rest, err := c.Embed(ctx, files[mid:])
+	if err != nil {
+		return nil, err
+	}
+
+	for path, vector := range rest {
+		embeddings[path] = vector
+	}
+	return embeddings, nil
+}
+
+func (c *Client) EmbedProgress(ctx context.Context, files []models.FileInfo, onProgress func(done, total int)) (map[string][]float32, error) {
+	embeddings := make(map[string][]float32, len(files))
+	total := len(files)
+
+	for start := 0; start < total; start += c.embedBatchSize {
+		end := start + c.embedBatchSize
+		if end > total {
+			end = total
+		}
+
+		batch, err := c.Embed(ctx, files[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("batch %d-%d: %w", start, end, err)
+		}
+
+		for path, vector := range batch {
+			embeddings[path] = vector
+		}
+
+		if onProgress != nil {
+			onProgress(end, total)
+		}
+	}
+
+	return embeddings, nil
+}
+
+func (c *Client) EmbedDelta(ctx context.Context, files []models.FileInfo, knownHashes map[string]string) (map[string][]float32, error) {
+	var changed []models.FileInfo
+	for _, f := range files {
+		if hash, ok := knownHashes[f.Path]; !ok || hash != f.Hash {
+			changed = append(changed, f)
+		}
+	}
+
+	if len(changed) == 0 {
+		return make(map[string][]float32), nil
+	}
+
+	return c.Embed(ctx, changed)
+}
+
+func (c *Client) post(ctx context.Context, endpoint string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	reqID, err := requestID(ctx)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(requestIDHeader, reqID)
+
+	var nonce string
+	if len(c.sharedSecret) > 0 {
+		nonce, err = newNonce()
+		if err != nil {
+			return err
+		}
+		req.Header.Set(nonceHeader, nonce)
+	}
+
+	if err := waitRateLimit(ctx, c.limiter); err != nil {
+		return fmt.Errorf("rate limiter: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data)), RequestID: reqID}
+	}
+
+	// Only bodies we are about to trust need a signature; error responses
+	// often come from proxies that know nothing about the shared secret.
+	if len(c.sharedSecret) > 0 {
+		if err := verifySignature(c.sharedSecret, nonce, data, resp.Header.Get(signatureHeader)); err != nil {
+			return err
+		}
+	}
+
+	var apiResp apiResponse
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if apiResp.Error != "" {
+		return fmt.Errorf("sidecar error (request %s): %s", reqID, apiResp.Error)
+	}
+
+	if out != nil && len(apiResp.Data) > 0 {
+		if err := json.Unmarshal(apiResp.Data, out); err != nil {
+			return fmt.Errorf("failed to decode data: %w", err)
+		}
+	}
+
+	return nil
+}
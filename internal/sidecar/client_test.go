@@ -0,0 +1,479 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+type recordingTransport struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	next     http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.requests = append(t.requests, req)
+	t.mu.Unlock()
+	return t.next.RoundTrip(req)
+}
+
+func newTestSidecar(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func writeAPIResponse(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+func TestNewClientWithTransport(t *testing.T) {
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		writeAPIResponse(w, map[string]interface{}{
+			"total_files":   2,
+			"total_symbols": 5,
+			"hotspots":      []interface{}{},
+		})
+	})
+
+	rt := &recordingTransport{next: http.DefaultTransport}
+	client := NewClientWithTransport(server.URL, rt)
+
+	result, err := client.Analyze(context.Background(), ".", nil)
+	if err != nil {
+		t.Fatalf("Analyze returned error: %v", err)
+	}
+	if result.TotalFiles != 2 {
+		t.Errorf("expected 2 files, got %d", result.TotalFiles)
+	}
+
+	if len(rt.requests) != 1 {
+		t.Fatalf("expected 1 recorded request, got %d", len(rt.requests))
+	}
+	req := rt.requests[0]
+	if req.URL.Path != "/analyze" {
+		t.Errorf("expected /analyze, got %s", req.URL.Path)
+	}
+	if req.Method != http.MethodPost {
+		t.Errorf("expected POST, got %s", req.Method)
+	}
+}
+
+func TestSetHTTPClient(t *testing.T) {
+	t.Run("uses injected transport", func(t *testing.T) {
+		server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+			writeAPIResponse(w, map[string]interface{}{"total_files": 1})
+		})
+
+		rt := &recordingTransport{next: http.DefaultTransport}
+		client := NewClient(server.URL)
+		client.SetHTTPClient(&http.Client{Transport: rt})
+
+		if _, err := client.Analyze(context.Background(), ".", nil); err != nil {
+			t.Fatalf("Analyze returned error: %v", err)
+		}
+		if len(rt.requests) != 1 || rt.requests[0].URL.Path != "/analyze" {
+			t.Errorf("expected recording transport to observe /analyze, got %v", rt.requests)
+		}
+	})
+
+	t.Run("keeps timeout when unset", func(t *testing.T) {
+		client := NewClient("http://localhost")
+		client.SetTimeout(3 * time.Second)
+
+		hc := &http.Client{}
+		client.SetHTTPClient(hc)
+
+		if client.httpClient.Timeout != 3*time.Second {
+			t.Errorf("expected inherited timeout 3s, got %v", client.httpClient.Timeout)
+		}
+		if hc.Timeout != 0 {
+			t.Error("expected caller's client to be left untouched")
+		}
+	})
+
+	t.Run("timeout still applies", func(t *testing.T) {
+		server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+			time.Sleep(200 * time.Millisecond)
+			writeAPIResponse(w, nil)
+		})
+
+		client := NewClient(server.URL)
+		client.SetHTTPClient(&http.Client{
+			Transport: &recordingTransport{next: http.DefaultTransport},
+			Timeout:   20 * time.Millisecond,
+		})
+
+		if _, err := client.Analyze(context.Background(), ".", nil); err == nil {
+			t.Error("expected timeout error")
+		}
+	})
+
+	t.Run("nil is ignored", func(t *testing.T) {
+		client := NewClient("http://localhost")
+		client.SetHTTPClient(nil)
+		if client.httpClient == nil {
+			t.Error("expected existing HTTP client to be kept")
+		}
+	})
+}
+
+func TestClientErrorResponses(t *testing.T) {
+	t.Run("non-200 status", func(t *testing.T) {
+		server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"detail":"boom"}`, http.StatusInternalServerError)
+		})
+
+		client := NewClient(server.URL)
+		if _, err := client.Analyze(context.Background(), ".", nil); err == nil {
+			t.Error("expected error for 500 response")
+		}
+	})
+
+	t.Run("error field", func(t *testing.T) {
+		server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"status": "error",
+				"error":  "model unavailable",
+			})
+		})
+
+		client := NewClient(server.URL)
+		if _, err := client.ApplyPlan(context.Background(), "abc"); err == nil {
+			t.Error("expected error when response carries an error")
+		}
+	})
+}
+
+func TestAnalyzeResultSort(t *testing.T) {
+	payload := map[string]interface{}{
+		"total_files":   3,
+		"total_symbols": 4,
+		"hotspots": []map[string]interface{}{
+			{"file": "b.py", "line": 10, "symbol": "b", "cyclomatic_complexity": 12},
+			{"file": "a.py", "line": 30, "symbol": "a2", "cyclomatic_complexity": 20},
+			{"file": "a.py", "line": 5, "symbol": "a1", "cyclomatic_complexity": 12},
+			{"file": "c.py", "line": 1, "symbol": "c", "cyclomatic_complexity": 15},
+		},
+		"symbols": []map[string]interface{}{
+			{"name": "z", "type": "function", "file": "b.py", "start_line": 1, "end_line": 2},
+			{"name": "y", "type": "function", "file": "a.py", "start_line": 20, "end_line": 30},
+			{"name": "x", "type": "function", "file": "a.py", "start_line": 3, "end_line": 9},
+		},
+	}
+
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		writeAPIResponse(w, payload)
+	})
+
+	t.Run("unsorted by default", func(t *testing.T) {
+		client := NewClient(server.URL)
+		result, err := client.Analyze(context.Background(), ".", nil)
+		if err != nil {
+			t.Fatalf("Analyze returned error: %v", err)
+		}
+		if result.Hotspots[0].Symbol != "b" {
+			t.Errorf("expected sidecar order to be preserved, got %s first", result.Hotspots[0].Symbol)
+		}
+	})
+
+	t.Run("sorted when enabled", func(t *testing.T) {
+		client := NewClient(server.URL)
+		client.SetDeterministicOrder(true)
+
+		for i := 0; i < 3; i++ {
+			result, err := client.Analyze(context.Background(), ".", nil)
+			if err != nil {
+				t.Fatalf("Analyze returned error: %v", err)
+			}
+
+			var hotspots []string
+			for _, hs := range result.Hotspots {
+				hotspots = append(hotspots, hs.Symbol)
+			}
+			if got := strings.Join(hotspots, ","); got != "a2,c,a1,b" {
+				t.Errorf("unexpected hotspot order %s", got)
+			}
+
+			var symbols []string
+			for _, s := range result.Symbols {
+				symbols = append(symbols, s.Name)
+			}
+			if got := strings.Join(symbols, ","); got != "x,y,z" {
+				t.Errorf("unexpected symbol order %s", got)
+			}
+		}
+	})
+}
+
+func TestEmbedProgress(t *testing.T) {
+	var mu sync.Mutex
+	var batchSizes []int
+
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Files []struct {
+				Path string `json:"path"`
+			} `json:"files"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		batchSizes = append(batchSizes, len(req.Files))
+		mu.Unlock()
+
+		embeddings := make(map[string][]float32)
+		for _, f := range req.Files {
+			embeddings[f.Path] = []float32{1, 2}
+		}
+		writeAPIResponse(w, embeddings)
+	})
+
+	files := make([]models.FileInfo, 100)
+	for i := range files {
+		files[i] = models.FileInfo{Path: fmt.Sprintf("file%d.py", i)}
+	}
+
+	client := NewClient(server.URL)
+	client.SetEmbedBatchSize(25)
+
+	var progress []int
+	embeddings, err := client.EmbedProgress(context.Background(), files, func(done, total int) {
+		if total != 100 {
+			t.Errorf("expected total 100, got %d", total)
+		}
+		progress = append(progress, done)
+	})
+	if err != nil {
+		t.Fatalf("EmbedProgress returned error: %v", err)
+	}
+
+	if len(embeddings) != 100 {
+		t.Errorf("expected 100 embeddings, got %d", len(embeddings))
+	}
+
+	expected := []int{25, 50, 75, 100}
+	if fmt.Sprint(progress) != fmt.Sprint(expected) {
+		t.Errorf("expected progress %v, got %v", expected, progress)
+	}
+
+	for _, size := range batchSizes {
+		if size != 25 {
+			t.Errorf("expected batches of 25, got %v", batchSizes)
+			break
+		}
+	}
+}
+
+func TestEmbedDelta(t *testing.T) {
+	var sent []string
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Files []struct {
+				Path string `json:"path"`
+			} `json:"files"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		embeddings := make(map[string][]float32)
+		for _, f := range req.Files {
+			sent = append(sent, f.Path)
+			embeddings[f.Path] = []float32{1, 2}
+		}
+		writeAPIResponse(w, embeddings)
+	})
+
+	files := make([]models.FileInfo, 5)
+	known := make(map[string]string)
+	for i := range files {
+		files[i] = models.FileInfo{Path: fmt.Sprintf("file%d.py", i), Hash: fmt.Sprintf("hash%d", i)}
+		if i < 3 {
+			known[files[i].Path] = files[i].Hash
+		}
+	}
+	known["file3.py"] = "stale"
+
+	client := NewClient(server.URL)
+	embeddings, err := client.EmbedDelta(context.Background(), files, known)
+	if err != nil {
+		t.Fatalf("EmbedDelta returned error: %v", err)
+	}
+
+	if fmt.Sprint(sent) != "[file3.py file4.py]" {
+		t.Errorf("expected only changed files to be sent, got %v", sent)
+	}
+	if len(embeddings) != 2 {
+		t.Errorf("expected 2 embeddings, got %d", len(embeddings))
+	}
+	if _, ok := embeddings["file3.py"]; !ok {
+		t.Error("expected embedding for changed file3.py")
+	}
+}
+
+func TestIdiomatizeLang(t *testing.T) {
+	var received map[string]interface{}
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeAPIResponse(w, models.RefactorPlan{SessionID: "idiom-1"})
+	})
+
+	client := NewClient(server.URL)
+	plan, err := client.IdiomatizeLang(context.Background(), "src", models.LanguagePython)
+	if err != nil {
+		t.Fatalf("IdiomatizeLang returned error: %v", err)
+	}
+
+	if plan.SessionID != "idiom-1" {
+		t.Errorf("expected session idiom-1, got %s", plan.SessionID)
+	}
+	if received["language"] != "python" {
+		t.Errorf("expected language python to be serialized, got %v", received["language"])
+	}
+}
+
+func TestAnalyzePublicOnly(t *testing.T) {
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		writeAPIResponse(w, map[string]interface{}{
+			"total_files":   3,
+			"total_symbols": 7,
+			"symbols": []map[string]interface{}{
+				{"name": "Serve", "file": "server.go"},
+				{"name": "handle", "file": "server.go"},
+				{"name": "load_config", "file": "config.py"},
+				{"name": "_parse", "file": "config.py"},
+				{"name": "render", "file": "view.ts", "signature": "export function render()"},
+				{"name": "helper", "file": "view.ts", "signature": "function helper()"},
+				{"name": "Internal", "file": "server.go", "exported": false},
+			},
+		})
+	})
+
+	tests := []struct {
+		name       string
+		publicOnly bool
+		expected   []string
+	}{
+		{
+			name:     "all symbols",
+			expected: []string{"Serve", "handle", "load_config", "_parse", "render", "helper", "Internal"},
+		},
+		{
+			name:       "public only",
+			publicOnly: true,
+			expected:   []string{"Serve", "load_config", "render"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(server.URL)
+			client.SetPublicOnly(tt.publicOnly)
+
+			result, err := client.Analyze(context.Background(), ".", nil)
+			if err != nil {
+				t.Fatalf("Analyze returned error: %v", err)
+			}
+
+			var names []string
+			for _, sym := range result.Symbols {
+				names = append(names, sym.Name)
+			}
+			if fmt.Sprint(names) != fmt.Sprint(tt.expected) {
+				t.Errorf("expected symbols %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestEmbedSplitsOnPayloadTooLarge(t *testing.T) {
+	var mu sync.Mutex
+	var accepted []int
+	rejected := 0
+
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Files []struct {
+				Path string `json:"path"`
+			} `json:"files"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(req.Files) > 10 {
+			rejected++
+			http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		accepted = append(accepted, len(req.Files))
+
+		embeddings := make(map[string][]float32)
+		for _, f := range req.Files {
+			embeddings[f.Path] = []float32{1}
+		}
+		writeAPIResponse(w, embeddings)
+	})
+
+	files := make([]models.FileInfo, 25)
+	for i := range files {
+		files[i] = models.FileInfo{Path: fmt.Sprintf("file%d.py", i)}
+	}
+
+	client := NewClient(server.URL)
+	embeddings, err := client.Embed(context.Background(), files)
+	if err != nil {
+		t.Fatalf("Embed returned error: %v", err)
+	}
+
+	if len(embeddings) != 25 {
+		t.Errorf("expected 25 embeddings, got %d", len(embeddings))
+	}
+	if rejected == 0 {
+		t.Error("expected oversized batches to be rejected")
+	}
+	for _, size := range accepted {
+		if size > 10 {
+			t.Errorf("expected accepted batches of at most 10, got %v", accepted)
+		}
+	}
+
+	t.Run("single file too large", func(t *testing.T) {
+		big := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		})
+
+		_, err := NewClient(big.URL).Embed(context.Background(), files[:4])
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusRequestEntityTooLarge {
+			t.Errorf("expected 413 StatusError, got %v", err)
+		}
+	})
+}
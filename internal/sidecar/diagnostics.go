@@ -0,0 +1,68 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Diagnostics struct {
+	Status         string        `json:"status"`
+	SidecarVersion string        `json:"version"`
+	PythonVersion  string        `json:"python_version"`
+	ModelLoaded    bool          `json:"model_loaded"`
+	Latency        time.Duration `json:"-"`
+}
+
+func (c *Client) Ping(ctx context.Context) (time.Duration, error) {
+	_, latency, err := c.health(ctx)
+	return latency, err
+}
+
+func (c *Client) Diagnostics(ctx context.Context) (*Diagnostics, error) {
+	diag, latency, err := c.health(ctx)
+	if err != nil {
+		return nil, err
+	}
+	diag.Latency = latency
+	return diag, nil
+}
+
+func (c *Client) health(ctx context.Context) (*Diagnostics, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/health", nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	reqID, err := requestID(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set(requestIDHeader, reqID)
+
+	if err := waitRateLimit(ctx, c.limiter); err != nil {
+		return nil, 0, fmt.Errorf("rate limiter: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("health check failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var diag Diagnostics
+	decodeErr := json.NewDecoder(resp.Body).Decode(&diag)
+	latency := time.Since(start)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, latency, fmt.Errorf("health check failed: status %d (request %s)", resp.StatusCode, reqID)
+	}
+	if decodeErr != nil {
+		return nil, latency, fmt.Errorf("failed to decode health response: %w", decodeErr)
+	}
+
+	return &diag, latency, nil
+}
@@ -0,0 +1,78 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPing(t *testing.T) {
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			t.Errorf("expected /health, got %s", r.URL.Path)
+		}
+		time.Sleep(5 * time.Millisecond)
+		json.NewEncoder(w).Encode(map[string]interface{}{"status": "healthy"})
+	})
+
+	client := NewClient(server.URL)
+	latency, err := client.Ping(context.Background())
+	if err != nil {
+		t.Fatalf("Ping returned error: %v", err)
+	}
+	if latency < 5*time.Millisecond {
+		t.Errorf("expected latency of at least 5ms, got %v", latency)
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	t.Run("healthy sidecar", func(t *testing.T) {
+		server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"status":         "healthy",
+				"service":        "ai_sidecar",
+				"version":        "0.1.0",
+				"python_version": "3.11.4",
+				"model_loaded":   true,
+			})
+		})
+
+		client := NewClient(server.URL)
+		diag, err := client.Diagnostics(context.Background())
+		if err != nil {
+			t.Fatalf("Diagnostics returned error: %v", err)
+		}
+
+		if diag.Status != "healthy" {
+			t.Errorf("expected status healthy, got %s", diag.Status)
+		}
+		if diag.SidecarVersion != "0.1.0" {
+			t.Errorf("expected sidecar version 0.1.0, got %s", diag.SidecarVersion)
+		}
+		if diag.PythonVersion != "3.11.4" {
+			t.Errorf("expected python version 3.11.4, got %s", diag.PythonVersion)
+		}
+		if !diag.ModelLoaded {
+			t.Error("expected model to be loaded")
+		}
+		if diag.Latency <= 0 {
+			t.Errorf("expected positive latency, got %v", diag.Latency)
+		}
+	})
+
+	t.Run("unhealthy sidecar", func(t *testing.T) {
+		server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		client := NewClient(server.URL)
+		if _, err := client.Diagnostics(context.Background()); err == nil {
+			t.Error("expected error for unavailable sidecar")
+		}
+		if _, err := client.Ping(context.Background()); err == nil {
+			t.Error("expected ping error for unavailable sidecar")
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package sidecar
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/alexkarsten/reducto/internal/walker"
+)
+
+type DirSummary struct {
+	Files             int                `json:"files"`
+	Symbols           int                `json:"symbols"`
+	AverageComplexity float64            `json:"average_complexity"`
+	WorstHotspot      *ComplexityHotspot `json:"worst_hotspot,omitempty"`
+
+	complexityTotal int
+	hotspots        int
+}
+
+func (c *Client) AnalyzeByDirectory(ctx context.Context, root string) (map[string]*DirSummary, error) {
+	collected, err := walker.CollectFilesWithOptions(root, walker.CollectOptions{ForceLanguage: c.forceLanguage})
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect files: %w", err)
+	}
+	if len(collected.Files) == 0 {
+		return make(map[string]*DirSummary), nil
+	}
+
+	result, err := c.Analyze(ctx, root, collected.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[string]*DirSummary)
+	summaryFor := func(file string) *DirSummary {
+		dir := path.Dir(filepath.ToSlash(file))
+		s, ok := summaries[dir]
+		if !ok {
+			s = &DirSummary{}
+			summaries[dir] = s
+		}
+		return s
+	}
+
+	for _, f := range collected.Files {
+		summaryFor(f.Path).Files++
+	}
+	for _, sym := range result.Symbols {
+		summaryFor(sym.File).Symbols++
+	}
+	for i := range result.Hotspots {
+		hs := result.Hotspots[i]
+		s := summaryFor(hs.File)
+		s.complexityTotal += hs.CyclomaticComplexity
+		s.hotspots++
+		if s.WorstHotspot == nil || hs.CyclomaticComplexity > s.WorstHotspot.CyclomaticComplexity {
+			s.WorstHotspot = &hs
+		}
+	}
+
+	for _, s := range summaries {
+		if s.hotspots > 0 {
+			s.AverageComplexity = float64(s.complexityTotal) / float64(s.hotspots)
+		}
+	}
+
+	return summaries, nil
+}
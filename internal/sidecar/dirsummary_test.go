@@ -0,0 +1,77 @@
+package sidecar
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAnalyzeByDirectory(t *testing.T) {
+	repoDir := t.TempDir()
+	files := map[string]string{
+		"api/handlers.py": "def get():\n    pass\n",
+		"api/routes.py":   "def route():\n    pass\n",
+		"core/engine.py":  "def run():\n    pass\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(repoDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		writeAPIResponse(w, map[string]interface{}{
+			"total_files":   3,
+			"total_symbols": 4,
+			"symbols": []map[string]interface{}{
+				{"name": "get", "file": "api/handlers.py"},
+				{"name": "route", "file": "api/routes.py"},
+				{"name": "helper", "file": "api/routes.py"},
+				{"name": "run", "file": "core/engine.py"},
+			},
+			"hotspots": []map[string]interface{}{
+				{"file": "api/handlers.py", "line": 1, "symbol": "get", "cyclomatic_complexity": 4},
+				{"file": "api/routes.py", "line": 1, "symbol": "route", "cyclomatic_complexity": 12},
+				{"file": "core/engine.py", "line": 1, "symbol": "run", "cyclomatic_complexity": 7},
+			},
+		})
+	})
+
+	client := NewClient(server.URL)
+	summaries, err := client.AnalyzeByDirectory(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("AnalyzeByDirectory returned error: %v", err)
+	}
+
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 directories, got %d", len(summaries))
+	}
+
+	api := summaries["api"]
+	if api == nil {
+		t.Fatal("expected summary for api")
+	}
+	if api.Files != 2 || api.Symbols != 3 {
+		t.Errorf("expected api files=2 symbols=3, got files=%d symbols=%d", api.Files, api.Symbols)
+	}
+	if api.AverageComplexity != 8 {
+		t.Errorf("expected api average complexity 8, got %v", api.AverageComplexity)
+	}
+	if api.WorstHotspot == nil || api.WorstHotspot.Symbol != "route" {
+		t.Errorf("expected worst api hotspot route, got %+v", api.WorstHotspot)
+	}
+
+	core := summaries["core"]
+	if core == nil {
+		t.Fatal("expected summary for core")
+	}
+	if core.Files != 1 || core.Symbols != 1 || core.AverageComplexity != 7 {
+		t.Errorf("unexpected core summary %+v", core)
+	}
+}
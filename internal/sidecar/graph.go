@@ -0,0 +1,71 @@
+package sidecar
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type DependencyGraph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+type GraphNode struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	File string `json:"file,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+func (c *Client) AnalyzeGraph(ctx context.Context, path string) (*DependencyGraph, error) {
+	if err := validateTarget("analyze graph", path, nil); err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"path": path,
+	}
+
+	var graph DependencyGraph
+	if err := c.post(ctx, "/analyze/graph", body, &graph); err != nil {
+		return nil, fmt.Errorf("analyze graph failed: %w", err)
+	}
+
+	return &graph, nil
+}
+
+func (g *DependencyGraph) ToDOT() string {
+	var sb strings.Builder
+
+	sb.WriteString("digraph dependencies {\n")
+	for _, node := range g.Nodes {
+		label := node.Name
+		if label == "" {
+			label = node.ID
+		}
+		sb.WriteString(fmt.Sprintf("  %s [label=%s, shape=%s];\n",
+			strconv.Quote(node.ID), strconv.Quote(label), dotShape(node.Kind)))
+	}
+	for _, edge := range g.Edges {
+		sb.WriteString(fmt.Sprintf("  %s -> %s [label=%s];\n",
+			strconv.Quote(edge.From), strconv.Quote(edge.To), strconv.Quote(edge.Kind)))
+	}
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+func dotShape(kind string) string {
+	if kind == "file" {
+		return "box"
+	}
+	return "ellipse"
+}
@@ -0,0 +1,70 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeGraph(t *testing.T) {
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/analyze/graph" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req["path"] != "src" {
+			http.Error(w, "unexpected path", http.StatusBadRequest)
+			return
+		}
+
+		writeAPIResponse(w, map[string]interface{}{
+			"nodes": []map[string]interface{}{
+				{"id": "app.py", "kind": "file"},
+				{"id": "app.py::main", "kind": "function", "file": "app.py", "name": "main"},
+				{"id": "util.py::helper", "kind": "function", "file": "util.py", "name": "helper"},
+			},
+			"edges": []map[string]interface{}{
+				{"from": "app.py", "to": "util.py::helper", "kind": "import"},
+				{"from": "app.py::main", "to": "util.py::helper", "kind": "call"},
+			},
+		})
+	})
+
+	client := NewClient(server.URL)
+	graph, err := client.AnalyzeGraph(context.Background(), "src")
+	if err != nil {
+		t.Fatalf("AnalyzeGraph returned error: %v", err)
+	}
+
+	if len(graph.Nodes) != 3 || len(graph.Edges) != 2 {
+		t.Fatalf("expected 3 nodes and 2 edges, got %d and %d", len(graph.Nodes), len(graph.Edges))
+	}
+	if graph.Nodes[1].Name != "main" || graph.Nodes[1].File != "app.py" {
+		t.Errorf("unexpected node %+v", graph.Nodes[1])
+	}
+	if graph.Edges[1].Kind != "call" {
+		t.Errorf("unexpected edge %+v", graph.Edges[1])
+	}
+
+	dot := graph.ToDOT()
+	if !strings.HasPrefix(dot, "digraph dependencies {\n") || !strings.HasSuffix(dot, "}\n") {
+		t.Errorf("expected DOT digraph wrapper, got:\n%s", dot)
+	}
+	for _, want := range []string{
+		`"app.py" [label="app.py", shape=box];`,
+		`"app.py::main" [label="main", shape=ellipse];`,
+		`"app.py::main" -> "util.py::helper" [label="call"];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("expected DOT output to contain %s, got:\n%s", want, dot)
+		}
+	}
+	if strings.Count(dot, "{") != strings.Count(dot, "}") {
+		t.Error("expected balanced braces in DOT output")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
@@ -23,6 +24,35 @@ type AnalyzeResult struct {
 	TotalFiles   int                 `json:"total_files"`
 	TotalSymbols int                 `json:"total_symbols"`
 	Hotspots     []ComplexityHotspot `json:"hotspots"`
+	Symbols      []models.Symbol     `json:"symbols,omitempty"`
+	ParseErrors  []ParseError        `json:"parse_errors,omitempty"`
+}
+
+func (r *AnalyzeResult) Sort() {
+	sort.SliceStable(r.Hotspots, func(i, j int) bool {
+		a, b := r.Hotspots[i], r.Hotspots[j]
+		if a.CyclomaticComplexity != b.CyclomaticComplexity {
+			return a.CyclomaticComplexity > b.CyclomaticComplexity
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Symbol < b.Symbol
+	})
+
+	sort.SliceStable(r.Symbols, func(i, j int) bool {
+		a, b := r.Symbols[i], r.Symbols[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.StartLine != b.StartLine {
+			return a.StartLine < b.StartLine
+		}
+		return a.Name < b.Name
+	})
 }
 
 type ComplexityHotspot struct {
@@ -41,6 +71,9 @@ type MCPManager struct {
 	cmd        *exec.Cmd
 	resultChan chan map[string]interface{}
 	mu         sync.Mutex
+	procMu     sync.Mutex
+	warnings   []string
+	runCheck   func(name string, args ...string) error
 }
 
 func NewMCPManager(rootDir string, cfg *models.Config) *MCPManager {
@@ -48,33 +81,43 @@ func NewMCPManager(rootDir string, cfg *models.Config) *MCPManager {
 		rootDir:    rootDir,
 		cfg:        cfg,
 		resultChan: make(chan map[string]interface{}, 1),
+		runCheck: func(name string, args ...string) error {
+			return exec.Command(name, args...).Run()
+		},
 	}
 }
 
+func (m *MCPManager) Warnings() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	warnings := make([]string, len(m.warnings))
+	copy(warnings, m.warnings)
+	return warnings
+}
+
+func (m *MCPManager) addWarning(warning string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.warnings = append(m.warnings, warning)
+}
+
 func (m *MCPManager) Start(command, path string) error {
-	if err := m.checkPythonInstalled(); err != nil {
-		return err
-	}
+	m.mu.Lock()
+	m.warnings = nil
+	m.mu.Unlock()
 
 	sidecarPath := m.findSidecarPath()
 	if sidecarPath == "" {
 		return fmt.Errorf("could not find ai_sidecar module")
 	}
 
-	args := []string{
-		"-m", "ai_sidecar.mcp_entry",
-		"--root", path,
-		"--command", command,
-	}
-
-	if m.cfg != nil && m.cfg.Verbose {
-		args = append(args, "--verbose")
+	cmd, err := m.buildCommand(command, path, sidecarPath)
+	if err != nil {
+		return err
 	}
 
-	m.cmd = exec.Command("python3", args...)
-	m.cmd.Dir = sidecarPath
-	m.cmd.Env = append(os.Environ(), "PYTHONUNBUFFERED=1")
-
 	serverIn, clientOut, err := os.Pipe()
 	if err != nil {
 		return fmt.Errorf("failed to create pipe: %w", err)
@@ -87,10 +130,10 @@ func (m *MCPManager) Start(command, path string) error {
 		return fmt.Errorf("failed to create pipe: %w", err)
 	}
 
-	m.cmd.Stdin = clientIn
-	m.cmd.Stdout = clientOut
+	cmd.Stdin = clientIn
+	cmd.Stdout = clientOut
 
-	stderrPipe, err := m.cmd.StderrPipe()
+	stderrPipe, err := cmd.StderrPipe()
 	if err != nil {
 		serverIn.Close()
 		clientOut.Close()
@@ -101,11 +144,11 @@ func (m *MCPManager) Start(command, path string) error {
 
 	go m.readResultFromStderr(stderrPipe)
 
-	m.cmd.SysProcAttr = &syscall.SysProcAttr{
+	cmd.SysProcAttr = &syscall.SysProcAttr{
 		Setpgid: true,
 	}
 
-	if err := m.cmd.Start(); err != nil {
+	if err := cmd.Start(); err != nil {
 		serverIn.Close()
 		clientOut.Close()
 		clientIn.Close()
@@ -113,7 +156,7 @@ func (m *MCPManager) Start(command, path string) error {
 		return fmt.Errorf("failed to start sidecar: %w", err)
 	}
 
-	m.process = m.cmd.Process
+	m.setProcess(cmd)
 
 	m.server = mcp.NewServer(m.rootDir)
 	go func() {
@@ -128,6 +171,9 @@ func (m *MCPManager) readResultFromStderr(reader io.Reader) {
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
 		line := scanner.Text()
+		if strings.HasPrefix(line, "WARNING:") {
+			m.addWarning(strings.TrimSpace(strings.TrimPrefix(line, "WARNING:")))
+		}
 		if strings.HasPrefix(line, "RESULT:") {
 			jsonStr := strings.TrimPrefix(line, "RESULT:")
 			var result map[string]interface{}
@@ -142,17 +188,31 @@ func (m *MCPManager) readResultFromStderr(reader io.Reader) {
 	}
 }
 
+func (m *MCPManager) setProcess(cmd *exec.Cmd) {
+	m.procMu.Lock()
+	defer m.procMu.Unlock()
+
+	m.cmd = cmd
+	m.process = cmd.Process
+}
+
 func (m *MCPManager) Stop() {
-	if m.process != nil {
-		if runtime.GOOS == "windows" {
-			m.process.Kill()
-		} else {
-			syscall.Kill(-m.process.Pid, syscall.SIGTERM)
-		}
-		if m.cmd != nil {
-			m.cmd.Wait()
-		}
-		m.process = nil
+	m.procMu.Lock()
+	process, cmd := m.process, m.cmd
+	m.process, m.cmd = nil, nil
+	m.procMu.Unlock()
+
+	if process == nil {
+		return
+	}
+
+	if runtime.GOOS == "windows" {
+		process.Kill()
+	} else {
+		syscall.Kill(-process.Pid, syscall.SIGTERM)
+	}
+	if cmd != nil {
+		cmd.Wait()
 	}
 }
 
@@ -217,6 +277,16 @@ func (m *MCPManager) Analyze(path string) (*AnalyzeResult, error) {
 		}
 	}
 
+	if symbols, ok := data["symbols"].([]interface{}); ok {
+		raw, err := json.Marshal(symbols)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode symbols: %w", err)
+		}
+		if err := json.Unmarshal(raw, &analyzeResult.Symbols); err != nil {
+			return nil, fmt.Errorf("failed to decode symbols: %w", err)
+		}
+	}
+
 	return analyzeResult, nil
 }
 
@@ -296,15 +366,57 @@ func (m *MCPManager) parsePlan(result map[string]interface{}) (*models.RefactorP
 	return plan, nil
 }
 
-func (m *MCPManager) checkPythonInstalled() error {
-	cmd := exec.Command("python3", "--version")
-	if err := cmd.Run(); err != nil {
-		cmd = exec.Command("python", "--version")
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("python3 is not installed or not in PATH")
-		}
+func (m *MCPManager) buildCommand(command, path, sidecarPath string) (*exec.Cmd, error) {
+	python, err := m.resolvePython()
+	if err != nil {
+		return nil, err
 	}
-	return nil
+
+	args := []string{
+		"-m", "ai_sidecar.mcp_entry",
+		"--root", path,
+		"--command", command,
+	}
+
+	if m.cfg != nil && m.cfg.Verbose {
+		args = append(args, "--verbose")
+	}
+
+	if m.cfg != nil {
+		args = append(args, m.cfg.Sidecar.ExtraArgs...)
+	}
+
+	cmd := exec.Command(python, args...)
+	cmd.Dir = sidecarPath
+	cmd.Env = append(os.Environ(), "PYTHONUNBUFFERED=1")
+
+	return cmd, nil
+}
+
+func (m *MCPManager) resolvePython() (string, error) {
+	if m.cfg == nil || m.cfg.Sidecar.PythonPath == "" {
+		return m.findPython()
+	}
+
+	python, err := exec.LookPath(m.cfg.Sidecar.PythonPath)
+	if err != nil {
+		return "", fmt.Errorf("configured python interpreter %s is not usable: %w", m.cfg.Sidecar.PythonPath, err)
+	}
+
+	return python, nil
+}
+
+func (m *MCPManager) findPython() (string, error) {
+	if err := m.runCheck("python3", "--version"); err == nil {
+		return "python3", nil
+	}
+
+	if err := m.runCheck("python", "--version"); err != nil {
+		return "", fmt.Errorf("python3 is not installed or not in PATH")
+	}
+
+	m.addWarning("python3 not found in PATH; using fallback `python` interpreter")
+	return "python", nil
 }
 
 func (m *MCPManager) findSidecarPath() string {
@@ -353,13 +465,13 @@ func (m *MCPManager) isValidSidecarPath(path string) bool {
 }
 
 func (m *MCPManager) IsRunning() bool {
-	if m.process == nil {
-		return false
-	}
+	m.procMu.Lock()
+	process := m.process
+	m.procMu.Unlock()
 
-	if runtime.GOOS == "windows" {
-		return m.process.Signal(syscall.Signal(0)) == nil
+	if process == nil {
+		return false
 	}
 
-	return m.process.Signal(syscall.Signal(0)) == nil
+	return process.Signal(syscall.Signal(0)) == nil
 }
@@ -0,0 +1,169 @@
+package sidecar
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestFindPythonWarnings(t *testing.T) {
+	tests := []struct {
+		name      string
+		available map[string]bool
+		expected  string
+		warnings  int
+		wantErr   bool
+	}{
+		{
+			name:      "python3 available",
+			available: map[string]bool{"python3": true, "python": true},
+			expected:  "python3",
+		},
+		{
+			name:      "fallback to python",
+			available: map[string]bool{"python": true},
+			expected:  "python",
+			warnings:  1,
+		},
+		{
+			name:      "no interpreter",
+			available: map[string]bool{},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMCPManager(t.TempDir(), nil)
+			m.runCheck = func(name string, args ...string) error {
+				if tt.available[name] {
+					return nil
+				}
+				return errors.New("not found")
+			}
+
+			python, err := m.findPython()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error when no interpreter is available")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findPython returned error: %v", err)
+			}
+			if python != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, python)
+			}
+
+			warnings := m.Warnings()
+			if len(warnings) != tt.warnings {
+				t.Fatalf("expected %d warnings, got %v", tt.warnings, warnings)
+			}
+			if tt.warnings > 0 && !strings.Contains(warnings[0], "fallback `python`") {
+				t.Errorf("unexpected warning %q", warnings[0])
+			}
+		})
+	}
+}
+
+func TestReadResultFromStderrWarnings(t *testing.T) {
+	m := NewMCPManager(t.TempDir(), nil)
+	m.readResultFromStderr(strings.NewReader("starting\nWARNING: model download in progress\nRESULT:{\"ok\":true}\n"))
+
+	warnings := m.Warnings()
+	if len(warnings) != 1 || warnings[0] != "model download in progress" {
+		t.Errorf("unexpected warnings %v", warnings)
+	}
+}
+
+func TestBuildCommand(t *testing.T) {
+	t.Run("configured interpreter and extra args", func(t *testing.T) {
+		python := filepath.Join(t.TempDir(), "python")
+		if err := os.WriteFile(python, []byte("#!/bin/sh\n"), 0755); err != nil {
+			t.Fatalf("failed to write interpreter: %v", err)
+		}
+
+		cfg := &models.Config{}
+		cfg.Sidecar.PythonPath = python
+		cfg.Sidecar.ExtraArgs = []string{"--log-level", "debug", "--device", "cpu"}
+
+		m := NewMCPManager(t.TempDir(), cfg)
+		m.runCheck = func(name string, args ...string) error {
+			t.Errorf("unexpected interpreter probe for %s", name)
+			return nil
+		}
+
+		cmd, err := m.buildCommand("analyze", "/repo", "/sidecar")
+		if err != nil {
+			t.Fatalf("buildCommand returned error: %v", err)
+		}
+
+		if cmd.Path != python {
+			t.Errorf("expected interpreter %s, got %s", python, cmd.Path)
+		}
+		if cmd.Dir != "/sidecar" {
+			t.Errorf("expected dir /sidecar, got %s", cmd.Dir)
+		}
+
+		expected := []string{python, "-m", "ai_sidecar.mcp_entry", "--root", "/repo", "--command", "analyze", "--log-level", "debug", "--device", "cpu"}
+		if strings.Join(cmd.Args, " ") != strings.Join(expected, " ") {
+			t.Errorf("expected args %v, got %v", expected, cmd.Args)
+		}
+	})
+
+	t.Run("missing interpreter", func(t *testing.T) {
+		cfg := &models.Config{}
+		cfg.Sidecar.PythonPath = filepath.Join(t.TempDir(), "missing", "python")
+
+		m := NewMCPManager(t.TempDir(), cfg)
+		if _, err := m.buildCommand("analyze", "/repo", "/sidecar"); err == nil {
+			t.Error("expected error for missing interpreter")
+		}
+	})
+}
+
+func TestMCPManagerConcurrentIsRunningAndStop(t *testing.T) {
+	cmd := exec.Command("sleep", "30")
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		t.Skipf("cannot start helper process: %v", err)
+	}
+
+	m := NewMCPManager(t.TempDir(), &models.Config{})
+	m.setProcess(cmd)
+
+	if !m.IsRunning() {
+		t.Fatal("expected manager to report a running process")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.IsRunning()
+			}
+		}()
+	}
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Stop()
+		}()
+	}
+	wg.Wait()
+
+	if m.IsRunning() {
+		t.Error("expected process to be stopped")
+	}
+}
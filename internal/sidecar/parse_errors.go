@@ -0,0 +1,27 @@
+package sidecar
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ParseError struct {
+	File    string `json:"file"`
+	Message string `json:"message"`
+}
+
+type ParseFailedError struct {
+	Errors []ParseError
+}
+
+func (e *ParseFailedError) Error() string {
+	files := make([]string, len(e.Errors))
+	for i, pe := range e.Errors {
+		files[i] = pe.File
+	}
+	return fmt.Sprintf("%d file(s) could not be parsed: %s", len(e.Errors), strings.Join(files, ", "))
+}
+
+func (c *Client) SetStrictParse(strict bool) {
+	c.strictParse = strict
+}
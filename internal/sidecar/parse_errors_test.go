@@ -0,0 +1,57 @@
+package sidecar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestAnalyzeParseErrors(t *testing.T) {
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		writeAPIResponse(w, AnalyzeResult{
+			TotalFiles: 2,
+			ParseErrors: []ParseError{
+				{File: "broken.py", Message: "unexpected indent at line 3"},
+			},
+		})
+	})
+
+	files := []models.FileInfo{{Path: "ok.py"}, {Path: "broken.py"}}
+
+	tests := []struct {
+		name   string
+		strict bool
+	}{
+		{name: "lenient", strict: false},
+		{name: "strict", strict: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(server.URL)
+			client.SetStrictParse(tt.strict)
+
+			result, err := client.Analyze(context.Background(), "", files)
+			if tt.strict {
+				var parseErr *ParseFailedError
+				if !errors.As(err, &parseErr) {
+					t.Fatalf("expected ParseFailedError, got %v", err)
+				}
+				if len(parseErr.Errors) != 1 || parseErr.Errors[0].File != "broken.py" {
+					t.Errorf("unexpected parse errors %v", parseErr.Errors)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Analyze returned error: %v", err)
+			}
+			if len(result.ParseErrors) != 1 || result.ParseErrors[0].File != "broken.py" {
+				t.Errorf("expected parse errors as warnings, got %v", result.ParseErrors)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package sidecar
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *Client) CanApplyPattern(ctx context.Context, pattern, path string) (bool, []string, error) {
+	if strings.TrimSpace(pattern) == "" {
+		return false, nil, NewValidationError("pattern check", "pattern required")
+	}
+	if err := validateTarget("pattern check", path, nil); err != nil {
+		return false, nil, err
+	}
+
+	body := map[string]interface{}{
+		"pattern": pattern,
+		"path":    path,
+	}
+
+	var resp struct {
+		Applicable bool     `json:"applicable"`
+		Reasons    []string `json:"reasons"`
+	}
+	if err := c.post(ctx, "/pattern/check", body, &resp); err != nil {
+		return false, nil, fmt.Errorf("pattern check failed: %w", err)
+	}
+
+	return resp.Applicable, resp.Reasons, nil
+}
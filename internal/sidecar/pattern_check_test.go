@@ -0,0 +1,43 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCanApplyPattern(t *testing.T) {
+	var received map[string]interface{}
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pattern/check" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		writeAPIResponse(w, map[string]interface{}{
+			"applicable": false,
+			"reasons":    []string{"no candidate functions found"},
+		})
+	})
+
+	client := NewClient(server.URL)
+	ok, reasons, err := client.CanApplyPattern(context.Background(), "strategy", "src")
+	if err != nil {
+		t.Fatalf("CanApplyPattern returned error: %v", err)
+	}
+
+	if ok {
+		t.Error("expected pattern to be reported as not applicable")
+	}
+	if len(reasons) != 1 || reasons[0] != "no candidate functions found" {
+		t.Errorf("unexpected reasons %v", reasons)
+	}
+	if received["pattern"] != "strategy" || received["path"] != "src" {
+		t.Errorf("unexpected request body %v", received)
+	}
+
+	if _, _, err := client.CanApplyPattern(context.Background(), " ", "src"); err == nil {
+		t.Error("expected validation error for empty pattern")
+	}
+}
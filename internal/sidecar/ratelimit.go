@@ -0,0 +1,21 @@
+package sidecar
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+func newRateLimiter(requestsPerSecond float64) *rate.Limiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), 1)
+}
+
+func waitRateLimit(ctx context.Context, limiter *rate.Limiter) error {
+	if limiter == nil {
+		return ctx.Err()
+	}
+	return limiter.Wait(ctx)
+}
@@ -0,0 +1,66 @@
+package sidecar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSetRateLimit(t *testing.T) {
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		writeAPIResponse(w, map[string][]float32{"a.py": {1}})
+	})
+
+	t.Run("limited requests are spaced out", func(t *testing.T) {
+		client := NewClient(server.URL)
+		client.SetRateLimit(20)
+
+		const requests = 5
+		start := time.Now()
+		for i := 0; i < requests; i++ {
+			if err := client.post(context.Background(), "/embed", nil, nil); err != nil {
+				t.Fatalf("request %d failed: %v", i, err)
+			}
+		}
+
+		minimum := time.Duration(requests-1) * time.Second / 20
+		if elapsed := time.Since(start); elapsed < minimum {
+			t.Errorf("expected %d requests to take at least %v, took %v", requests, minimum, elapsed)
+		}
+	})
+
+	t.Run("waiting respects cancellation", func(t *testing.T) {
+		client := NewClient(server.URL)
+		client.SetRateLimit(0.1)
+
+		if err := client.post(context.Background(), "/embed", nil, nil); err != nil {
+			t.Fatalf("first request failed: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		if err := client.post(ctx, "/embed", nil, nil); err == nil {
+			t.Fatal("expected the wait to be abandoned before the deadline")
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("expected wait to give up promptly, took %v", elapsed)
+		}
+
+		cancelled, cancelNow := context.WithCancel(context.Background())
+		cancelNow()
+		if err := client.post(cancelled, "/embed", nil, nil); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context canceled, got %v", err)
+		}
+	})
+
+	t.Run("unlimited by default", func(t *testing.T) {
+		client := NewClient(server.URL)
+		if client.limiter != nil {
+			t.Error("expected no rate limiter by default")
+		}
+	})
+}
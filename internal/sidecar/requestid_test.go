@@ -0,0 +1,68 @@
+package sidecar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRequestIDHeader(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, r.Header.Get(requestIDHeader))
+		mu.Unlock()
+
+		if r.URL.Path == "/fail" {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		writeAPIResponse(w, nil)
+	})
+
+	client := NewClient(server.URL)
+
+	t.Run("generated when absent", func(t *testing.T) {
+		received = nil
+		for i := 0; i < 2; i++ {
+			if err := client.post(context.Background(), "/ok", nil, nil); err != nil {
+				t.Fatalf("post returned error: %v", err)
+			}
+		}
+		if len(received) != 2 || received[0] == "" || received[1] == "" {
+			t.Fatalf("expected generated request IDs, got %v", received)
+		}
+		if received[0] == received[1] {
+			t.Errorf("expected unique request IDs, got %q twice", received[0])
+		}
+	})
+
+	t.Run("taken from context", func(t *testing.T) {
+		received = nil
+		ctx := WithRequestID(context.Background(), "trace-42")
+		if err := client.post(ctx, "/ok", nil, nil); err != nil {
+			t.Fatalf("post returned error: %v", err)
+		}
+		if len(received) != 1 || received[0] != "trace-42" {
+			t.Errorf("expected request ID trace-42, got %v", received)
+		}
+	})
+
+	t.Run("included in errors", func(t *testing.T) {
+		ctx := WithRequestID(context.Background(), "trace-43")
+		err := client.post(ctx, "/fail", nil, nil)
+
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) {
+			t.Fatalf("expected StatusError, got %v", err)
+		}
+		if statusErr.RequestID != "trace-43" || !strings.Contains(err.Error(), "trace-43") {
+			t.Errorf("expected request ID in error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/alexkarsten/reducto/internal/walker"
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+const analyzeCacheFile = "analyze-cache.json"
+
+type analyzeCache struct {
+	Files map[string]*analyzeCacheEntry `json:"files"`
+}
+
+type analyzeCacheEntry struct {
+	Hash        string              `json:"hash"`
+	SymbolCount int                 `json:"symbol_count"`
+	Symbols     []models.Symbol     `json:"symbols,omitempty"`
+	Hotspots    []ComplexityHotspot `json:"hotspots,omitempty"`
+}
+
+func (c *Client) AnalyzeResumable(ctx context.Context, path string) (*AnalyzeResult, error) {
+	collected, err := walker.CollectFilesWithOptions(path, walker.CollectOptions{ForceLanguage: c.forceLanguage})
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect files: %w", err)
+	}
+	files := collected.Files
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+
+	cache, err := c.loadAnalyzeCache()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []models.FileInfo
+	for _, f := range files {
+		if entry, ok := cache.Files[f.Path]; !ok || entry.Hash != f.Hash {
+			pending = append(pending, f)
+		}
+	}
+
+	for start := 0; start < len(pending); start += c.analyzeBatchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		end := start + c.analyzeBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		batch := pending[start:end]
+
+		result, err := c.Analyze(ctx, path, batch)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			return nil, err
+		}
+
+		for filePath, entry := range splitAnalyzeResult(batch, result) {
+			cache.Files[filePath] = entry
+		}
+
+		if err := c.saveAnalyzeCache(cache); err != nil {
+			return nil, err
+		}
+	}
+
+	return mergeAnalyzeCache(files, cache), nil
+}
+
+func splitAnalyzeResult(batch []models.FileInfo, result *AnalyzeResult) map[string]*analyzeCacheEntry {
+	entries := make(map[string]*analyzeCacheEntry, len(batch))
+	for _, f := range batch {
+		entries[f.Path] = &analyzeCacheEntry{Hash: f.Hash}
+	}
+
+	for _, sym := range result.Symbols {
+		if entry, ok := entries[sym.File]; ok {
+			entry.Symbols = append(entry.Symbols, sym)
+			entry.SymbolCount++
+		}
+	}
+
+	for _, hs := range result.Hotspots {
+		if entry, ok := entries[hs.File]; ok {
+			entry.Hotspots = append(entry.Hotspots, hs)
+		}
+	}
+
+	// Without a symbol list the sidecar only reports a batch total; pin it
+	// to the first file so the merged total stays correct.
+	if len(result.Symbols) == 0 && len(batch) > 0 {
+		entries[batch[0].Path].SymbolCount = result.TotalSymbols
+	}
+
+	return entries
+}
+
+func mergeAnalyzeCache(files []models.FileInfo, cache *analyzeCache) *AnalyzeResult {
+	result := &AnalyzeResult{TotalFiles: len(files)}
+	for _, f := range files {
+		entry, ok := cache.Files[f.Path]
+		if !ok {
+			continue
+		}
+		result.TotalSymbols += entry.SymbolCount
+		result.Symbols = append(result.Symbols, entry.Symbols...)
+		result.Hotspots = append(result.Hotspots, entry.Hotspots...)
+	}
+	result.Sort()
+	return result
+}
+
+func (c *Client) loadAnalyzeCache() (*analyzeCache, error) {
+	cache := &analyzeCache{Files: make(map[string]*analyzeCacheEntry)}
+
+	data, err := os.ReadFile(filepath.Join(c.stateDir, analyzeCacheFile))
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read analyze cache: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cache); err != nil {
+		return nil, fmt.Errorf("failed to parse analyze cache: %w", err)
+	}
+	if cache.Files == nil {
+		cache.Files = make(map[string]*analyzeCacheEntry)
+	}
+
+	return cache, nil
+}
+
+func (c *Client) saveAnalyzeCache(cache *analyzeCache) error {
+	if err := os.MkdirAll(c.stateDir, 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("failed to marshal analyze cache: %w", err)
+	}
+
+	path := filepath.Join(c.stateDir, analyzeCacheFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write analyze cache: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write analyze cache: %w", err)
+	}
+
+	return nil
+}
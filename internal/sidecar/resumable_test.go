@@ -0,0 +1,101 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestAnalyzeResumable(t *testing.T) {
+	repoDir := t.TempDir()
+	for i := 0; i < 6; i++ {
+		content := fmt.Sprintf("def f%d():\n    return %d\n", i, i)
+		if err := os.WriteFile(filepath.Join(repoDir, fmt.Sprintf("mod%d.py", i)), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	analyzed := make(map[string]int)
+	requests := 0
+
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Files []struct {
+				Path string `json:"path"`
+			} `json:"files"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		requests++
+		interrupt := requests == 2
+		if !interrupt {
+			for _, f := range req.Files {
+				analyzed[f.Path]++
+			}
+		}
+		mu.Unlock()
+
+		if interrupt {
+			cancel()
+			http.Error(w, "interrupted", http.StatusServiceUnavailable)
+			return
+		}
+
+		var symbols []map[string]interface{}
+		for _, f := range req.Files {
+			symbols = append(symbols, map[string]interface{}{"name": "f", "type": "function", "file": f.Path, "start_line": 1, "end_line": 2})
+		}
+		writeAPIResponse(w, map[string]interface{}{
+			"total_files":   len(req.Files),
+			"total_symbols": len(req.Files),
+			"symbols":       symbols,
+		})
+	})
+
+	client := NewClient(server.URL)
+	client.SetStateDir(t.TempDir())
+	client.SetAnalyzeBatchSize(2)
+
+	if _, err := client.AnalyzeResumable(ctx, repoDir); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation error, got %v", err)
+	}
+	if len(analyzed) != 2 {
+		t.Fatalf("expected 2 files analyzed before cancellation, got %v", analyzed)
+	}
+
+	result, err := client.AnalyzeResumable(context.Background(), repoDir)
+	if err != nil {
+		t.Fatalf("AnalyzeResumable returned error: %v", err)
+	}
+
+	if result.TotalFiles != 6 || result.TotalSymbols != 6 {
+		t.Errorf("expected 6 files and 6 symbols, got %d and %d", result.TotalFiles, result.TotalSymbols)
+	}
+	if len(analyzed) != 6 {
+		t.Errorf("expected every file analyzed, got %v", analyzed)
+	}
+	for path, count := range analyzed {
+		if count != 1 {
+			t.Errorf("expected %s to be analyzed once, got %d", path, count)
+		}
+	}
+
+	before := requests
+	if _, err := client.AnalyzeResumable(context.Background(), repoDir); err != nil {
+		t.Fatalf("AnalyzeResumable returned error: %v", err)
+	}
+	if requests != before {
+		t.Error("expected fully cached run to make no requests")
+	}
+}
@@ -0,0 +1,203 @@
+package sidecar
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+type SerializedClient struct {
+	inner SidecarAPI
+	slots chan struct{}
+}
+
+var _ SidecarAPI = (*SerializedClient)(nil)
+
+func NewSerializedClient(inner SidecarAPI, maxConcurrent int) *SerializedClient {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	return &SerializedClient{
+		inner: inner,
+		slots: make(chan struct{}, maxConcurrent),
+	}
+}
+
+func (s *SerializedClient) acquire(ctx context.Context) error {
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *SerializedClient) release() {
+	<-s.slots
+}
+
+func (s *SerializedClient) Health(ctx context.Context) error {
+	return s.inner.Health(ctx)
+}
+
+func (s *SerializedClient) Ping(ctx context.Context) (time.Duration, error) {
+	return s.inner.Ping(ctx)
+}
+
+func (s *SerializedClient) Diagnostics(ctx context.Context) (*Diagnostics, error) {
+	return s.inner.Diagnostics(ctx)
+}
+
+func (s *SerializedClient) Analyze(ctx context.Context, path string, files []models.FileInfo) (*AnalyzeResult, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.Analyze(ctx, path, files)
+}
+
+func (s *SerializedClient) AnalyzeFiles(ctx context.Context, files []models.FileInfo) (*AnalyzeResult, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.AnalyzeFiles(ctx, files)
+}
+
+func (s *SerializedClient) AnalyzeTar(ctx context.Context, r io.Reader) (*AnalyzeResult, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.AnalyzeTar(ctx, r)
+}
+
+func (s *SerializedClient) AnalyzeResumable(ctx context.Context, path string) (*AnalyzeResult, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.AnalyzeResumable(ctx, path)
+}
+
+func (s *SerializedClient) AnalyzeByDirectory(ctx context.Context, root string) (map[string]*DirSummary, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.AnalyzeByDirectory(ctx, root)
+}
+
+func (s *SerializedClient) AnalyzeGraph(ctx context.Context, path string) (*DependencyGraph, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.AnalyzeGraph(ctx, path)
+}
+
+func (s *SerializedClient) LanguageStats(ctx context.Context, path string) ([]models.LanguageStat, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.LanguageStats(ctx, path)
+}
+
+func (s *SerializedClient) SuggestFix(ctx context.Context, group models.DuplicateGroup) (string, error) {
+	if err := s.acquire(ctx); err != nil {
+		return "", err
+	}
+	defer s.release()
+	return s.inner.SuggestFix(ctx, group)
+}
+
+func (s *SerializedClient) Deduplicate(ctx context.Context, path string, files []models.FileInfo, threshold float64) (*models.RefactorPlan, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.Deduplicate(ctx, path, files, threshold)
+}
+
+func (s *SerializedClient) Idiomatize(ctx context.Context, path string, files []models.FileInfo, language models.Language) (*models.RefactorPlan, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.Idiomatize(ctx, path, files, language)
+}
+
+func (s *SerializedClient) IdiomatizeLang(ctx context.Context, path string, lang models.Language) (*models.RefactorPlan, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.IdiomatizeLang(ctx, path, lang)
+}
+
+func (s *SerializedClient) CanApplyPattern(ctx context.Context, pattern, path string) (bool, []string, error) {
+	if err := s.acquire(ctx); err != nil {
+		return false, nil, err
+	}
+	defer s.release()
+	return s.inner.CanApplyPattern(ctx, pattern, path)
+}
+
+func (s *SerializedClient) ApplyPattern(ctx context.Context, pattern, path string, files []models.FileInfo) (*models.RefactorPlan, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.ApplyPattern(ctx, pattern, path, files)
+}
+
+func (s *SerializedClient) ApplyPlan(ctx context.Context, sessionID string) (*models.RefactorResult, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.ApplyPlan(ctx, sessionID)
+}
+
+func (s *SerializedClient) ApplyBatch(ctx context.Context, plans []*models.RefactorPlan) ([]*models.RefactorResult, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.ApplyBatch(ctx, plans)
+}
+
+func (s *SerializedClient) ResumeBatch(ctx context.Context, id string, plans []*models.RefactorPlan) ([]*models.RefactorResult, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.ResumeBatch(ctx, id, plans)
+}
+
+func (s *SerializedClient) Embed(ctx context.Context, files []models.FileInfo) (map[string][]float32, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.Embed(ctx, files)
+}
+
+func (s *SerializedClient) EmbedProgress(ctx context.Context, files []models.FileInfo, onProgress func(done, total int)) (map[string][]float32, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.EmbedProgress(ctx, files, onProgress)
+}
+
+func (s *SerializedClient) EmbedDelta(ctx context.Context, files []models.FileInfo, knownHashes map[string]string) (map[string][]float32, error) {
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+	return s.inner.EmbedDelta(ctx, files, knownHashes)
+}
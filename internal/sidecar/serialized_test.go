@@ -0,0 +1,82 @@
+package sidecar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestSerializedClientLimitsConcurrency(t *testing.T) {
+	const limit = 2
+
+	var running, peak int32
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		writeAPIResponse(w, map[string]interface{}{})
+	})
+
+	client := NewSerializedClient(NewClient(server.URL), limit)
+	files := []models.FileInfo{{Path: "a.py", Content: "x = 1"}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Analyze(context.Background(), "src", files); err != nil {
+				t.Errorf("Analyze returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > limit {
+		t.Errorf("expected at most %d concurrent operations, saw %d", limit, peak)
+	}
+	if peak < limit {
+		t.Errorf("expected operations to run in parallel up to %d, saw %d", limit, peak)
+	}
+}
+
+func TestSerializedClientQueueRespectsContext(t *testing.T) {
+	release := make(chan struct{})
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			w.Write([]byte(`{"status": "ok"}`))
+			return
+		}
+		<-release
+		writeAPIResponse(w, map[string]interface{}{})
+	})
+	defer close(release)
+
+	client := NewSerializedClient(NewClient(server.URL), 1)
+	files := []models.FileInfo{{Path: "a.py", Content: "x = 1"}}
+
+	go client.Analyze(context.Background(), "src", files)
+	time.Sleep(20 * time.Millisecond)
+
+	if err := client.Health(context.Background()); err != nil {
+		t.Errorf("expected health to bypass the queue, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Analyze(ctx, "src", files); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected queued call to time out, got %v", err)
+	}
+}
@@ -0,0 +1,281 @@
+package sidecartest
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/alexkarsten/reducto/internal/sidecar"
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+type Call struct {
+	Method string
+	Path   string
+	Files  []models.FileInfo
+	Args   map[string]interface{}
+}
+
+type FakeClient struct {
+	AnalyzeResult   *sidecar.AnalyzeResult
+	Graph           *sidecar.DependencyGraph
+	DirSummaries    map[string]*sidecar.DirSummary
+	Stats           []models.LanguageStat
+	Suggestion      string
+	PatternReasons  []string
+	DeduplicatePlan *models.RefactorPlan
+	IdiomatizePlan  *models.RefactorPlan
+	PatternPlan     *models.RefactorPlan
+	ApplyResults    map[string]*models.RefactorResult
+	Embeddings      map[string][]float32
+	DiagnosticsInfo *sidecar.Diagnostics
+	Latency         time.Duration
+	Err             error
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ sidecar.SidecarAPI = (*FakeClient)(nil)
+
+func NewFakeClient() *FakeClient {
+	return &FakeClient{
+		ApplyResults: make(map[string]*models.RefactorResult),
+		Embeddings:   make(map[string][]float32),
+	}
+}
+
+func (f *FakeClient) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	calls := make([]Call, len(f.calls))
+	copy(calls, f.calls)
+	return calls
+}
+
+func (f *FakeClient) Methods() []string {
+	var methods []string
+	for _, call := range f.Calls() {
+		methods = append(methods, call.Method)
+	}
+	return methods
+}
+
+func (f *FakeClient) record(call Call) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, call)
+}
+
+func (f *FakeClient) Health(ctx context.Context) error {
+	f.record(Call{Method: "Health"})
+	return f.Err
+}
+
+func (f *FakeClient) Ping(ctx context.Context) (time.Duration, error) {
+	f.record(Call{Method: "Ping"})
+	if f.Err != nil {
+		return 0, f.Err
+	}
+	return f.Latency, nil
+}
+
+func (f *FakeClient) Diagnostics(ctx context.Context) (*sidecar.Diagnostics, error) {
+	f.record(Call{Method: "Diagnostics"})
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if f.DiagnosticsInfo == nil {
+		return &sidecar.Diagnostics{Status: "healthy", Latency: f.Latency}, nil
+	}
+	return f.DiagnosticsInfo, nil
+}
+
+func (f *FakeClient) Analyze(ctx context.Context, path string, files []models.FileInfo) (*sidecar.AnalyzeResult, error) {
+	f.record(Call{Method: "Analyze", Path: path, Files: files})
+	return f.analyzeResult()
+}
+
+func (f *FakeClient) AnalyzeFiles(ctx context.Context, files []models.FileInfo) (*sidecar.AnalyzeResult, error) {
+	f.record(Call{Method: "AnalyzeFiles", Files: files})
+	return f.analyzeResult()
+}
+
+func (f *FakeClient) AnalyzeTar(ctx context.Context, r io.Reader) (*sidecar.AnalyzeResult, error) {
+	files, err := sidecar.ReadTarFiles(r)
+	if err != nil {
+		return nil, err
+	}
+	f.record(Call{Method: "AnalyzeTar", Files: files})
+	return f.analyzeResult()
+}
+
+func (f *FakeClient) AnalyzeResumable(ctx context.Context, path string) (*sidecar.AnalyzeResult, error) {
+	f.record(Call{Method: "AnalyzeResumable", Path: path})
+	return f.analyzeResult()
+}
+
+func (f *FakeClient) AnalyzeByDirectory(ctx context.Context, root string) (map[string]*sidecar.DirSummary, error) {
+	f.record(Call{Method: "AnalyzeByDirectory", Path: root})
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if f.DirSummaries == nil {
+		return make(map[string]*sidecar.DirSummary), nil
+	}
+	return f.DirSummaries, nil
+}
+
+func (f *FakeClient) AnalyzeGraph(ctx context.Context, path string) (*sidecar.DependencyGraph, error) {
+	f.record(Call{Method: "AnalyzeGraph", Path: path})
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if f.Graph == nil {
+		return &sidecar.DependencyGraph{}, nil
+	}
+	return f.Graph, nil
+}
+
+func (f *FakeClient) LanguageStats(ctx context.Context, path string) ([]models.LanguageStat, error) {
+	f.record(Call{Method: "LanguageStats", Path: path})
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	return f.Stats, nil
+}
+
+func (f *FakeClient) SuggestFix(ctx context.Context, group models.DuplicateGroup) (string, error) {
+	f.record(Call{Method: "SuggestFix", Args: map[string]interface{}{"group": group.ID}})
+	if f.Err != nil {
+		return "", f.Err
+	}
+	return f.Suggestion, nil
+}
+
+func (f *FakeClient) CanApplyPattern(ctx context.Context, pattern, path string) (bool, []string, error) {
+	f.record(Call{Method: "CanApplyPattern", Path: path, Args: map[string]interface{}{"pattern": pattern}})
+	if f.Err != nil {
+		return false, nil, f.Err
+	}
+	return len(f.PatternReasons) == 0, f.PatternReasons, nil
+}
+
+func (f *FakeClient) analyzeResult() (*sidecar.AnalyzeResult, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if f.AnalyzeResult == nil {
+		return &sidecar.AnalyzeResult{}, nil
+	}
+	return f.AnalyzeResult, nil
+}
+
+func (f *FakeClient) Deduplicate(ctx context.Context, path string, files []models.FileInfo, threshold float64) (*models.RefactorPlan, error) {
+	f.record(Call{Method: "Deduplicate", Path: path, Files: files, Args: map[string]interface{}{"threshold": threshold}})
+	return f.plan(f.DeduplicatePlan)
+}
+
+func (f *FakeClient) Idiomatize(ctx context.Context, path string, files []models.FileInfo, language models.Language) (*models.RefactorPlan, error) {
+	f.record(Call{Method: "Idiomatize", Path: path, Files: files, Args: map[string]interface{}{"language": language}})
+	return f.plan(f.IdiomatizePlan)
+}
+
+func (f *FakeClient) IdiomatizeLang(ctx context.Context, path string, lang models.Language) (*models.RefactorPlan, error) {
+	f.record(Call{Method: "IdiomatizeLang", Path: path, Args: map[string]interface{}{"language": lang}})
+	return f.plan(f.IdiomatizePlan)
+}
+
+func (f *FakeClient) ApplyPattern(ctx context.Context, pattern, path string, files []models.FileInfo) (*models.RefactorPlan, error) {
+	f.record(Call{Method: "ApplyPattern", Path: path, Files: files, Args: map[string]interface{}{"pattern": pattern}})
+	return f.plan(f.PatternPlan)
+}
+
+func (f *FakeClient) plan(plan *models.RefactorPlan) (*models.RefactorPlan, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if plan == nil {
+		return &models.RefactorPlan{}, nil
+	}
+	return plan, nil
+}
+
+func (f *FakeClient) ApplyPlan(ctx context.Context, sessionID string) (*models.RefactorResult, error) {
+	f.record(Call{Method: "ApplyPlan", Args: map[string]interface{}{"session_id": sessionID}})
+	return f.applyResult(sessionID)
+}
+
+func (f *FakeClient) applyResult(sessionID string) (*models.RefactorResult, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if result, ok := f.ApplyResults[sessionID]; ok {
+		return result, nil
+	}
+	return &models.RefactorResult{SessionID: sessionID, Success: true}, nil
+}
+
+func (f *FakeClient) ApplyBatch(ctx context.Context, plans []*models.RefactorPlan) ([]*models.RefactorResult, error) {
+	f.record(Call{Method: "ApplyBatch", Args: map[string]interface{}{"plans": len(plans)}})
+	return f.applyPlans(plans)
+}
+
+func (f *FakeClient) ResumeBatch(ctx context.Context, id string, plans []*models.RefactorPlan) ([]*models.RefactorResult, error) {
+	f.record(Call{Method: "ResumeBatch", Args: map[string]interface{}{"id": id, "plans": len(plans)}})
+	return f.applyPlans(plans)
+}
+
+func (f *FakeClient) applyPlans(plans []*models.RefactorPlan) ([]*models.RefactorResult, error) {
+	var results []*models.RefactorResult
+	for _, plan := range plans {
+		result, err := f.applyResult(plan.SessionID)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (f *FakeClient) Embed(ctx context.Context, files []models.FileInfo) (map[string][]float32, error) {
+	f.record(Call{Method: "Embed", Files: files})
+	return f.embed(files)
+}
+
+func (f *FakeClient) EmbedProgress(ctx context.Context, files []models.FileInfo, onProgress func(done, total int)) (map[string][]float32, error) {
+	f.record(Call{Method: "EmbedProgress", Files: files})
+	embeddings, err := f.embed(files)
+	if err == nil && onProgress != nil {
+		onProgress(len(files), len(files))
+	}
+	return embeddings, err
+}
+
+func (f *FakeClient) EmbedDelta(ctx context.Context, files []models.FileInfo, knownHashes map[string]string) (map[string][]float32, error) {
+	var changed []models.FileInfo
+	for _, file := range files {
+		if hash, ok := knownHashes[file.Path]; !ok || hash != file.Hash {
+			changed = append(changed, file)
+		}
+	}
+	f.record(Call{Method: "EmbedDelta", Files: changed})
+	return f.embed(changed)
+}
+
+func (f *FakeClient) embed(files []models.FileInfo) (map[string][]float32, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	embeddings := make(map[string][]float32)
+	for _, file := range files {
+		if vector, ok := f.Embeddings[file.Path]; ok {
+			embeddings[file.Path] = vector
+		}
+	}
+	return embeddings, nil
+}
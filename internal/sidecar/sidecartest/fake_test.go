@@ -0,0 +1,62 @@
+package sidecartest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestFakeClientCannedResponses(t *testing.T) {
+	fake := NewFakeClient()
+	fake.DeduplicatePlan = &models.RefactorPlan{
+		SessionID:   "dedup-1",
+		Description: "extract shared helper",
+	}
+	fake.ApplyResults["dedup-1"] = &models.RefactorResult{SessionID: "dedup-1", Success: true, TestsPassed: true}
+
+	ctx := context.Background()
+	files := []models.FileInfo{{Path: "a.py"}, {Path: "b.py"}}
+
+	plan, err := fake.Deduplicate(ctx, ".", files, 0.85)
+	if err != nil {
+		t.Fatalf("Deduplicate returned error: %v", err)
+	}
+	if plan.SessionID != "dedup-1" || plan.Description != "extract shared helper" {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+
+	result, err := fake.ApplyPlan(ctx, plan.SessionID)
+	if err != nil {
+		t.Fatalf("ApplyPlan returned error: %v", err)
+	}
+	if !result.TestsPassed {
+		t.Error("expected canned apply result")
+	}
+
+	if got := strings.Join(fake.Methods(), ","); got != "Deduplicate,ApplyPlan" {
+		t.Errorf("unexpected recorded methods %s", got)
+	}
+
+	calls := fake.Calls()
+	if len(calls[0].Files) != 2 || calls[0].Args["threshold"] != 0.85 {
+		t.Errorf("expected deduplicate arguments to be recorded, got %+v", calls[0])
+	}
+	if calls[1].Args["session_id"] != "dedup-1" {
+		t.Errorf("expected session ID to be recorded, got %+v", calls[1])
+	}
+}
+
+func TestFakeClientError(t *testing.T) {
+	fake := NewFakeClient()
+	fake.Err = errors.New("sidecar down")
+
+	if _, err := fake.Idiomatize(context.Background(), ".", nil, models.LanguageGo); err == nil {
+		t.Error("expected configured error")
+	}
+	if len(fake.Calls()) != 1 {
+		t.Error("expected failed call to be recorded")
+	}
+}
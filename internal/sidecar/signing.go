@@ -0,0 +1,49 @@
+package sidecar
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+const (
+	nonceHeader     = "X-Reducto-Nonce"
+	signatureHeader = "X-Reducto-Signature"
+)
+
+var ErrSignatureMismatch = errors.New("sidecar response signature mismatch")
+
+func (c *Client) SetSharedSecret(secret string) {
+	c.sharedSecret = []byte(secret)
+}
+
+func newNonce() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func SignResponse(secret []byte, nonce string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(nonce))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifySignature(secret []byte, nonce string, body []byte, signature string) error {
+	got, err := hex.DecodeString(signature)
+	if err != nil || signature == "" {
+		return ErrSignatureMismatch
+	}
+
+	expected, _ := hex.DecodeString(SignResponse(secret, nonce, body))
+	if !hmac.Equal(got, expected) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
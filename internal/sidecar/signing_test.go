@@ -0,0 +1,118 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestSharedSecretVerification(t *testing.T) {
+	const secret = "s3cret"
+
+	newSigningServer := func(signingSecret string) string {
+		server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+			body, _ := json.Marshal(map[string]interface{}{
+				"status": "success",
+				"data":   map[string]interface{}{"session_id": "abc", "success": true},
+			})
+			nonce := r.Header.Get(nonceHeader)
+			if nonce == "" {
+				http.Error(w, "missing nonce", http.StatusBadRequest)
+				return
+			}
+			w.Header().Set(signatureHeader, SignResponse([]byte(signingSecret), nonce, body))
+			w.Write(body)
+		})
+		return server.URL
+	}
+
+	t.Run("correct signature is accepted", func(t *testing.T) {
+		client := NewClient(newSigningServer(secret))
+		client.SetSharedSecret(secret)
+
+		result, err := client.ApplyPlan(context.Background(), "abc")
+		if err != nil {
+			t.Fatalf("expected signed response to be accepted, got %v", err)
+		}
+		if result.SessionID != "abc" {
+			t.Errorf("expected session abc, got %q", result.SessionID)
+		}
+	})
+
+	t.Run("wrong signature is rejected", func(t *testing.T) {
+		client := NewClient(newSigningServer("other-secret"))
+		client.SetSharedSecret(secret)
+
+		_, err := client.ApplyPlan(context.Background(), "abc")
+		if !errors.Is(err, ErrSignatureMismatch) {
+			t.Errorf("expected ErrSignatureMismatch, got %v", err)
+		}
+	})
+
+	t.Run("missing signature is rejected", func(t *testing.T) {
+		server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+			writeAPIResponse(w, map[string]interface{}{"session_id": "abc"})
+		})
+		client := NewClient(server.URL)
+		client.SetSharedSecret(secret)
+
+		_, err := client.ApplyPlan(context.Background(), "abc")
+		if !errors.Is(err, ErrSignatureMismatch) {
+			t.Errorf("expected ErrSignatureMismatch, got %v", err)
+		}
+	})
+
+	t.Run("unsigned error status is reported as StatusError", func(t *testing.T) {
+		server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		})
+		client := NewClient(server.URL)
+		client.SetSharedSecret(secret)
+
+		_, err := client.ApplyPlan(context.Background(), "abc")
+		var statusErr *StatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusRequestEntityTooLarge {
+			t.Fatalf("expected 413 StatusError, got %v", err)
+		}
+		if statusErr.RequestID == "" {
+			t.Error("expected StatusError to carry the request ID")
+		}
+	})
+
+	t.Run("unsigned 413 still triggers embed split", func(t *testing.T) {
+		var accepted int
+		server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+			var req struct {
+				Files []models.FileInfo `json:"files"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			if len(req.Files) > 2 {
+				http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			accepted += len(req.Files)
+			embeddings := make(map[string][]float32)
+			for _, f := range req.Files {
+				embeddings[f.Path] = []float32{1}
+			}
+			body, _ := json.Marshal(map[string]interface{}{"status": "success", "data": embeddings})
+			w.Header().Set(signatureHeader, SignResponse([]byte(secret), r.Header.Get(nonceHeader), body))
+			w.Write(body)
+		})
+		client := NewClient(server.URL)
+		client.SetSharedSecret(secret)
+
+		files := []models.FileInfo{{Path: "a.py"}, {Path: "b.py"}, {Path: "c.py"}, {Path: "d.py"}}
+		embeddings, err := client.Embed(context.Background(), files)
+		if err != nil {
+			t.Fatalf("Embed returned error: %v", err)
+		}
+		if len(embeddings) != 4 || accepted != 4 {
+			t.Errorf("expected 4 embeddings after splitting, got %d (accepted %d)", len(embeddings), accepted)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package sidecar
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func (c *Client) LanguageStats(ctx context.Context, path string) ([]models.LanguageStat, error) {
+	if err := validateTarget("stats", path, nil); err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"path": path,
+	}
+
+	var stats []models.LanguageStat
+	if err := c.post(ctx, "/stats", body, &stats); err != nil {
+		return nil, fmt.Errorf("stats failed: %w", err)
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].Lines != stats[j].Lines {
+			return stats[i].Lines > stats[j].Lines
+		}
+		return stats[i].Language < stats[j].Language
+	})
+
+	return stats, nil
+}
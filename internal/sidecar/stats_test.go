@@ -0,0 +1,46 @@
+package sidecar
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestLanguageStats(t *testing.T) {
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stats" {
+			http.NotFound(w, r)
+			return
+		}
+
+		writeAPIResponse(w, []map[string]interface{}{
+			{"language": "python", "files": 12, "lines": 1800, "symbols": 140},
+			{"language": "go", "files": 30, "lines": 5200, "symbols": 410},
+		})
+	})
+
+	client := NewClient(server.URL)
+	stats, err := client.LanguageStats(context.Background(), "src")
+	if err != nil {
+		t.Fatalf("LanguageStats returned error: %v", err)
+	}
+
+	expected := []models.LanguageStat{
+		{Language: models.LanguageGo, Files: 30, Lines: 5200, Symbols: 410},
+		{Language: models.LanguagePython, Files: 12, Lines: 1800, Symbols: 140},
+	}
+	if len(stats) != len(expected) {
+		t.Fatalf("expected %d stats, got %d", len(expected), len(stats))
+	}
+	for i := range expected {
+		if stats[i] != expected[i] {
+			t.Errorf("stat %d: expected %+v, got %+v", i, expected[i], stats[i])
+		}
+	}
+
+	if _, err := client.LanguageStats(context.Background(), ""); err == nil {
+		t.Error("expected validation error for empty path")
+	}
+}
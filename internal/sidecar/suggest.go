@@ -0,0 +1,27 @@
+package sidecar
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func (c *Client) SuggestFix(ctx context.Context, group models.DuplicateGroup) (string, error) {
+	if len(group.Blocks) == 0 {
+		return "", NewValidationError("suggest", "duplicate group has no blocks")
+	}
+
+	body := map[string]interface{}{
+		"group": group,
+	}
+
+	var resp struct {
+		Suggestion string `json:"suggestion"`
+	}
+	if err := c.post(ctx, "/suggest", body, &resp); err != nil {
+		return "", fmt.Errorf("suggest failed: %w", err)
+	}
+
+	return resp.Suggestion, nil
+}
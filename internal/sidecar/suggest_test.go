@@ -0,0 +1,52 @@
+package sidecar
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestSuggestFix(t *testing.T) {
+	var received struct {
+		Group models.DuplicateGroup `json:"group"`
+	}
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/suggest" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		writeAPIResponse(w, map[string]string{
+			"suggestion": "extract func validateUser(u *User) error into pkg/users/validate.go",
+		})
+	})
+
+	group := models.DuplicateGroup{
+		ID:         "group-1",
+		Similarity: 0.93,
+		Blocks: []models.CodeBlock{
+			{ID: "a", File: "pkg/a.go", StartLine: 10, EndLine: 20, SymbolName: "checkA"},
+			{ID: "b", File: "pkg/b.go", StartLine: 5, EndLine: 15, SymbolName: "checkB"},
+		},
+	}
+
+	client := NewClient(server.URL)
+	suggestion, err := client.SuggestFix(context.Background(), group)
+	if err != nil {
+		t.Fatalf("SuggestFix returned error: %v", err)
+	}
+
+	if suggestion != "extract func validateUser(u *User) error into pkg/users/validate.go" {
+		t.Errorf("unexpected suggestion %q", suggestion)
+	}
+	if received.Group.ID != "group-1" || len(received.Group.Blocks) != 2 || received.Group.Blocks[1].File != "pkg/b.go" {
+		t.Errorf("expected group to be serialized, got %+v", received.Group)
+	}
+
+	if _, err := client.SuggestFix(context.Background(), models.DuplicateGroup{ID: "empty"}); err == nil {
+		t.Error("expected validation error for a group without blocks")
+	}
+}
@@ -0,0 +1,40 @@
+package sidecar
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+type ValidationError struct {
+	Method  string
+	Message string
+}
+
+func NewValidationError(method, message string) *ValidationError {
+	return &ValidationError{
+		Method:  method,
+		Message: message,
+	}
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s request: %s", e.Method, e.Message)
+}
+
+func knownLanguage(lang models.Language) bool {
+	switch lang {
+	case models.LanguagePython, models.LanguageJavaScript, models.LanguageTypeScript, models.LanguageGo:
+		return true
+	default:
+		return false
+	}
+}
+
+func validateTarget(method, path string, files []models.FileInfo) error {
+	if strings.TrimSpace(path) == "" && len(files) == 0 {
+		return NewValidationError(method, "path or files required")
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package sidecar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestClientValidation(t *testing.T) {
+	var hits int32
+	server := newTestSidecar(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		writeAPIResponse(w, nil)
+	})
+
+	client := NewClient(server.URL)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "analyze without path or files",
+			call: func() error {
+				_, err := client.Analyze(ctx, "", nil)
+				return err
+			},
+		},
+		{
+			name: "deduplicate without path or files",
+			call: func() error {
+				_, err := client.Deduplicate(ctx, "", nil, 0.8)
+				return err
+			},
+		},
+		{
+			name: "deduplicate with out of range threshold",
+			call: func() error {
+				_, err := client.Deduplicate(ctx, ".", nil, 1.5)
+				return err
+			},
+		},
+		{
+			name: "idiomatize without path or files",
+			call: func() error {
+				_, err := client.Idiomatize(ctx, " ", nil, models.LanguagePython)
+				return err
+			},
+		},
+		{
+			name: "idiomatize with unknown language",
+			call: func() error {
+				_, err := client.IdiomatizeLang(ctx, ".", models.Language("cobol"))
+				return err
+			},
+		},
+		{
+			name: "pattern without pattern",
+			call: func() error {
+				_, err := client.ApplyPattern(ctx, "", ".", nil)
+				return err
+			},
+		},
+		{
+			name: "apply without session",
+			call: func() error {
+				_, err := client.ApplyPlan(ctx, "")
+				return err
+			},
+		},
+		{
+			name: "embed without files",
+			call: func() error {
+				_, err := client.Embed(ctx, nil)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+		})
+	}
+
+	if n := atomic.LoadInt32(&hits); n != 0 {
+		t.Errorf("expected no requests to reach the server, got %d", n)
+	}
+
+	t.Run("valid input reaches server", func(t *testing.T) {
+		if _, err := client.Analyze(ctx, "", []models.FileInfo{{Path: "a.py"}}); err != nil {
+			t.Fatalf("Analyze returned error: %v", err)
+		}
+		if n := atomic.LoadInt32(&hits); n != 1 {
+			t.Errorf("expected 1 request, got %d", n)
+		}
+	})
+}
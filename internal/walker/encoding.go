@@ -0,0 +1,60 @@
+package walker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+func decodeContent(data []byte, transcode bool) (string, bool) {
+	if bytes.HasPrefix(data, bomUTF8) {
+		data = data[len(bomUTF8):]
+	}
+
+	if transcode {
+		switch {
+		case bytes.HasPrefix(data, bomUTF16LE):
+			return decodeUTF16(data[2:], binary.LittleEndian), true
+		case bytes.HasPrefix(data, bomUTF16BE):
+			return decodeUTF16(data[2:], binary.BigEndian), true
+		}
+	}
+
+	if bytes.IndexByte(data, 0) >= 0 {
+		return "", false
+	}
+
+	if utf8.Valid(data) {
+		return string(data), true
+	}
+
+	if !transcode {
+		return "", false
+	}
+	return decodeLatin1(data), true
+}
+
+func decodeUTF16(data []byte, order binary.ByteOrder) string {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[i*2:])
+	}
+	return string(utf16.Decode(units))
+}
+
+func decodeLatin1(data []byte) string {
+	var sb strings.Builder
+	sb.Grow(len(data))
+	for _, b := range data {
+		sb.WriteRune(rune(b))
+	}
+	return sb.String()
+}
@@ -0,0 +1,88 @@
+package walker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCollectFilesEncoding(t *testing.T) {
+	root := t.TempDir()
+	files := map[string][]byte{
+		"valid.py":  []byte("name = \"café\"\n"),
+		"data.dat":  {0x7f, 'E', 'L', 'F', 0x00, 0x01, 0x02},
+		"legacy.py": {'n', 'a', 'm', 'e', ' ', '=', ' ', '"', 'c', 'a', 'f', 0xE9, '"', '\n'},
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), content, 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name            string
+		transcode       bool
+		expectedFiles   map[string]string
+		expectedSkipped []string
+	}{
+		{
+			name:            "skip invalid encodings",
+			expectedFiles:   map[string]string{"valid.py": "name = \"café\"\n"},
+			expectedSkipped: []string{"data.dat", "legacy.py"},
+		},
+		{
+			name:      "transcode latin-1",
+			transcode: true,
+			expectedFiles: map[string]string{
+				"valid.py":  "name = \"café\"\n",
+				"legacy.py": "name = \"café\"\n",
+			},
+			expectedSkipped: []string{"data.dat"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CollectFilesWithOptions(root, CollectOptions{Transcode: tt.transcode})
+			if err != nil {
+				t.Fatalf("CollectFilesWithOptions returned error: %v", err)
+			}
+
+			if len(result.Files) != len(tt.expectedFiles) {
+				t.Fatalf("expected %d files, got %d", len(tt.expectedFiles), len(result.Files))
+			}
+			for _, f := range result.Files {
+				want, ok := tt.expectedFiles[f.Path]
+				if !ok {
+					t.Errorf("unexpected file %s", f.Path)
+					continue
+				}
+				if f.Content != want {
+					t.Errorf("%s: expected content %q, got %q", f.Path, want, f.Content)
+				}
+			}
+
+			if len(result.Skipped) != len(tt.expectedSkipped) {
+				t.Fatalf("expected skipped %v, got %v", tt.expectedSkipped, result.Skipped)
+			}
+			for i := range tt.expectedSkipped {
+				if result.Skipped[i].Path != tt.expectedSkipped[i] {
+					t.Errorf("expected skipped %v, got %v", tt.expectedSkipped, result.Skipped)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeContentUTF16(t *testing.T) {
+	data := []byte{0xFF, 0xFE, 'h', 0, 'i', 0}
+
+	if _, ok := decodeContent(data, false); ok {
+		t.Error("expected UTF-16 content to be skipped without transcoding")
+	}
+
+	got, ok := decodeContent(data, true)
+	if !ok || got != "hi" {
+		t.Errorf("expected \"hi\", got %q (ok=%v)", got, ok)
+	}
+}
@@ -0,0 +1,93 @@
+package walker
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+const IgnoreFile = ".dehydratorignore"
+
+var ignoreFiles = []string{".gitignore", IgnoreFile}
+
+type CollectOptions struct {
+	ExcludePatterns []string
+	IncludePatterns []string
+	Transcode       bool
+	ForceLanguage   models.Language
+}
+
+func CollectFiles(root string, excludePatterns, includePatterns []string) ([]models.FileInfo, error) {
+	result, err := CollectFilesWithOptions(root, CollectOptions{
+		ExcludePatterns: excludePatterns,
+		IncludePatterns: includePatterns,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Files, nil
+}
+
+func CollectFilesWithOptions(root string, opts CollectOptions) (*CollectResult, error) {
+	patterns, err := loadIgnorePatterns(root)
+	if err != nil {
+		return nil, err
+	}
+
+	w := New(opts.ExcludePatterns, opts.IncludePatterns)
+	w.SetTranscode(opts.Transcode)
+	w.SetForceLanguage(opts.ForceLanguage)
+	if len(patterns) > 0 {
+		w.ignore = gitignore.NewMatcher(patterns)
+	}
+
+	return w.Collect(root)
+}
+
+func loadIgnorePatterns(root string) ([]gitignore.Pattern, error) {
+	var patterns []gitignore.Pattern
+
+	for _, name := range ignoreFiles {
+		f, err := os.Open(filepath.Join(root, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", name, err)
+		}
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimRight(scanner.Text(), " \t\r")
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			patterns = append(patterns, gitignore.ParsePattern(line, nil))
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+	}
+
+	return patterns, nil
+}
+
+func (w *Walker) isIgnored(root, path string, isDir bool) bool {
+	if w.ignore == nil {
+		return false
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		return false
+	}
+
+	return w.ignore.Match(strings.Split(filepath.ToSlash(rel), "/"), isDir)
+}
@@ -0,0 +1,82 @@
+package walker
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestCollectFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected []string
+	}{
+		{
+			name: "dehydratorignore excludes directory",
+			files: map[string]string{
+				".dehydratorignore":  "legacy/**\n",
+				"main.py":            "print('main')",
+				"legacy/old.py":      "print('old')",
+				"legacy/deep/old.py": "print('deep')",
+			},
+			expected: []string{"main.py"},
+		},
+		{
+			name: "combines with gitignore",
+			files: map[string]string{
+				".gitignore":        "generated.py\n",
+				".dehydratorignore": "# legacy code\nlegacy/**\n",
+				"main.py":           "print('main')",
+				"generated.py":      "x = 1",
+				"legacy/old.py":     "print('old')",
+			},
+			expected: []string{".gitignore", "main.py"},
+		},
+		{
+			name: "no ignore files",
+			files: map[string]string{
+				"main.py":       "print('main')",
+				"legacy/old.py": "print('old')",
+			},
+			expected: []string{"legacy/old.py", "main.py"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(tmpDir, filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("failed to create dir: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write file: %v", err)
+				}
+			}
+
+			files, err := CollectFiles(tmpDir, nil, nil)
+			if err != nil {
+				t.Fatalf("CollectFiles returned error: %v", err)
+			}
+
+			var paths []string
+			for _, f := range files {
+				paths = append(paths, filepath.ToSlash(f.Path))
+			}
+			sort.Strings(paths)
+
+			if len(paths) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, paths)
+			}
+			for i := range paths {
+				if paths[i] != tt.expected[i] {
+					t.Errorf("expected %v, got %v", tt.expected, paths)
+					break
+				}
+			}
+		})
+	}
+}
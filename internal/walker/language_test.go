@@ -0,0 +1,64 @@
+package walker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestCollectFilesLanguage(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"deploy":   "#!/usr/bin/env python3\nimport sys\n",
+		"cleanup":  "import shutil\nshutil.rmtree('build')\n",
+		"utils.go": "package utils\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0755); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		force    models.Language
+		expected map[string]models.Language
+	}{
+		{
+			name: "auto-detect with shebang fallback",
+			expected: map[string]models.Language{
+				"deploy":   models.LanguagePython,
+				"cleanup":  models.LanguageUnknown,
+				"utils.go": models.LanguageGo,
+			},
+		},
+		{
+			name:  "forced language",
+			force: models.LanguagePython,
+			expected: map[string]models.Language{
+				"deploy":   models.LanguagePython,
+				"cleanup":  models.LanguagePython,
+				"utils.go": models.LanguagePython,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CollectFilesWithOptions(root, CollectOptions{ForceLanguage: tt.force})
+			if err != nil {
+				t.Fatalf("CollectFilesWithOptions returned error: %v", err)
+			}
+			if len(result.Files) != len(tt.expected) {
+				t.Fatalf("expected %d files, got %d", len(tt.expected), len(result.Files))
+			}
+			for _, f := range result.Files {
+				if f.Language != tt.expected[f.Path] {
+					t.Errorf("%s: expected %s, got %s", f.Path, tt.expected[f.Path], f.Language)
+				}
+			}
+		})
+	}
+}
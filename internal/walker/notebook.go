@@ -0,0 +1,67 @@
+package walker
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const notebookExt = ".ipynb"
+
+type notebook struct {
+	Cells []notebookCell `json:"cells"`
+}
+
+type notebookCell struct {
+	CellType string          `json:"cell_type"`
+	Source   json.RawMessage `json:"source"`
+}
+
+func isNotebook(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), notebookExt)
+}
+
+func ExtractNotebook(data []byte) (string, error) {
+	var nb notebook
+	if err := json.Unmarshal(data, &nb); err != nil {
+		return "", fmt.Errorf("failed to parse notebook: %w", err)
+	}
+
+	var sb strings.Builder
+	for i, cell := range nb.Cells {
+		if cell.CellType != "code" {
+			continue
+		}
+
+		source, err := cellSource(cell.Source)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse cell %d: %w", i, err)
+		}
+
+		sb.WriteString(fmt.Sprintf("# %%%% [cell %d]\n", i))
+		sb.WriteString(source)
+		if !strings.HasSuffix(source, "\n") {
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func cellSource(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 {
+		return "", nil
+	}
+
+	var lines []string
+	if err := json.Unmarshal(raw, &lines); err == nil {
+		return strings.Join(lines, ""), nil
+	}
+
+	var source string
+	if err := json.Unmarshal(raw, &source); err != nil {
+		return "", err
+	}
+	return source, nil
+}
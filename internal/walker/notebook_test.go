@@ -0,0 +1,76 @@
+package walker
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+const testNotebook = `{
+  "cells": [
+    {"cell_type": "markdown", "metadata": {}, "source": ["# Analysis notes\n", "Some prose here."]},
+    {"cell_type": "code", "metadata": {}, "execution_count": 1, "outputs": [{"output_type": "stream", "text": ["hidden output"]}], "source": ["import pandas as pd\n", "df = pd.read_csv('data.csv')"]},
+    {"cell_type": "code", "metadata": {}, "execution_count": 2, "outputs": [], "source": "df.describe()"}
+  ],
+  "metadata": {},
+  "nbformat": 4,
+  "nbformat_minor": 5
+}`
+
+func TestExtractNotebook(t *testing.T) {
+	content, err := ExtractNotebook([]byte(testNotebook))
+	if err != nil {
+		t.Fatalf("ExtractNotebook returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		"# %% [cell 1]\nimport pandas as pd\ndf = pd.read_csv('data.csv')\n",
+		"# %% [cell 2]\ndf.describe()\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected content to contain %q, got:\n%s", want, content)
+		}
+	}
+
+	for _, unwanted := range []string{"Analysis notes", "Some prose", "hidden output", "[cell 0]"} {
+		if strings.Contains(content, unwanted) {
+			t.Errorf("expected content to exclude %q, got:\n%s", unwanted, content)
+		}
+	}
+}
+
+func TestCollectFilesNotebook(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "analysis.ipynb"), []byte(testNotebook), 0644); err != nil {
+		t.Fatalf("failed to write notebook: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "broken.ipynb"), []byte("{not json"), 0644); err != nil {
+		t.Fatalf("failed to write notebook: %v", err)
+	}
+
+	result, err := CollectFilesWithOptions(root, CollectOptions{})
+	if err != nil {
+		t.Fatalf("CollectFilesWithOptions returned error: %v", err)
+	}
+	files := result.Files
+	if len(files) != 1 {
+		t.Fatalf("expected 1 file, got %d", len(files))
+	}
+
+	if len(result.Skipped) != 1 || result.Skipped[0].Path != "broken.ipynb" {
+		t.Fatalf("expected broken.ipynb to be skipped, got %+v", result.Skipped)
+	}
+	if !strings.Contains(result.Skipped[0].Reason, "failed to parse notebook") {
+		t.Errorf("expected a parse failure reason, got %q", result.Skipped[0].Reason)
+	}
+
+	if lang := models.LanguageFromPath(files[0].Path); lang != models.LanguagePython {
+		t.Errorf("expected notebook to be analyzed as python, got %s", lang)
+	}
+	if !strings.Contains(files[0].Content, "df.describe()") || strings.Contains(files[0].Content, "Analysis notes") {
+		t.Errorf("unexpected notebook content:\n%s", files[0].Content)
+	}
+}
@@ -8,16 +8,31 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/alexkarsten/reducto/pkg/models"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
 	"golang.org/x/sync/errgroup"
 )
 
 type Walker struct {
 	excludePatterns []string
 	includePatterns []string
+	ignore          gitignore.Matcher
+	transcode       bool
+	forceLanguage   models.Language
+}
+
+type CollectResult struct {
+	Files   []models.FileInfo
+	Skipped []SkippedFile
+}
+
+type SkippedFile struct {
+	Path   string
+	Reason string
 }
 
 func New(excludePatterns, includePatterns []string) *Walker {
@@ -27,7 +42,23 @@ func New(excludePatterns, includePatterns []string) *Walker {
 	}
 }
 
+func (w *Walker) SetTranscode(enabled bool) {
+	w.transcode = enabled
+}
+
+func (w *Walker) SetForceLanguage(lang models.Language) {
+	w.forceLanguage = lang
+}
+
 func (w *Walker) Walk(root string) ([]models.FileInfo, error) {
+	result, err := w.Collect(root)
+	if err != nil {
+		return nil, err
+	}
+	return result.Files, nil
+}
+
+func (w *Walker) Collect(root string) (*CollectResult, error) {
 	var filePaths []string
 
 	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
@@ -36,7 +67,7 @@ func (w *Walker) Walk(root string) ([]models.FileInfo, error) {
 		}
 
 		if d.IsDir() {
-			if w.shouldExcludeDir(path) {
+			if w.shouldExcludeDir(path) || w.isIgnored(root, path, true) {
 				return fs.SkipDir
 			}
 			return nil
@@ -52,7 +83,7 @@ func (w *Walker) Walk(root string) ([]models.FileInfo, error) {
 			}
 		}
 
-		if w.shouldExcludeFile(path) {
+		if w.shouldExcludeFile(path) || w.isIgnored(root, path, false) {
 			return nil
 		}
 
@@ -68,7 +99,7 @@ func (w *Walker) Walk(root string) ([]models.FileInfo, error) {
 		return nil, fmt.Errorf("failed to walk directory: %w", err)
 	}
 
-	var files []models.FileInfo
+	result := &CollectResult{}
 	var mu sync.Mutex
 
 	g, ctx := errgroup.WithContext(context.Background())
@@ -96,12 +127,30 @@ func (w *Walker) Walk(root string) ([]models.FileInfo, error) {
 
 			hash := sha256.Sum256(content)
 
+			if isNotebook(path) {
+				source, err := ExtractNotebook(content)
+				if err != nil {
+					mu.Lock()
+					result.Skipped = append(result.Skipped, SkippedFile{Path: relPath, Reason: err.Error()})
+					mu.Unlock()
+					return nil
+				}
+				content = []byte(source)
+			}
+
+			text, ok := decodeContent(content, w.transcode)
+
 			mu.Lock()
-			files = append(files, models.FileInfo{
-				Path:    relPath,
-				Content: string(content),
-				Hash:    hex.EncodeToString(hash[:]),
-			})
+			if ok {
+				result.Files = append(result.Files, models.FileInfo{
+					Path:     relPath,
+					Content:  text,
+					Hash:     hex.EncodeToString(hash[:]),
+					Language: w.languageFor(relPath, text),
+				})
+			} else {
+				result.Skipped = append(result.Skipped, SkippedFile{Path: relPath, Reason: "unsupported text encoding"})
+			}
 			mu.Unlock()
 
 			return nil
@@ -112,7 +161,10 @@ func (w *Walker) Walk(root string) ([]models.FileInfo, error) {
 		return nil, fmt.Errorf("failed to read files: %w", err)
 	}
 
-	return files, nil
+	sort.Slice(result.Skipped, func(i, j int) bool {
+		return result.Skipped[i].Path < result.Skipped[j].Path
+	})
+	return result, nil
 }
 
 func (w *Walker) shouldExcludeDir(path string) bool {
@@ -181,19 +233,14 @@ func (w *Walker) shouldIncludeFile(path string) bool {
 }
 
 func (w *Walker) DetectLanguage(path string) models.Language {
-	ext := strings.ToLower(filepath.Ext(path))
-	switch ext {
-	case ".py":
-		return models.LanguagePython
-	case ".js":
-		return models.LanguageJavaScript
-	case ".ts", ".tsx":
-		return models.LanguageTypeScript
-	case ".go":
-		return models.LanguageGo
-	default:
-		return models.LanguageUnknown
+	return models.LanguageFromPath(path)
+}
+
+func (w *Walker) languageFor(path, content string) models.Language {
+	if w.forceLanguage != "" {
+		return w.forceLanguage
 	}
+	return models.DetectLanguage(path, content)
 }
 
 func (w *Walker) CountLines(content string) int {
@@ -212,8 +259,7 @@ func (w *Walker) GetProjectStats(root string) (*ProjectStats, error) {
 	}
 
 	for _, f := range files {
-		lang := w.DetectLanguage(f.Path)
-		stats.ByLanguage[lang]++
+		stats.ByLanguage[f.Language]++
 		stats.TotalLines += w.CountLines(f.Content)
 	}
 
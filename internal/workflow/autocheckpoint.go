@@ -0,0 +1,148 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alexkarsten/reducto/internal/git"
+	"github.com/alexkarsten/reducto/internal/runner"
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	defaultSettleDelay = 2 * time.Second
+	stateDir           = ".reducto"
+)
+
+var watchExcludedDirs = map[string]bool{
+	".git":          true,
+	stateDir:        true,
+	"node_modules":  true,
+	"__pycache__":   true,
+	".pytest_cache": true,
+	".venv":         true,
+	"venv":          true,
+}
+
+func AutoCheckpoint(ctx context.Context, g *git.Manager, r *runner.Runner) error {
+	return autoCheckpoint(ctx, g, r, defaultSettleDelay, nil)
+}
+
+func autoCheckpoint(ctx context.Context, g *git.Manager, r *runner.Runner, settle time.Duration, onResult func(*runner.TestResult, bool)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	root := g.Path()
+	if err := watchTree(watcher, root); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(settle)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("watcher error: %w", err)
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if isWatchExcluded(root, event.Name) {
+				continue
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := watchTree(watcher, event.Name); err != nil {
+						return err
+					}
+				}
+			}
+			timer.Reset(settle)
+
+		case <-timer.C:
+			result, committed, err := checkpointIfPassing(g, r)
+			if err != nil {
+				return err
+			}
+			if onResult != nil {
+				onResult(result, committed)
+			}
+		}
+	}
+}
+
+func checkpointIfPassing(g *git.Manager, r *runner.Runner) (*runner.TestResult, bool, error) {
+	dirty, err := g.HasChangesExcluding(stateDir)
+	if err != nil {
+		return nil, false, err
+	}
+	if !dirty {
+		return nil, false, nil
+	}
+
+	result, err := r.RunTests()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to run tests: %w", err)
+	}
+	if !result.Success {
+		return result, false, nil
+	}
+
+	message := fmt.Sprintf("reducto: auto-checkpoint %s\n\n%s: %s",
+		time.Now().Format(time.RFC3339), git.TestsTrailer, TestSummary(result))
+	if err := g.CreateCheckpointExcluding(message, stateDir); err != nil {
+		return result, false, fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+
+	return result, true, nil
+}
+
+func watchTree(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != dir && watchExcludedDirs[d.Name()] {
+			return fs.SkipDir
+		}
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+func isWatchExcluded(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	for _, part := range strings.Split(filepath.ToSlash(rel), "/") {
+		if watchExcludedDirs[part] {
+			return true
+		}
+	}
+	return false
+}
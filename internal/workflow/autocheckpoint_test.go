@@ -0,0 +1,104 @@
+package workflow
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alexkarsten/reducto/internal/git"
+	"github.com/alexkarsten/reducto/internal/runner"
+	gogit "github.com/go-git/go-git/v5"
+)
+
+type autoCheckpointEvent struct {
+	result    *runner.TestResult
+	committed bool
+}
+
+func TestAutoCheckpoint(t *testing.T) {
+	tmpDir := t.TempDir()
+	if _, err := gogit.PlainInit(tmpDir, false); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	r := runner.New(tmpDir)
+	r.SetConfig(runner.RunnerConfig{TestCommand: "test ! -f FAIL", Shell: true})
+	mgr := git.NewManager(tmpDir)
+
+	events := make(chan autoCheckpointEvent, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- autoCheckpoint(ctx, mgr, r, 100*time.Millisecond, func(result *runner.TestResult, committed bool) {
+			events <- autoCheckpointEvent{result, committed}
+		})
+	}()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("autoCheckpoint returned error: %v", err)
+		}
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	writeFile := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	waitEvent := func() autoCheckpointEvent {
+		t.Helper()
+		select {
+		case ev := <-events:
+			return ev
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for auto-checkpoint")
+			return autoCheckpointEvent{}
+		}
+	}
+
+	writeFile(".reducto/state.json", "{}")
+	writeFile("main.go", "package main\n")
+
+	if ev := waitEvent(); !ev.committed || !ev.result.Success {
+		t.Fatalf("expected passing change to be committed, got %+v", ev)
+	}
+
+	checkpoints, err := mgr.ListCheckpoints()
+	if err != nil {
+		t.Fatalf("ListCheckpoints returned error: %v", err)
+	}
+	if len(checkpoints) != 1 {
+		t.Fatalf("expected 1 checkpoint, got %d", len(checkpoints))
+	}
+
+	changed, err := mgr.ChangedFiles()
+	if err != nil {
+		t.Fatalf("ChangedFiles returned error: %v", err)
+	}
+	if len(changed) != 1 || changed[0] != ".reducto/state.json" {
+		t.Errorf("expected .reducto to stay uncommitted, got changed files %v", changed)
+	}
+
+	writeFile("FAIL", "")
+	writeFile("main.go", "package main\n\nfunc broken() {}\n")
+
+	if ev := waitEvent(); ev.committed || ev.result == nil || ev.result.Success {
+		t.Fatalf("expected failing change to be skipped, got %+v", ev)
+	}
+
+	checkpoints, err = mgr.ListCheckpoints()
+	if err != nil {
+		t.Fatalf("ListCheckpoints returned error: %v", err)
+	}
+	if len(checkpoints) != 1 {
+		t.Errorf("expected failing change not to be committed, got %d checkpoints", len(checkpoints))
+	}
+}
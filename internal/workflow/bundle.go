@@ -0,0 +1,282 @@
+package workflow
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+const (
+	BundleExt     = ".dehydrator-bundle"
+	bundleVersion = 1
+
+	manifestEntry = "manifest.json"
+	planEntry     = "plan.json"
+	reportsPrefix = "reports/"
+	snapshotsDir  = "snapshots"
+	plansDir      = "plans"
+)
+
+type BundleManifest struct {
+	Version   int       `json:"version"`
+	SessionID string    `json:"session_id"`
+	CreatedAt time.Time `json:"created_at"`
+	Files     []string  `json:"files"`
+}
+
+type Bundle struct {
+	Manifest BundleManifest
+	Plan     *models.RefactorPlan
+	Reports  map[string]string
+}
+
+func SavePlan(plan *models.RefactorPlan) error {
+	return savePlan(stateDir, plan)
+}
+
+func LoadPlan(sessionID string) (*models.RefactorPlan, error) {
+	return loadPlan(stateDir, sessionID)
+}
+
+func ExportBundle(sessionID, outPath string) error {
+	return exportBundle(stateDir, sessionID, outPath)
+}
+
+func ImportBundle(bundlePath string) (*Bundle, error) {
+	return importBundle(stateDir, bundlePath)
+}
+
+func validSessionID(sessionID string) bool {
+	return sessionID != "" && sessionID != "." && sessionID != ".." && !strings.ContainsAny(sessionID, `/\`)
+}
+
+func planPath(dir, sessionID string) string {
+	return filepath.Join(dir, plansDir, sessionID+".json")
+}
+
+func savePlan(dir string, plan *models.RefactorPlan) error {
+	if !validSessionID(plan.SessionID) {
+		return fmt.Errorf("invalid session ID: %q", plan.SessionID)
+	}
+
+	stamped := *plan
+	stamped.SchemaVersion = models.CurrentSchemaVersion
+	data, err := json.MarshalIndent(&stamped, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan: %w", err)
+	}
+
+	path := planPath(dir, plan.SessionID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create plans directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+
+	return nil
+}
+
+func loadPlan(dir, sessionID string) (*models.RefactorPlan, error) {
+	if !validSessionID(sessionID) {
+		return nil, fmt.Errorf("invalid session ID: %q", sessionID)
+	}
+
+	data, err := os.ReadFile(planPath(dir, sessionID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+
+	return models.DecodePlan(data)
+}
+
+func exportBundle(dir, sessionID, outPath string) error {
+	if !validSessionID(sessionID) {
+		return fmt.Errorf("invalid session ID: %q", sessionID)
+	}
+
+	entries := make(map[string]string)
+
+	if _, err := os.Stat(planPath(dir, sessionID)); err == nil {
+		entries[planEntry] = planPath(dir, sessionID)
+	}
+
+	reports, err := filepath.Glob(filepath.Join(dir, "reducto-report-"+sessionID+".*"))
+	if err != nil {
+		return fmt.Errorf("failed to find reports: %w", err)
+	}
+	for _, report := range reports {
+		entries[reportsPrefix+filepath.Base(report)] = report
+	}
+
+	snapshotRoot := filepath.Join(dir, snapshotsDir, sessionID)
+	err = filepath.WalkDir(snapshotRoot, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(snapshotRoot, p)
+		if err != nil {
+			return err
+		}
+		entries[snapshotsDir+"/"+filepath.ToSlash(rel)] = p
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to collect snapshots: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return fmt.Errorf("no data found for session %s", sessionID)
+	}
+
+	manifest := BundleManifest{
+		Version:   bundleVersion,
+		SessionID: sessionID,
+		CreatedAt: time.Now().UTC(),
+	}
+	for name := range entries {
+		manifest.Files = append(manifest.Files, name)
+	}
+	sort.Strings(manifest.Files)
+
+	out, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := writeZipEntry(zw, manifestEntry, manifestData); err != nil {
+		return err
+	}
+
+	for _, name := range manifest.Files {
+		data, err := os.ReadFile(entries[name])
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", entries[name], err)
+		}
+		if err := writeZipEntry(zw, name, data); err != nil {
+			return err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize bundle: %w", err)
+	}
+
+	return out.Close()
+}
+
+func writeZipEntry(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to bundle: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to bundle: %w", name, err)
+	}
+	return nil
+}
+
+func importBundle(dir, bundlePath string) (*Bundle, error) {
+	zr, err := zip.OpenReader(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer zr.Close()
+
+	contents := make(map[string][]byte)
+	for _, f := range zr.File {
+		name := path.Clean(f.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("bundle entry escapes bundle root: %q", f.Name)
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		contents[name] = data
+	}
+
+	manifestData, ok := contents[manifestEntry]
+	if !ok {
+		return nil, fmt.Errorf("bundle is missing %s", manifestEntry)
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(manifestData, &bundle.Manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if bundle.Manifest.Version != bundleVersion {
+		return nil, fmt.Errorf("unsupported bundle version %d (expected %d)", bundle.Manifest.Version, bundleVersion)
+	}
+	sessionID := bundle.Manifest.SessionID
+	if !validSessionID(sessionID) {
+		return nil, fmt.Errorf("invalid session ID in manifest: %q", sessionID)
+	}
+
+	bundle.Reports = make(map[string]string)
+	for _, name := range bundle.Manifest.Files {
+		data, ok := contents[name]
+		if !ok {
+			return nil, fmt.Errorf("bundle is missing %s listed in manifest", name)
+		}
+
+		var target string
+		switch {
+		case name == planEntry:
+			plan, err := models.DecodePlan(data)
+			if err != nil {
+				return nil, err
+			}
+			bundle.Plan = plan
+			target = planPath(dir, sessionID)
+		case strings.HasPrefix(name, reportsPrefix):
+			base := strings.TrimPrefix(name, reportsPrefix)
+			bundle.Reports[base] = string(data)
+			target = filepath.Join(dir, filepath.FromSlash(base))
+		case strings.HasPrefix(name, snapshotsDir+"/"):
+			rel := strings.TrimPrefix(name, snapshotsDir+"/")
+			target = filepath.Join(dir, snapshotsDir, sessionID, filepath.FromSlash(rel))
+		default:
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", name, err)
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", target, err)
+		}
+	}
+
+	return &bundle, nil
+}
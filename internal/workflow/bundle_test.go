@@ -0,0 +1,135 @@
+package workflow
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	srcDir := t.TempDir()
+	plan := &models.RefactorPlan{
+		SessionID:   "sess-1",
+		Description: "extract shared helper",
+		Changes: []models.FileChange{
+			{Path: "pkg/a.go", Original: "old", Modified: "new"},
+		},
+	}
+	if err := savePlan(srcDir, plan); err != nil {
+		t.Fatalf("savePlan returned error: %v", err)
+	}
+
+	report := "# reducto Compression Report\n\nSession: sess-1\n"
+	files := map[string]string{
+		"reducto-report-sess-1.md":       report,
+		"reducto-report-other.md":        "unrelated",
+		"snapshots/sess-1/pkg/a.go.orig": "old",
+		"snapshots/sess-1/pkg/a.go.new":  "new",
+		"snapshots/other/pkg/b.go.orig":  "unrelated",
+	}
+	for name, content := range files {
+		p := filepath.Join(srcDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	bundlePath := filepath.Join(t.TempDir(), "sess-1"+BundleExt)
+	if err := exportBundle(srcDir, "sess-1", bundlePath); err != nil {
+		t.Fatalf("exportBundle returned error: %v", err)
+	}
+
+	dstDir := t.TempDir()
+	bundle, err := importBundle(dstDir, bundlePath)
+	if err != nil {
+		t.Fatalf("importBundle returned error: %v", err)
+	}
+
+	if bundle.Manifest.SessionID != "sess-1" || bundle.Manifest.Version != bundleVersion {
+		t.Errorf("unexpected manifest %+v", bundle.Manifest)
+	}
+	if len(bundle.Manifest.Files) != 4 {
+		t.Errorf("expected 4 bundled files, got %v", bundle.Manifest.Files)
+	}
+
+	if bundle.Plan == nil || bundle.Plan.Description != plan.Description || len(bundle.Plan.Changes) != 1 {
+		t.Fatalf("expected plan to be recovered, got %+v", bundle.Plan)
+	}
+	if got := bundle.Reports["reducto-report-sess-1.md"]; got != report {
+		t.Errorf("expected report to be recovered, got %q", got)
+	}
+
+	loaded, err := loadPlan(dstDir, "sess-1")
+	if err != nil {
+		t.Fatalf("loadPlan after import returned error: %v", err)
+	}
+	if loaded.Changes[0].Modified != "new" {
+		t.Errorf("unexpected imported plan %+v", loaded)
+	}
+
+	snapshot, err := os.ReadFile(filepath.Join(dstDir, "snapshots", "sess-1", "pkg", "a.go.orig"))
+	if err != nil || string(snapshot) != "old" {
+		t.Errorf("expected snapshot to be restored, got %q (%v)", snapshot, err)
+	}
+	if _, err := os.Stat(filepath.Join(dstDir, "reducto-report-other.md")); !os.IsNotExist(err) {
+		t.Error("expected unrelated reports to be excluded from the bundle")
+	}
+}
+
+func TestImportBundleRejectsUnknownVersion(t *testing.T) {
+	bundlePath := filepath.Join(t.TempDir(), "future"+BundleExt)
+
+	f, err := os.Create(bundlePath)
+	if err != nil {
+		t.Fatalf("failed to create bundle: %v", err)
+	}
+	zw := zip.NewWriter(f)
+	w, _ := zw.Create(manifestEntry)
+	json.NewEncoder(w).Encode(BundleManifest{Version: bundleVersion + 1, SessionID: "future"})
+	zw.Close()
+	f.Close()
+
+	_, err = importBundle(t.TempDir(), bundlePath)
+	if err == nil || !strings.Contains(err.Error(), "unsupported bundle version") {
+		t.Errorf("expected unsupported version error, got %v", err)
+	}
+}
+
+func TestPlanMetadataRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	plan := &models.RefactorPlan{
+		SessionID: "sess-meta",
+		Metadata:  map[string]string{"pr": "42", "user": "dana", "reason": "cleanup"},
+	}
+	if err := savePlan(dir, plan); err != nil {
+		t.Fatalf("savePlan returned error: %v", err)
+	}
+
+	loaded, err := loadPlan(dir, "sess-meta")
+	if err != nil {
+		t.Fatalf("loadPlan returned error: %v", err)
+	}
+	if len(loaded.Metadata) != 3 || loaded.Metadata["pr"] != "42" || loaded.Metadata["reason"] != "cleanup" {
+		t.Errorf("expected metadata to survive round trip, got %v", loaded.Metadata)
+	}
+
+	bare := &models.RefactorPlan{SessionID: "sess-bare"}
+	if err := savePlan(dir, bare); err != nil {
+		t.Fatalf("savePlan returned error: %v", err)
+	}
+	data, err := os.ReadFile(planPath(dir, "sess-bare"))
+	if err != nil {
+		t.Fatalf("failed to read plan: %v", err)
+	}
+	if strings.Contains(string(data), "metadata") {
+		t.Errorf("expected empty metadata to be omitted, got %s", data)
+	}
+}
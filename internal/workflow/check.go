@@ -0,0 +1,56 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/alexkarsten/reducto/internal/runner"
+)
+
+type Checker interface {
+	RunLint() (*runner.LintResult, error)
+	RunTests() (*runner.TestResult, error)
+}
+
+var _ Checker = (*runner.Runner)(nil)
+
+type CheckOptions struct {
+	LintGatesTests bool
+}
+
+type CombinedResult struct {
+	Lint         *runner.LintResult
+	Tests        *runner.TestResult
+	LintErrors   int
+	TestsSkipped bool
+	Success      bool
+}
+
+func CheckPipeline(r Checker, opts CheckOptions) (*CombinedResult, error) {
+	combined := &CombinedResult{}
+
+	lint, err := r.RunLint()
+	if err != nil {
+		return combined, fmt.Errorf("failed to run lint: %w", err)
+	}
+	combined.Lint = lint
+
+	for _, issue := range lint.Issues {
+		if issue.IsError() {
+			combined.LintErrors++
+		}
+	}
+
+	if opts.LintGatesTests && combined.LintErrors > 0 {
+		combined.TestsSkipped = true
+		return combined, nil
+	}
+
+	tests, err := r.RunTests()
+	if err != nil {
+		return combined, fmt.Errorf("failed to run tests: %w", err)
+	}
+	combined.Tests = tests
+	combined.Success = combined.LintErrors == 0 && tests.Success
+
+	return combined, nil
+}
@@ -0,0 +1,157 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/internal/runner"
+	"github.com/alexkarsten/reducto/pkg/models"
+)
+
+type fakeChecker struct {
+	lint  *runner.LintResult
+	tests *runner.TestResult
+	calls []string
+}
+
+func (f *fakeChecker) RunLint() (*runner.LintResult, error) {
+	f.calls = append(f.calls, "lint")
+	return f.lint, nil
+}
+
+func (f *fakeChecker) RunTests() (*runner.TestResult, error) {
+	f.calls = append(f.calls, "tests")
+	return f.tests, nil
+}
+
+func TestCheckPipeline(t *testing.T) {
+	lintError := &runner.LintResult{
+		Issues: []models.LintIssue{
+			{File: "main.go", Line: 3, Message: "undefined: helper", Severity: "error"},
+			{File: "main.go", Line: 7, Message: "unused variable", Severity: "warning"},
+		},
+	}
+	lintWarning := &runner.LintResult{
+		Issues: []models.LintIssue{
+			{File: "main.go", Line: 7, Message: "unused variable", Severity: "warning"},
+		},
+	}
+	passing := &runner.TestResult{Success: true}
+
+	tests := []struct {
+		name          string
+		opts          CheckOptions
+		lint          *runner.LintResult
+		expectedCalls string
+		wantSkipped   bool
+		wantSuccess   bool
+	}{
+		{
+			name:          "lint error skips tests when gating",
+			opts:          CheckOptions{LintGatesTests: true},
+			lint:          lintError,
+			expectedCalls: "lint",
+			wantSkipped:   true,
+		},
+		{
+			name:          "lint warnings still run tests when gating",
+			opts:          CheckOptions{LintGatesTests: true},
+			lint:          lintWarning,
+			expectedCalls: "lint tests",
+			wantSuccess:   true,
+		},
+		{
+			name:          "lint error runs tests without gating",
+			opts:          CheckOptions{},
+			lint:          lintError,
+			expectedCalls: "lint tests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeChecker{lint: tt.lint, tests: passing}
+
+			result, err := CheckPipeline(f, tt.opts)
+			if err != nil {
+				t.Fatalf("CheckPipeline returned error: %v", err)
+			}
+
+			if calls := strings.Join(f.calls, " "); calls != tt.expectedCalls {
+				t.Errorf("expected calls %q, got %q", tt.expectedCalls, calls)
+			}
+			if result.TestsSkipped != tt.wantSkipped {
+				t.Errorf("expected TestsSkipped=%v, got %v", tt.wantSkipped, result.TestsSkipped)
+			}
+			if tt.wantSkipped && result.Tests != nil {
+				t.Error("expected no test result when tests are skipped")
+			}
+			if result.Success != tt.wantSuccess {
+				t.Errorf("expected Success=%v, got %v", tt.wantSuccess, result.Success)
+			}
+		})
+	}
+}
+
+func TestCheckPipelineParsedLintOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell lint command")
+	}
+
+	tests := []struct {
+		name        string
+		marker      string
+		lintOutput  string
+		wantErrors  int
+		wantSkipped bool
+	}{
+		{
+			name:        "ruff undefined name gates tests",
+			marker:      "pyproject.toml",
+			lintOutput:  "app.py:3:5: F821 Undefined name `helper`\napp.py:7:89: E501 Line too long (101 > 88)\n",
+			wantErrors:  1,
+			wantSkipped: true,
+		},
+		{
+			name:       "ruff style findings do not gate tests",
+			marker:     "pyproject.toml",
+			lintOutput: "app.py:7:89: E501 Line too long (101 > 88)\n",
+		},
+		{
+			name:        "golangci typecheck gates tests",
+			marker:      "go.mod",
+			lintOutput:  "main.go:3:2: undefined: helper (typecheck)\nmain.go:9:6: func `unused` is unused (unused)\n",
+			wantErrors:  1,
+			wantSkipped: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			files := map[string]string{tt.marker: "", "lint.txt": tt.lintOutput}
+			for name, content := range files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+
+			r := runner.New(dir)
+			r.SetConfig(runner.RunnerConfig{LintCommand: "cat lint.txt; exit 1", TestCommand: "true", Shell: true})
+
+			result, err := CheckPipeline(r, CheckOptions{LintGatesTests: true})
+			if err != nil {
+				t.Fatalf("CheckPipeline returned error: %v", err)
+			}
+			if result.LintErrors != tt.wantErrors {
+				t.Errorf("expected %d lint errors, got %d (%+v)", tt.wantErrors, result.LintErrors, result.Lint.Issues)
+			}
+			if result.TestsSkipped != tt.wantSkipped {
+				t.Errorf("expected TestsSkipped=%v, got %v", tt.wantSkipped, result.TestsSkipped)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexkarsten/reducto/internal/runner"
+)
+
+type Verifier interface {
+	Build() (*runner.TestResult, error)
+	RunTests() (*runner.TestResult, error)
+}
+
+type Rollbacker interface {
+	Rollback() error
+}
+
+type VerifyOptions struct {
+	VerifyBuild bool
+	RunTests    bool
+}
+
+type Verification struct {
+	Build      *runner.TestResult
+	Tests      *runner.TestResult
+	RolledBack bool
+}
+
+type VerificationError struct {
+	Stage       string
+	Output      string
+	BuildErrors []runner.BuildError
+}
+
+func (e *VerificationError) Error() string {
+	if len(e.BuildErrors) == 0 {
+		return fmt.Sprintf("%s failed", e.Stage)
+	}
+
+	lines := make([]string, len(e.BuildErrors))
+	for i, be := range e.BuildErrors {
+		lines[i] = fmt.Sprintf("%s:%d: %s", be.File, be.Line, be.Message)
+	}
+	return fmt.Sprintf("%s failed with %d error(s): %s", e.Stage, len(e.BuildErrors), strings.Join(lines, "; "))
+}
+
+func VerifyChanges(g Rollbacker, r Verifier, opts VerifyOptions) (*Verification, error) {
+	v := &Verification{}
+
+	if opts.VerifyBuild {
+		result, err := r.Build()
+		if err != nil {
+			return v, rollbackAfter(g, v, fmt.Errorf("failed to run build: %w", err))
+		}
+		v.Build = result
+		if !result.Success {
+			return v, rollbackAfter(g, v, &VerificationError{
+				Stage:       "build",
+				Output:      result.Output,
+				BuildErrors: result.BuildErrors,
+			})
+		}
+	}
+
+	if opts.RunTests {
+		result, err := r.RunTests()
+		if err != nil {
+			return v, rollbackAfter(g, v, fmt.Errorf("failed to run tests: %w", err))
+		}
+		v.Tests = result
+		if !result.Success {
+			return v, rollbackAfter(g, v, &VerificationError{
+				Stage:  "tests",
+				Output: result.Output,
+			})
+		}
+	}
+
+	return v, nil
+}
+
+func rollbackAfter(g Rollbacker, v *Verification, cause error) error {
+	if err := g.Rollback(); err != nil {
+		return fmt.Errorf("%w (rollback failed: %v)", cause, err)
+	}
+	v.RolledBack = true
+	return cause
+}
@@ -0,0 +1,117 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/internal/runner"
+)
+
+type fakeVerifier struct {
+	build *runner.TestResult
+	tests *runner.TestResult
+	calls []string
+}
+
+func (f *fakeVerifier) Build() (*runner.TestResult, error) {
+	f.calls = append(f.calls, "build")
+	return f.build, nil
+}
+
+func (f *fakeVerifier) RunTests() (*runner.TestResult, error) {
+	f.calls = append(f.calls, "tests")
+	return f.tests, nil
+}
+
+func (f *fakeVerifier) Rollback() error {
+	f.calls = append(f.calls, "rollback")
+	return nil
+}
+
+func TestVerifyChanges(t *testing.T) {
+	passing := &runner.TestResult{Success: true}
+	brokenBuild := &runner.TestResult{
+		Success: false,
+		Output:  "src/app.ts(3,5): error TS2304: Cannot find name 'helper'.",
+		BuildErrors: []runner.BuildError{
+			{File: "src/app.ts", Line: 3, Column: 5, Code: "TS2304", Message: "Cannot find name 'helper'."},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		opts          VerifyOptions
+		build         *runner.TestResult
+		tests         *runner.TestResult
+		expectedCalls string
+		wantStage     string
+	}{
+		{
+			name:          "build failure rolls back before tests",
+			opts:          VerifyOptions{VerifyBuild: true, RunTests: true},
+			build:         brokenBuild,
+			tests:         passing,
+			expectedCalls: "build rollback",
+			wantStage:     "build",
+		},
+		{
+			name:          "test failure rolls back after build",
+			opts:          VerifyOptions{VerifyBuild: true, RunTests: true},
+			build:         passing,
+			tests:         &runner.TestResult{Success: false, Output: "FAIL"},
+			expectedCalls: "build tests rollback",
+			wantStage:     "tests",
+		},
+		{
+			name:          "build skipped when disabled",
+			opts:          VerifyOptions{RunTests: true},
+			build:         brokenBuild,
+			tests:         passing,
+			expectedCalls: "tests",
+		},
+		{
+			name:          "all passing",
+			opts:          VerifyOptions{VerifyBuild: true, RunTests: true},
+			build:         passing,
+			tests:         passing,
+			expectedCalls: "build tests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeVerifier{build: tt.build, tests: tt.tests}
+
+			v, err := VerifyChanges(f, f, tt.opts)
+
+			if got := strings.Join(f.calls, " "); got != tt.expectedCalls {
+				t.Errorf("expected calls %q, got %q", tt.expectedCalls, got)
+			}
+
+			if tt.wantStage == "" {
+				if err != nil {
+					t.Fatalf("VerifyChanges returned error: %v", err)
+				}
+				if v.RolledBack {
+					t.Error("expected no rollback")
+				}
+				return
+			}
+
+			var verr *VerificationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected VerificationError, got %v", err)
+			}
+			if verr.Stage != tt.wantStage {
+				t.Errorf("expected stage %s, got %s", tt.wantStage, verr.Stage)
+			}
+			if !v.RolledBack {
+				t.Error("expected rollback")
+			}
+			if tt.wantStage == "build" && !strings.Contains(err.Error(), "src/app.ts:3: Cannot find name 'helper'.") {
+				t.Errorf("expected build errors in failure, got %q", err.Error())
+			}
+		})
+	}
+}
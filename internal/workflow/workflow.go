@@ -0,0 +1,39 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexkarsten/reducto/internal/git"
+	"github.com/alexkarsten/reducto/internal/runner"
+)
+
+func CheckpointWithVerification(msg string, g *git.Manager, r *runner.Runner) (*runner.TestResult, error) {
+	if err := g.CreateCheckpoint(msg); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+
+	result, err := r.RunTests()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run tests: %w", err)
+	}
+
+	message := strings.TrimRight(msg, "\n") + "\n\n" + git.TestsTrailer + ": " + TestSummary(result)
+	if err := g.AmendMessage(message); err != nil {
+		return result, fmt.Errorf("failed to record test result: %w", err)
+	}
+
+	return result, nil
+}
+
+func TestSummary(result *runner.TestResult) string {
+	status := "FAIL"
+	if result.Success {
+		status = "PASS"
+	}
+
+	if !result.CountsMeasured {
+		return status
+	}
+	return fmt.Sprintf("%s %d/%d", status, result.Passed, result.Total)
+}
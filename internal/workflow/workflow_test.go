@@ -0,0 +1,73 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexkarsten/reducto/internal/git"
+	"github.com/alexkarsten/reducto/internal/runner"
+	gogit "github.com/go-git/go-git/v5"
+)
+
+func TestCheckpointWithVerification(t *testing.T) {
+	tmpDir := t.TempDir()
+	if _, err := gogit.PlainInit(tmpDir, false); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	r := runner.New(tmpDir)
+	r.SetConfig(runner.RunnerConfig{
+		TestCommand: "echo '=== RUN   TestA'; echo '--- PASS: TestA'; echo '=== RUN   TestB'; echo '--- PASS: TestB'",
+		Shell:       true,
+	})
+	mgr := git.NewManager(tmpDir)
+
+	result, err := CheckpointWithVerification("checkpoint before refactor", mgr, r)
+	if err != nil {
+		t.Fatalf("CheckpointWithVerification returned error: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("expected passing tests, got output: %s", result.Output)
+	}
+
+	checkpoints, err := mgr.ListCheckpoints()
+	if err != nil {
+		t.Fatalf("ListCheckpoints returned error: %v", err)
+	}
+	if len(checkpoints) != 1 {
+		t.Fatalf("expected 1 checkpoint, got %d", len(checkpoints))
+	}
+
+	cp := checkpoints[0]
+	if !strings.HasSuffix(cp.Message, "Dehydrator-Tests: PASS 2/2") {
+		t.Errorf("expected test summary trailer, got message %q", cp.Message)
+	}
+	if cp.TestStatus != "PASS 2/2" {
+		t.Errorf("expected test status PASS 2/2, got %q", cp.TestStatus)
+	}
+}
+
+func TestTestSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   runner.TestResult
+		expected string
+	}{
+		{"pass with counts", runner.TestResult{Success: true, Passed: 42, Total: 42, CountsMeasured: true}, "PASS 42/42"},
+		{"fail with counts", runner.TestResult{Passed: 40, Total: 42, CountsMeasured: true}, "FAIL 40/42"},
+		{"pass without counts", runner.TestResult{Success: true}, "PASS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TestSummary(&tt.result); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
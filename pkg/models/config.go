@@ -23,10 +23,12 @@ type ModelsConfig struct {
 }
 
 type SidecarConfig struct {
-	Port            int  `mapstructure:"port" yaml:"port"`
-	StartupTimeout  int  `mapstructure:"startup_timeout" yaml:"startup_timeout"`
-	ShutdownTimeout int  `mapstructure:"shutdown_timeout" yaml:"shutdown_timeout"`
-	AutoInstall     bool `mapstructure:"auto_install" yaml:"auto_install"`
+	Port            int      `mapstructure:"port" yaml:"port"`
+	StartupTimeout  int      `mapstructure:"startup_timeout" yaml:"startup_timeout"`
+	ShutdownTimeout int      `mapstructure:"shutdown_timeout" yaml:"shutdown_timeout"`
+	AutoInstall     bool     `mapstructure:"auto_install" yaml:"auto_install"`
+	PythonPath      string   `mapstructure:"python_path" yaml:"python_path"`
+	ExtraArgs       []string `mapstructure:"extra_args" yaml:"extra_args"`
 }
 
 type ComplexityThresholds struct {
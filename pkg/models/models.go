@@ -1,11 +1,47 @@
 package models
 
-import "time"
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
 
 type FileInfo struct {
-	Path    string `json:"path"`
-	Content string `json:"content"`
-	Hash    string `json:"hash,omitempty"`
+	Path     string   `json:"path"`
+	Content  string   `json:"content"`
+	Hash     string   `json:"hash,omitempty"`
+	Language Language `json:"language,omitempty"`
+}
+
+func (f FileInfo) Redacted() FileInfo {
+	return FileInfo{
+		Path:     f.Path,
+		Hash:     f.Hash,
+		Language: f.Language,
+	}
+}
+
+type AnalyzeRequest struct {
+	Path  string     `json:"path"`
+	Files []FileInfo `json:"files"`
+}
+
+func (r AnalyzeRequest) Redacted() AnalyzeRequest {
+	redacted := AnalyzeRequest{Path: r.Path}
+	if r.Files != nil {
+		redacted.Files = make([]FileInfo, len(r.Files))
+		for i, f := range r.Files {
+			redacted.Files[i] = f.Redacted()
+		}
+	}
+	return redacted
 }
 
 type Language string
@@ -18,6 +54,81 @@ const (
 	LanguageUnknown    Language = "unknown"
 )
 
+func LanguageFromPath(path string) Language {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".py", ".ipynb":
+		return LanguagePython
+	case ".js":
+		return LanguageJavaScript
+	case ".ts", ".tsx":
+		return LanguageTypeScript
+	case ".go":
+		return LanguageGo
+	default:
+		return LanguageUnknown
+	}
+}
+
+var shebangInterpreters = map[string]Language{
+	"python":  LanguagePython,
+	"python2": LanguagePython,
+	"python3": LanguagePython,
+	"node":    LanguageJavaScript,
+	"nodejs":  LanguageJavaScript,
+	"deno":    LanguageTypeScript,
+	"ts-node": LanguageTypeScript,
+}
+
+func LanguageFromShebang(content string) Language {
+	if !strings.HasPrefix(content, "#!") {
+		return LanguageUnknown
+	}
+
+	line := content[2:]
+	if idx := strings.IndexByte(line, '\n'); idx >= 0 {
+		line = line[:idx]
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return LanguageUnknown
+	}
+
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		args := fields[1:]
+		for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			return LanguageUnknown
+		}
+		interpreter = filepath.Base(args[0])
+	}
+
+	if lang, ok := shebangInterpreters[interpreter]; ok {
+		return lang
+	}
+	if strings.HasPrefix(interpreter, "python3.") {
+		return LanguagePython
+	}
+	return LanguageUnknown
+}
+
+func DetectLanguage(path, content string) Language {
+	if lang := LanguageFromPath(path); lang != LanguageUnknown {
+		return lang
+	}
+	return LanguageFromShebang(content)
+}
+
+type LanguageStat struct {
+	Language Language `json:"language"`
+	Files    int      `json:"files"`
+	Lines    int      `json:"lines"`
+	Symbols  int      `json:"symbols"`
+}
+
 type Symbol struct {
 	Name       string   `json:"name"`
 	Type       string   `json:"type"`
@@ -26,6 +137,35 @@ type Symbol struct {
 	EndLine    int      `json:"end_line"`
 	Signature  string   `json:"signature,omitempty"`
 	References []string `json:"references,omitempty"`
+	Exported   *bool    `json:"exported,omitempty"`
+
+	CyclomaticComplexity int `json:"cyclomatic_complexity,omitempty"`
+}
+
+func (s Symbol) IsExported() bool {
+	if s.Exported != nil {
+		return *s.Exported
+	}
+
+	name := s.Name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	if name == "" {
+		return false
+	}
+
+	switch LanguageFromPath(s.File) {
+	case LanguageGo:
+		r, _ := utf8.DecodeRuneInString(name)
+		return unicode.IsUpper(r)
+	case LanguagePython:
+		return !strings.HasPrefix(name, "_")
+	case LanguageJavaScript, LanguageTypeScript:
+		return strings.HasPrefix(strings.TrimSpace(s.Signature), "export ")
+	default:
+		return true
+	}
 }
 
 type ComplexityMetrics struct {
@@ -49,6 +189,51 @@ type CodeBlock struct {
 	Embedding  []float32         `json:"embedding,omitempty"`
 }
 
+// BlockID hashes a block's location and symbol name, so two symbols sharing a
+// line range (e.g. a decorator and the function it wraps) keep distinct IDs.
+// Fields are NUL-separated so a ':' in a path or name cannot shift them.
+func BlockID(file, symbolName string, startLine, endLine int) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s\x00%d\x00%d\x00%s", file, startLine, endLine, symbolName)))
+	return hex.EncodeToString(sum[:])
+}
+
+func ExtractBlocks(file FileInfo, symbols []Symbol) []CodeBlock {
+	lines := strings.Split(file.Content, "\n")
+	language := LanguageFromPath(file.Path)
+
+	var blocks []CodeBlock
+	for _, sym := range symbols {
+		if sym.File != "" && sym.File != file.Path {
+			continue
+		}
+
+		start := sym.StartLine
+		if start < 1 {
+			start = 1
+		}
+		end := sym.EndLine
+		if end > len(lines) {
+			end = len(lines)
+		}
+		if start > end {
+			continue
+		}
+
+		blocks = append(blocks, CodeBlock{
+			ID:         BlockID(file.Path, sym.Name, start, end),
+			File:       file.Path,
+			StartLine:  start,
+			EndLine:    end,
+			Content:    strings.Join(lines[start-1:end], "\n"),
+			Language:   language,
+			SymbolType: sym.Type,
+			SymbolName: sym.Name,
+		})
+	}
+
+	return blocks
+}
+
 type DuplicateGroup struct {
 	ID           string      `json:"id"`
 	Blocks       []CodeBlock `json:"blocks"`
@@ -56,12 +241,60 @@ type DuplicateGroup struct {
 	SuggestedFix string      `json:"suggested_fix,omitempty"`
 }
 
+func (g DuplicateGroup) Summary() string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, block := range g.Blocks {
+		if !seen[block.File] {
+			seen[block.File] = true
+			files = append(files, block.File)
+		}
+	}
+
+	kind := "similar"
+	if g.Similarity >= 0.9 {
+		kind = "near-identical"
+	}
+
+	fix := g.SuggestedFix
+	if fix == "" {
+		fix = "extract shared helper"
+	}
+
+	return fmt.Sprintf("%d %s blocks (%.0f%%) in %s — %s",
+		len(g.Blocks), kind, g.Similarity*100, strings.Join(files, ", "), fix)
+}
+
+// TotalDuplicateLines counts the lines that would disappear if every block
+// but the longest were replaced by a call to a shared helper.
+func (g DuplicateGroup) TotalDuplicateLines() int {
+	total := 0
+	longest := 0
+	for _, block := range g.Blocks {
+		lines := block.EndLine - block.StartLine + 1
+		if lines < 0 {
+			lines = 0
+		}
+		total += lines
+		if lines > longest {
+			longest = lines
+		}
+	}
+	return total - longest
+}
+
 type RefactorPlan struct {
-	SessionID   string       `json:"session_id"`
-	Changes     []FileChange `json:"changes"`
-	Description string       `json:"description"`
-	Pattern     string       `json:"pattern,omitempty"`
-	CreatedAt   time.Time    `json:"created_at"`
+	SchemaVersion int               `json:"schema_version"`
+	SessionID     string            `json:"session_id"`
+	Changes       []FileChange      `json:"changes"`
+	Description   string            `json:"description"`
+	Pattern       string            `json:"pattern,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
+
+func (p *RefactorPlan) HasConflicts() bool {
+	return len(DetectConflicts(p.Changes)) > 0
 }
 
 type FileChange struct {
@@ -69,6 +302,100 @@ type FileChange struct {
 	Original    string `json:"original"`
 	Modified    string `json:"modified"`
 	Description string `json:"description"`
+	StartLine   int    `json:"start_line,omitempty"`
+	EndLine     int    `json:"end_line,omitempty"`
+}
+
+type DiffOptions struct {
+	IgnoreWhitespace bool
+}
+
+func (o DiffOptions) LinesEqual(a, b string) bool {
+	if o.IgnoreWhitespace {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+	return a == b
+}
+
+type DiffStat struct {
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+}
+
+func (c FileChange) Stat(opts DiffOptions) DiffStat {
+	origLines := strings.Split(c.Original, "\n")
+	modLines := strings.Split(c.Modified, "\n")
+
+	var stat DiffStat
+	for i := 0; i < len(origLines) || i < len(modLines); i++ {
+		switch {
+		case i >= len(modLines):
+			stat.Removed++
+		case i >= len(origLines):
+			stat.Added++
+		case !opts.LinesEqual(origLines[i], modLines[i]):
+			stat.Removed++
+			stat.Added++
+		}
+	}
+
+	return stat
+}
+
+func (c FileChange) hasLineRange() bool {
+	return c.StartLine > 0 && c.EndLine >= c.StartLine
+}
+
+type Conflict struct {
+	Path   string `json:"path"`
+	First  int    `json:"first"`
+	Second int    `json:"second"`
+	Reason string `json:"reason"`
+}
+
+func DetectConflicts(changes []FileChange) []Conflict {
+	var conflicts []Conflict
+
+	byPath := make(map[string][]int)
+	var paths []string
+	for i, change := range changes {
+		if _, ok := byPath[change.Path]; !ok {
+			paths = append(paths, change.Path)
+		}
+		byPath[change.Path] = append(byPath[change.Path], i)
+	}
+
+	for _, path := range paths {
+		indices := byPath[path]
+		for a := 0; a < len(indices); a++ {
+			for b := a + 1; b < len(indices); b++ {
+				first, second := changes[indices[a]], changes[indices[b]]
+
+				if first.hasLineRange() && second.hasLineRange() {
+					if first.EndLine < second.StartLine || second.EndLine < first.StartLine {
+						continue
+					}
+					conflicts = append(conflicts, Conflict{
+						Path:   path,
+						First:  indices[a],
+						Second: indices[b],
+						Reason: fmt.Sprintf("overlapping lines %d-%d and %d-%d",
+							first.StartLine, first.EndLine, second.StartLine, second.EndLine),
+					})
+					continue
+				}
+
+				conflicts = append(conflicts, Conflict{
+					Path:   path,
+					First:  indices[a],
+					Second: indices[b],
+					Reason: "multiple changes to the same file",
+				})
+			}
+		}
+	}
+
+	return conflicts
 }
 
 type RefactorResult struct {
@@ -79,18 +406,41 @@ type RefactorResult struct {
 	Error         string            `json:"error,omitempty"`
 	MetricsBefore ComplexityMetrics `json:"metrics_before"`
 	MetricsAfter  ComplexityMetrics `json:"metrics_after"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
+
+// Fingerprint hashes the parts of the result a report is rendered from. It
+// returns "" when the result cannot be encoded (e.g. a NaN metric); callers
+// must treat an empty fingerprint as matching nothing.
+func (r *RefactorResult) Fingerprint() string {
+	data, err := json.Marshal(struct {
+		SessionID     string            `json:"session_id"`
+		Changes       []FileChange      `json:"changes"`
+		MetricsBefore ComplexityMetrics `json:"metrics_before"`
+		MetricsAfter  ComplexityMetrics `json:"metrics_after"`
+		Metadata      map[string]string `json:"metadata,omitempty"`
+	}{r.SessionID, r.Changes, r.MetricsBefore, r.MetricsAfter, r.Metadata})
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
 }
 
 type Report struct {
-	SessionID       string           `json:"session_id"`
-	GeneratedAt     time.Time        `json:"generated_at"`
-	LOCBefore       int              `json:"loc_before"`
-	LOCAfter        int              `json:"loc_after"`
-	LOCReduced      int              `json:"loc_reduced"`
-	DuplicatesFound int              `json:"duplicates_found"`
-	PatternsApplied []PatternApplied `json:"patterns_applied"`
-	FilesModified   []string         `json:"files_modified"`
-	MetricsDelta    MetricsDelta     `json:"metrics_delta"`
+	SchemaVersion   int               `json:"schema_version"`
+	SessionID       string            `json:"session_id"`
+	GeneratedAt     time.Time         `json:"generated_at"`
+	LOCBefore       int               `json:"loc_before"`
+	LOCAfter        int               `json:"loc_after"`
+	LOCReduced      int               `json:"loc_reduced"`
+	DuplicatesFound int               `json:"duplicates_found"`
+	PatternsApplied []PatternApplied  `json:"patterns_applied"`
+	FilesModified   []string          `json:"files_modified"`
+	MetricsDelta    MetricsDelta      `json:"metrics_delta"`
+	Fingerprint     string            `json:"fingerprint,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
 }
 
 type PatternApplied struct {
@@ -104,3 +454,22 @@ type MetricsDelta struct {
 	CognitiveComplexityDelta  int     `json:"cognitive_complexity_delta"`
 	MaintainabilityIndexDelta float64 `json:"maintainability_index_delta"`
 }
+
+type LintIssue struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column,omitempty"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+	Rule     string `json:"rule,omitempty"`
+	DocURL   string `json:"doc_url,omitempty"`
+}
+
+func (i LintIssue) IsError() bool {
+	switch strings.ToLower(i.Severity) {
+	case "error", "fatal":
+		return true
+	default:
+		return false
+	}
+}
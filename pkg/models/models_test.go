@@ -0,0 +1,302 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDuplicateGroupSummary(t *testing.T) {
+	group := DuplicateGroup{
+		ID:         "dup-1",
+		Similarity: 0.92,
+		Blocks: []CodeBlock{
+			{File: "a.py", StartLine: 1, EndLine: 10},
+			{File: "b.py", StartLine: 20, EndLine: 29},
+			{File: "c.py", StartLine: 5, EndLine: 12},
+		},
+	}
+
+	expected := "3 near-identical blocks (92%) in a.py, b.py, c.py — extract shared helper"
+	if got := group.Summary(); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	if got := group.TotalDuplicateLines(); got != 18 {
+		t.Errorf("expected 18 duplicate lines, got %d", got)
+	}
+
+	t.Run("uses suggested fix and dedupes files", func(t *testing.T) {
+		group := DuplicateGroup{
+			Similarity:   0.8,
+			SuggestedFix: "move to utils.normalize",
+			Blocks: []CodeBlock{
+				{File: "a.py", StartLine: 1, EndLine: 3},
+				{File: "a.py", StartLine: 10, EndLine: 12},
+			},
+		}
+
+		expected := "2 similar blocks (80%) in a.py — move to utils.normalize"
+		if got := group.Summary(); got != expected {
+			t.Errorf("expected %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("empty group", func(t *testing.T) {
+		if got := (DuplicateGroup{}).TotalDuplicateLines(); got != 0 {
+			t.Errorf("expected 0 duplicate lines, got %d", got)
+		}
+	})
+}
+
+func TestDetectConflicts(t *testing.T) {
+	t.Run("same file", func(t *testing.T) {
+		changes := []FileChange{
+			{Path: "a.py", Modified: "one"},
+			{Path: "b.py", Modified: "two"},
+			{Path: "a.py", Modified: "three"},
+		}
+
+		conflicts := DetectConflicts(changes)
+		if len(conflicts) != 1 {
+			t.Fatalf("expected 1 conflict, got %d", len(conflicts))
+		}
+		c := conflicts[0]
+		if c.Path != "a.py" || c.First != 0 || c.Second != 2 {
+			t.Errorf("unexpected conflict: %+v", c)
+		}
+
+		plan := &RefactorPlan{Changes: changes}
+		if !plan.HasConflicts() {
+			t.Error("expected plan to report conflicts")
+		}
+	})
+
+	t.Run("distinct files", func(t *testing.T) {
+		plan := &RefactorPlan{Changes: []FileChange{
+			{Path: "a.py"},
+			{Path: "b.py"},
+		}}
+		if conflicts := DetectConflicts(plan.Changes); len(conflicts) != 0 {
+			t.Errorf("expected no conflicts, got %+v", conflicts)
+		}
+		if plan.HasConflicts() {
+			t.Error("expected plan without conflicts")
+		}
+	})
+
+	t.Run("line ranges", func(t *testing.T) {
+		changes := []FileChange{
+			{Path: "a.go", StartLine: 1, EndLine: 10},
+			{Path: "a.go", StartLine: 20, EndLine: 30},
+			{Path: "a.go", StartLine: 25, EndLine: 40},
+		}
+
+		conflicts := DetectConflicts(changes)
+		if len(conflicts) != 1 {
+			t.Fatalf("expected 1 conflict, got %+v", conflicts)
+		}
+		if conflicts[0].First != 1 || conflicts[0].Second != 2 {
+			t.Errorf("expected overlap between changes 1 and 2, got %+v", conflicts[0])
+		}
+	})
+}
+
+func TestRefactorResultFingerprint(t *testing.T) {
+	base := RefactorResult{
+		SessionID:     "s1",
+		Changes:       []FileChange{{Path: "a.py", Original: "a", Modified: "b"}},
+		MetricsBefore: ComplexityMetrics{LinesOfCode: 10},
+	}
+
+	same := base
+	if base.Fingerprint() != same.Fingerprint() {
+		t.Error("expected identical results to share a fingerprint")
+	}
+
+	same.TestsPassed = true
+	if base.Fingerprint() != same.Fingerprint() {
+		t.Error("expected fingerprint to ignore test status")
+	}
+
+	changed := base
+	changed.Changes = []FileChange{{Path: "a.py", Original: "a", Modified: "c"}}
+	if base.Fingerprint() == changed.Fingerprint() {
+		t.Error("expected changed result to have a different fingerprint")
+	}
+
+	unencodable := base
+	unencodable.MetricsAfter.MaintainabilityIndex = math.NaN()
+	if fp := unencodable.Fingerprint(); fp != "" {
+		t.Errorf("expected empty fingerprint for an unencodable result, got %q", fp)
+	}
+}
+
+func TestExtractBlocks(t *testing.T) {
+	file := FileInfo{
+		Path: "pkg/util.py",
+		Content: "import os\n" +
+			"\n" +
+			"def first():\n" +
+			"    return 1\n" +
+			"\n" +
+			"def second(x):\n" +
+			"    y = x * 2\n" +
+			"    return y",
+	}
+
+	symbols := []Symbol{
+		{Name: "first", Type: "function", File: "pkg/util.py", StartLine: 3, EndLine: 4},
+		{Name: "second", Type: "function", StartLine: 6, EndLine: 8},
+		{Name: "overflow", Type: "function", StartLine: 7, EndLine: 50},
+		{Name: "zero", Type: "function", StartLine: 0, EndLine: 1},
+		{Name: "inverted", Type: "function", StartLine: 5, EndLine: 3},
+		{Name: "beyond", Type: "function", StartLine: 20, EndLine: 30},
+		{Name: "other", Type: "function", File: "other.py", StartLine: 1, EndLine: 2},
+	}
+
+	blocks := ExtractBlocks(file, symbols)
+
+	expected := []struct {
+		name    string
+		start   int
+		end     int
+		content string
+	}{
+		{"first", 3, 4, "def first():\n    return 1"},
+		{"second", 6, 8, "def second(x):\n    y = x * 2\n    return y"},
+		{"overflow", 7, 8, "    y = x * 2\n    return y"},
+		{"zero", 1, 1, "import os"},
+	}
+
+	if len(blocks) != len(expected) {
+		t.Fatalf("expected %d blocks, got %d", len(expected), len(blocks))
+	}
+
+	for i, want := range expected {
+		block := blocks[i]
+		if block.SymbolName != want.name {
+			t.Errorf("block %d: expected name %s, got %s", i, want.name, block.SymbolName)
+		}
+		if block.StartLine != want.start || block.EndLine != want.end {
+			t.Errorf("block %s: expected lines %d-%d, got %d-%d", want.name, want.start, want.end, block.StartLine, block.EndLine)
+		}
+		if block.Content != want.content {
+			t.Errorf("block %s: expected content %q, got %q", want.name, want.content, block.Content)
+		}
+		if block.Language != LanguagePython {
+			t.Errorf("block %s: expected python, got %s", want.name, block.Language)
+		}
+		if block.SymbolType != "function" || block.File != "pkg/util.py" {
+			t.Errorf("block %s: unexpected metadata %+v", want.name, block)
+		}
+	}
+
+	again := ExtractBlocks(file, symbols)
+	for i := range blocks {
+		if blocks[i].ID != again[i].ID {
+			t.Errorf("expected stable ID, got %s and %s", blocks[i].ID, again[i].ID)
+		}
+	}
+}
+
+func TestBlockID(t *testing.T) {
+	id := BlockID("pkg/a.py", "load", 10, 20)
+
+	if again := BlockID("pkg/a.py", "load", 10, 20); again != id {
+		t.Errorf("expected same inputs to yield same ID, got %s and %s", id, again)
+	}
+	if len(id) != 40 {
+		t.Errorf("expected 40-character hex ID, got %q", id)
+	}
+
+	others := []string{
+		BlockID("pkg/b.py", "load", 10, 20),
+		BlockID("pkg/a.py", "save", 10, 20),
+		BlockID("pkg/a.py", "", 10, 20),
+		BlockID("pkg/a.py", "load", 11, 20),
+		BlockID("pkg/a.py", "load", 10, 21),
+		BlockID("pkg/a.py:10", "load", 20, 20),
+	}
+	for _, other := range others {
+		if other == id {
+			t.Errorf("expected different inputs to yield different IDs, got %s twice", id)
+		}
+	}
+}
+
+func TestFileChangeStat(t *testing.T) {
+	change := FileChange{
+		Original: "func f() {\n\treturn 1\n}",
+		Modified: "func f() {\n    return 1  \n}\n// done",
+	}
+
+	tests := []struct {
+		name     string
+		opts     DiffOptions
+		expected DiffStat
+	}{
+		{"default", DiffOptions{}, DiffStat{Added: 2, Removed: 1}},
+		{"ignore whitespace", DiffOptions{IgnoreWhitespace: true}, DiffStat{Added: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := change.Stat(tt.opts); got != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	file := FileInfo{Path: "config.py", Content: "API_KEY = 'secret'", Hash: "abc123"}
+
+	redacted := file.Redacted()
+	if redacted.Path != "config.py" || redacted.Hash != "abc123" {
+		t.Errorf("expected path and hash to be preserved, got %+v", redacted)
+	}
+	if redacted.Content != "" {
+		t.Errorf("expected empty content, got %q", redacted.Content)
+	}
+	if file.Content != "API_KEY = 'secret'" {
+		t.Error("expected original to be unchanged")
+	}
+
+	req := AnalyzeRequest{Path: ".", Files: []FileInfo{file, {Path: "b.py", Content: "x = 1"}}}
+	redactedReq := req.Redacted()
+	if redactedReq.Path != "." || len(redactedReq.Files) != 2 {
+		t.Fatalf("unexpected redacted request %+v", redactedReq)
+	}
+	for i, f := range redactedReq.Files {
+		if f.Content != "" || f.Path != req.Files[i].Path {
+			t.Errorf("file %d not redacted correctly: %+v", i, f)
+		}
+	}
+	if req.Files[0].Content == "" || req.Files[1].Content == "" {
+		t.Error("expected original request files to be unchanged")
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		expected Language
+	}{
+		{"extension wins", "tool.go", "#!/usr/bin/env python3\n", LanguageGo},
+		{"env python3", "bin/deploy", "#!/usr/bin/env python3\nprint('hi')\n", LanguagePython},
+		{"direct python path", "scripts/run", "#!/usr/local/bin/python3.11\n", LanguagePython},
+		{"env with flags", "bin/serve", "#!/usr/bin/env -S node --harmony\n", LanguageJavaScript},
+		{"shell script", "bin/build", "#!/bin/bash\necho hi\n", LanguageUnknown},
+		{"no shebang", "Makefile", "all:\n\tgo build\n", LanguageUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLanguage(tt.path, tt.content); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
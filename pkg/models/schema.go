@@ -0,0 +1,34 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// CurrentSchemaVersion is stamped on plans and reports written by this build.
+// Version 1 is the original unversioned layout: no "schema_version" field and
+// changes carrying only path, original, modified and description. Every v1 key
+// kept its name and meaning in v2, so v1 plans need no field mapping.
+const CurrentSchemaVersion = 2
+
+// DecodePlan decodes a stored plan of any supported schema version, stamping
+// it with CurrentSchemaVersion, and rejects plans written by a newer build.
+func DecodePlan(raw []byte) (*RefactorPlan, error) {
+	var header struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+
+	if header.SchemaVersion > CurrentSchemaVersion {
+		return nil, fmt.Errorf("plan schema version %d is newer than supported version %d", header.SchemaVersion, CurrentSchemaVersion)
+	}
+
+	var plan RefactorPlan
+	if err := json.Unmarshal(raw, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	plan.SchemaVersion = CurrentSchemaVersion
+	return &plan, nil
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodePlan(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	want := &RefactorPlan{
+		SchemaVersion: CurrentSchemaVersion,
+		SessionID:     "sess-1",
+		Description:   "merge helpers",
+		Pattern:       "extract-function",
+		CreatedAt:     created,
+		Changes: []FileChange{
+			{Path: "a.go", Original: "old a", Modified: "new a", Description: "dedupe a", StartLine: 3, EndLine: 9},
+			{Path: "b.go", Original: "old b", Modified: "new b", Description: "dedupe b"},
+		},
+	}
+
+	current, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("failed to marshal plan: %v", err)
+	}
+
+	wantV1 := &RefactorPlan{
+		SchemaVersion: CurrentSchemaVersion,
+		SessionID:     "sess-1",
+		Description:   "merge helpers",
+		Pattern:       "extract-function",
+		CreatedAt:     created,
+		Changes: []FileChange{
+			{Path: "a.go", Original: "old a", Modified: "new a", Description: "dedupe a"},
+			{Path: "b.go", Original: "old b", Modified: "new b", Description: "dedupe b"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+		want *RefactorPlan
+	}{
+		{
+			name: "v1 baseline layout",
+			raw: `{
+				"session_id": "sess-1",
+				"description": "merge helpers",
+				"pattern": "extract-function",
+				"created_at": "2026-01-02T03:04:05Z",
+				"changes": [
+					{"path": "a.go", "original": "old a", "modified": "new a", "description": "dedupe a"},
+					{"path": "b.go", "original": "old b", "modified": "new b", "description": "dedupe b"}
+				]
+			}`,
+			want: wantV1,
+		},
+		{
+			name: "explicit v1",
+			raw: `{
+				"schema_version": 1,
+				"session_id": "sess-1",
+				"description": "merge helpers",
+				"pattern": "extract-function",
+				"created_at": "2026-01-02T03:04:05Z",
+				"changes": [
+					{"path": "a.go", "original": "old a", "modified": "new a", "description": "dedupe a"},
+					{"path": "b.go", "original": "old b", "modified": "new b", "description": "dedupe b"}
+				]
+			}`,
+			want: wantV1,
+		},
+		{
+			name: "current",
+			raw:  string(current),
+			want: want,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodePlan([]byte(tt.raw))
+			if err != nil {
+				t.Fatalf("DecodePlan returned error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DecodePlan() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodePlanErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr string
+	}{
+		{"invalid json", `{`, "failed to parse plan"},
+		{"future version", `{"schema_version": 99}`, "newer than supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodePlan([]byte(tt.raw))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
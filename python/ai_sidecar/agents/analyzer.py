@@ -345,6 +345,7 @@ class AnalyzerAgent:
         
         for symbol in symbols:
             metrics = await self._calculate_complexity_fast(symbol, file_contents)
+            symbol.cyclomatic_complexity = metrics.cyclomatic_complexity
             if metrics.cyclomatic_complexity >= complexity_threshold:
                 hotspots.append(ComplexityHotspot(
                     file=symbol.file,
@@ -3,6 +3,7 @@ Validator agent for applying refactoring plans and validating changes.
 """
 
 import os
+import stat
 import subprocess
 import tempfile
 from typing import Dict, Optional
@@ -63,6 +64,7 @@ class ValidatorAgent:
                         error=f"Failed to apply change to {change.path}",
                         metrics_before=metrics_before,
                         metrics_after=ComplexityMetrics(),
+                        metadata=plan.metadata,
                     )
 
         tests_passed = await self._run_tests()
@@ -76,6 +78,7 @@ class ValidatorAgent:
             tests_passed=tests_passed,
             metrics_before=metrics_before,
             metrics_after=metrics_after,
+            metadata=plan.metadata,
         )
 
     def _find_plan(self, session_id: str) -> Optional[RefactorPlan]:
@@ -110,16 +113,28 @@ class ValidatorAgent:
             if not os.path.exists(change.path):
                 return False
 
-            with open(change.path, "r", encoding="utf-8") as f:
+            # Read and write without newline translation so CRLF files stay
+            # CRLF, and restore the mode in case the write replaced it.
+            mode = os.stat(change.path).st_mode
+            with open(change.path, "r", encoding="utf-8", newline="") as f:
                 content = f.read()
 
-            if change.original not in content:
+            crlf = _uses_crlf(content)
+            if crlf:
+                content = content.replace("\r\n", "\n")
+            original = change.original.replace("\r\n", "\n")
+            modified = change.modified.replace("\r\n", "\n")
+
+            if original not in content:
                 return False
 
-            new_content = content.replace(change.original, change.modified, 1)
+            new_content = content.replace(original, modified, 1)
+            if crlf:
+                new_content = new_content.replace("\n", "\r\n")
 
-            with open(change.path, "w", encoding="utf-8") as f:
+            with open(change.path, "w", encoding="utf-8", newline="") as f:
                 f.write(new_content)
+            os.chmod(change.path, stat.S_IMODE(mode))
 
             return True
 
@@ -178,3 +193,8 @@ class ValidatorAgent:
             cognitive_complexity=total_cognitive,
             lines_of_code=total_loc,
         )
+
+
+def _uses_crlf(content: str) -> bool:
+    crlf = content.count("\r\n")
+    return crlf > 0 and crlf >= content.count("\n") - crlf
@@ -85,7 +85,13 @@ app.add_middleware(
 
 @app.get("/health")
 async def health_check():
-    return {"status": "healthy", "service": "ai_sidecar"}
+    return {
+        "status": "healthy",
+        "service": "ai_sidecar",
+        "version": app.version,
+        "python_version": sys.version.split()[0],
+        "model_loaded": bool(embedding_service and embedding_service._use_real_embeddings),
+    }
 
 
 @app.post("/shutdown")
@@ -114,7 +114,9 @@ class MCPSidecar:
         request = AnalyzeRequest(path=path, files=files)
         result = await self.analyzer.analyze(request)
         result_dict = result.model_dump()
-        result_dict["symbols"] = len(result.symbols)
+        result_dict["symbols"] = [
+            s.model_dump(exclude={"references"}) for s in result.symbols
+        ]
         return result_dict
 
     async def _deduplicate(self, path: str) -> Dict[str, Any]:
@@ -30,6 +30,8 @@ class Symbol(BaseModel):
     end_line: int
     signature: Optional[str] = None
     references: List[str] = Field(default_factory=list)
+    exported: Optional[bool] = None
+    cyclomatic_complexity: Optional[int] = None
 
 
 class ComplexityMetrics(BaseModel):
@@ -68,11 +70,13 @@ class FileChange(BaseModel):
 
 
 class RefactorPlan(BaseModel):
+    schema_version: int = 2
     session_id: str
     changes: List[FileChange]
     description: str
     pattern: Optional[str] = None
     created_at: datetime = Field(default_factory=datetime.now)
+    metadata: Dict[str, str] = Field(default_factory=dict)
 
 
 class RefactorResult(BaseModel):
@@ -83,6 +87,7 @@ class RefactorResult(BaseModel):
     error: Optional[str] = None
     metrics_before: ComplexityMetrics
     metrics_after: ComplexityMetrics
+    metadata: Dict[str, str] = Field(default_factory=dict)
 
 
 class PatternApplied(BaseModel):
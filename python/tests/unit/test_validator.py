@@ -0,0 +1,71 @@
+"""
+Unit tests for ValidatorAgent._apply_change.
+"""
+
+import os
+import stat
+import sys
+
+import pytest
+
+from ai_sidecar.agents.validator import ValidatorAgent
+from ai_sidecar.models import FileChange
+
+
+class TestApplyChange:
+    """Test that applying a change keeps the file's mode and line endings."""
+
+    @pytest.fixture
+    def agent(self):
+        return ValidatorAgent()
+
+    @pytest.mark.asyncio
+    @pytest.mark.skipif(sys.platform == "win32", reason="no executable bit on windows")
+    async def test_executable_file_keeps_mode(self, agent, tmp_path):
+        """Test that an executable script stays executable."""
+        script = tmp_path / "run.sh"
+        script.write_text("#!/bin/sh\necho old\n")
+        os.chmod(script, 0o755)
+
+        change = FileChange(
+            path=str(script),
+            original="echo old",
+            modified="echo new",
+            description="update message",
+        )
+
+        assert await agent._apply_change(change)
+        assert script.read_text() == "#!/bin/sh\necho new\n"
+        assert stat.S_IMODE(os.stat(script).st_mode) == 0o755
+
+    @pytest.mark.asyncio
+    async def test_crlf_file_keeps_line_endings(self, agent, tmp_path):
+        """Test that a CRLF file is matched with LF text and stays CRLF."""
+        source = tmp_path / "app.py"
+        source.write_bytes(b"def old():\r\n    return 1\r\n\r\nprint(old())\r\n")
+
+        change = FileChange(
+            path=str(source),
+            original="def old():\n    return 1\n",
+            modified="def new():\n    return 2\n",
+            description="rename function",
+        )
+
+        assert await agent._apply_change(change)
+        assert source.read_bytes() == b"def new():\r\n    return 2\r\n\r\nprint(old())\r\n"
+
+    @pytest.mark.asyncio
+    async def test_missing_original_fails(self, agent, tmp_path):
+        """Test that a change whose original text is absent is rejected."""
+        source = tmp_path / "app.py"
+        source.write_text("print('hello')\n")
+
+        change = FileChange(
+            path=str(source),
+            original="print('bye')",
+            modified="print('hi')",
+            description="no-op",
+        )
+
+        assert not await agent._apply_change(change)
+        assert source.read_text() == "print('hello')\n"